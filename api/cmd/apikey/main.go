@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/auth"
+)
+
+// apikey generates an API key and prints it along with the hashed entry to add to
+// the auth.hashed_keys_file JSON array. The raw key is shown once and never stored.
+func main() {
+	id := flag.String("id", "", "Key ID, used in logs and for rate limiting (required)")
+	name := flag.String("name", "", "Human readable name of the key owner")
+	scopes := flag.String("scopes", string(auth.ScopeSearch), "Comma separated scopes: search, jobs, admin")
+	rateLimit := flag.Int("rate-limit", 0, "Requests per minute, 0 for unlimited")
+	burst := flag.Int("burst", 0, "Requests allowed at once above the rate limit")
+	quota := flag.Int("daily-post-quota", 0, "Posts evaluated per UTC day, 0 for unlimited")
+	flag.Parse()
+
+	if *id == "" {
+		log.Fatal("-id is required")
+	}
+
+	key := auth.APIKey{
+		ID:                 *id,
+		Name:               *name,
+		RateLimitPerMinute: *rateLimit,
+		Burst:              *burst,
+		DailyPostQuota:     *quota,
+	}
+	for _, scope := range strings.Split(*scopes, ",") {
+		switch scope := auth.Scope(strings.TrimSpace(scope)); scope {
+		case auth.ScopeSearch, auth.ScopeJobs, auth.ScopeAdmin:
+			key.Scopes = append(key.Scopes, scope)
+		default:
+			log.Fatalf("unknown scope %q", scope)
+		}
+	}
+
+	rawKey := auth.GenerateKey()
+	entry, err := json.MarshalIndent(auth.HashedKey{APIKey: key, KeyHash: auth.HashKey(rawKey)}, "", "  ")
+	if err != nil {
+		log.Fatalf("Error encoding key: %v", err)
+	}
+	fmt.Printf("API key (store it now, it is not saved anywhere):\n%s\n\nEntry for auth.hashed_keys_file:\n%s\n", rawKey, entry)
+}
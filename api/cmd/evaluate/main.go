@@ -0,0 +1,140 @@
+// Command evaluate measures how well the relevance scorer separates relevant from not relevant
+// posts in a labeled dataset and suggests a relevance threshold.
+//
+// Replay recorded embeddings (no network):
+//
+//	go run ./cmd/evaluate -dataset internal/evaluation/testdata/dataset.jsonl -embeddings internal/evaluation/testdata/embeddings.json
+//
+// Record embeddings from the configured LLM endpoint while evaluating:
+//
+//	go run ./cmd/evaluate -dataset internal/evaluation/testdata/dataset.jsonl -embeddings internal/evaluation/testdata/embeddings.json -record
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/app"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/evaluation"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+)
+
+func main() {
+	datasetPath := flag.String("dataset", "", "path to the labeled dataset (JSON Lines)")
+	embeddingsPath := flag.String("embeddings", "", "path to the recorded embeddings (JSON)")
+	record := flag.Bool("record", false, "call the configured LLM endpoint and record embeddings missing from -embeddings")
+	threshold := flag.Float64("threshold", 0.5, "relevance threshold to report metrics for")
+	jsonOutput := flag.Bool("json", false, "print the report as JSON")
+	chunkStrategy := flag.String("chunk-strategy", "", "how chunk scores are combined: max, mean or title_weighted, relevance.chunk_strategy of the config when empty")
+	flag.Parse()
+
+	if *datasetPath == "" {
+		log.Fatal("-dataset is required")
+	}
+	if *embeddingsPath == "" && !*record {
+		log.Fatal("-embeddings is required unless -record is set")
+	}
+	strategy := services.ChunkStrategy(*chunkStrategy)
+	if !strategy.Valid() {
+		log.Fatalf("unknown -chunk-strategy %q", *chunkStrategy)
+	}
+
+	// Posts are chunked as the server chunks them, so the scores match those it returns
+	cfg, err := config.New()
+	if err != nil {
+		log.Fatalf("Error reading config file: %v", err)
+	}
+	preprocess, err := app.PreprocessOptions(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if strategy != "" {
+		preprocess.Strategy = strategy
+	}
+
+	examples, err := evaluation.LoadDataset(*datasetPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	store := evaluation.NewEmbeddingStore()
+	if *embeddingsPath != "" {
+		loaded, err := evaluation.LoadEmbeddingStore(*embeddingsPath)
+		switch {
+		case err == nil:
+			store = loaded
+		case *record && errors.Is(err, fs.ErrNotExist):
+			// Recording into a new file
+		default:
+			log.Fatal(err)
+		}
+	}
+
+	var llmClient llm.ClientInterface = evaluation.NewReplayClient(store)
+	if *record {
+		container, err := app.NewContainer(cfg)
+		if err != nil {
+			log.Fatalf("Error creating application: %v", err)
+		}
+		llmClient = evaluation.NewRecordingClient(container.LLMClient, store)
+	}
+
+	evaluator := evaluation.NewEvaluator(llmClient, services.NewEmbeddingScorerWithOptions(llmClient, preprocess))
+	scored, err := evaluator.Score(context.Background(), examples)
+	if *record && *embeddingsPath != "" {
+		// Keep whatever was recorded, even if scoring stopped part way
+		if saveErr := store.Save(*embeddingsPath); saveErr != nil {
+			log.Printf("Error saving embeddings: %v", saveErr)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := evaluation.NewReport(scored, *threshold)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	printReport(os.Stdout, report)
+}
+
+func printReport(w io.Writer, report evaluation.Report) {
+	fmt.Fprintf(w, "Examples: %d (relevant: %d, not relevant: %d)\n", report.Examples, report.Positives, report.Negatives)
+	fmt.Fprintf(w, "ROC-AUC:  %.4f\n\n", report.ROCAUC)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tThreshold\tPrecision\tRecall\tF1\tTP\tFP\tTN\tFN")
+	for _, row := range []struct {
+		name    string
+		metrics evaluation.ThresholdMetrics
+	}{
+		{"Configured", report.AtThreshold},
+		{"Best F1", report.BestF1},
+	} {
+		m := row.metrics
+		fmt.Fprintf(tw, "%s\t%.4f\t%.4f\t%.4f\t%.4f\t%d\t%d\t%d\t%d\n",
+			row.name, m.Threshold, m.Precision, m.Recall, m.F1,
+			m.TruePositives, m.FalsePositives, m.TrueNegatives, m.FalseNegatives,
+		)
+	}
+	tw.Flush()
+}
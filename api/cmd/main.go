@@ -1,11 +1,14 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/api"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/app"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
 	"go.uber.org/zap"
 )
 
@@ -24,17 +27,36 @@ import (
 // @host      localhost:8080
 // @BasePath  /v1
 
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Required when auth.enabled is set. The key needs the search scope for searches and the admin scope for /admin routes.
+
 func main() {
-	logger := logger.GetLogger()
-	defer logger.Sync()
-	cfg := config.GetConfig()
-	port := cfg.GetString("api.port")
+	cfg, err := config.New()
+	if err != nil {
+		log.Fatalf("Error reading config file: %v", err)
+	}
+
+	container, err := app.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Error creating application: %v", err)
+	}
+	logger := container.Logger
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	logger.Info(
 		"Starting Reddit Content Analyzer API",
 		zap.String("version", "1.0.0"),
-		zap.String("port", fmt.Sprintf(":%s", port)),
+		zap.String("port", container.Server.Addr),
 	)
 
-	api.StartServer()
+	// Run flushes the logger once the server has drained
+	if err := container.Run(ctx); err != nil {
+		logger.Error("Server stopped with error", zap.Error(err))
+		_ = logger.Sync()
+		os.Exit(1)
+	}
 }
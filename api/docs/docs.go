@@ -24,14 +24,163 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/log-level": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the minimum level of the log entries that are written",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the log level",
+                "responses": {
+                    "200": {
+                        "description": "Current log level",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.LogLevelDto"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHENTICATED - missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "FORBIDDEN - API key lacks the admin scope",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Changes the minimum level of the log entries that are written, until the process restarts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Change the log level",
+                "parameters": [
+                    {
+                        "description": "New log level: debug, info, warn or error",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.LogLevelDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Log level changed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.LogLevelDto"
+                        }
+                    },
+                    "400": {
+                        "description": "VALIDATION_FAILED - unknown log level",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHENTICATED - missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "FORBIDDEN - API key lacks the admin scope",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Reports that the process is running and able to serve HTTP requests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Process is alive",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks that the LLM endpoint answers an embedding call and that Reddit is reachable. Results are cached briefly.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Every dependency is up",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_infra_health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "At least one dependency is down",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_infra_health.Report"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/reddit/relevance/search": {
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "Searches Reddit posts based on a topic and returns posts that are relevant according to the specified criteria",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "reddit"
@@ -54,128 +203,1203 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.RelevanceResponseDto"
                         }
-                    },
-                    "400": {
-                        "description": "Bad request - invalid input parameters",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                    },
+                    "400": {
+                        "description": "VALIDATION_FAILED - invalid input parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHENTICATED - missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "FORBIDDEN - API key lacks the search scope",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "SUBREDDIT_NOT_FOUND or SOURCE_NOT_FOUND - a subreddit or source does not exist or is private",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "429": {
+                        "description": "RATE_LIMITED or QUOTA_EXCEEDED - rate limit or daily post quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL - unexpected error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "502": {
+                        "description": "REDDIT_UNAVAILABLE or SOURCE_UNAVAILABLE - Reddit or a source could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "503": {
+                        "description": "REDDIT_RATE_LIMITED or LLM_UNAVAILABLE - an upstream service is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reddit/subreddits/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Finds subreddits with Reddit's subreddit search and name autocomplete, and ranks them by how close their public description is to the topic. NSFW and private subreddits are left out.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "reddit"
+                ],
+                "summary": "Suggest subreddits for a topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic to find subreddits for",
+                        "name": "topic",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 25,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Maximum number of suggestions, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suggested subreddits, most relevant first",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditSuggestionsResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "VALIDATION_FAILED - invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHENTICATED - missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "FORBIDDEN - API key lacks the search scope",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "429": {
+                        "description": "RATE_LIMITED - rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL - unexpected error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "502": {
+                        "description": "REDDIT_UNAVAILABLE - Reddit could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "503": {
+                        "description": "REDDIT_RATE_LIMITED or LLM_UNAVAILABLE - an upstream service is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reddit/trends": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Buckets the posts the stored searches of a topic evaluated by the time they were created, per subreddit, with their relevant posts, average relevance, engagement and sentiment. Buckets with unusually many relevant posts compared to the previous buckets are flagged as spikes. Searches are stored when runs are enabled.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "reddit"
+                ],
+                "summary": "Get the trend of a topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic of the stored searches",
+                        "name": "topic",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Subreddits or communities to include, all by default",
+                        "name": "subreddits",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "description": "Length of the buckets, day by default",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the trend in RFC 3339 format, 30 buckets before to by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the trend in RFC 3339 format, now by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": 2,
+                        "type": "integer",
+                        "description": "Previous buckets the spike baseline averages, 7 by default",
+                        "name": "baseline_window",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Standard deviations above the baseline a spike is, 2 by default",
+                        "name": "spike_threshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trend of every subreddit, most relevant posts first",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TrendResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "VALIDATION_FAILED - invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHENTICATED - missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "FORBIDDEN - API key lacks the search scope",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "429": {
+                        "description": "RATE_LIMITED - rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL - unexpected error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.DuplicatePostDto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "num_comments": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "enum": [
+                        "same_post",
+                        "crosspost",
+                        "url",
+                        "similar"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.DuplicateReason"
+                        }
+                    ]
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "similarity": {
+                    "description": "Similarity is the cosine similarity with the canonical post, for similar duplicates",
+                    "type": "number",
+                    "example": 0.95
+                },
+                "source": {
+                    "type": "string",
+                    "example": "r/programming"
+                },
+                "subreddit_name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.DuplicateReason": {
+            "type": "string",
+            "enum": [
+                "same_post",
+                "crosspost",
+                "url",
+                "similar"
+            ],
+            "x-enum-varnames": [
+                "DuplicateReasonSamePost",
+                "DuplicateReasonCrosspost",
+                "DuplicateReasonURL",
+                "DuplicateReasonSimilar"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.FieldErrorDto": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "subreddits[0]"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be a subreddit name of 2 to 21 letters, digits or underscores"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "subreddit"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.LogLevelDto": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "example": "debug"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.MediaDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description is the description of linked media, such as the oEmbed description of a video",
+                    "type": "string"
+                },
+                "generated_caption": {
+                    "description": "GeneratedCaption is the caption written by the multimodal model, when one is configured",
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.MediaItemDto"
+                    }
+                },
+                "kind": {
+                    "enum": [
+                        "image",
+                        "gallery",
+                        "video"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.MediaKind"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.MediaItemDto": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "description": "Caption is the gallery caption or alt text of the item",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.MediaKind": {
+            "type": "string",
+            "enum": [
+                "image",
+                "gallery",
+                "video"
+            ],
+            "x-enum-varnames": [
+                "MediaKindImage",
+                "MediaKindGallery",
+                "MediaKindVideo"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.ProblemDetails": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SUBREDDIT_NOT_FOUND"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Subreddit r/doesnotexist does not exist or is private"
+                },
+                "errors": {
+                    "description": "Errors lists the rejected fields of a VALIDATION_FAILED problem",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.FieldErrorDto"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/v1/reddit/relevance/search"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c2a7be1d04c3f9a6e2b8d7c1f0e5a"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Subreddit not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/subreddit-not-found"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.RelevanceRequestDto": {
+            "type": "object",
+            "required": [
+                "exclude_topics",
+                "search_method",
+                "topics"
+            ],
+            "properties": {
+                "analyze_sentiment": {
+                    "description": "AnalyzeSentiment has the chat model classify the sentiment of the relevant posts and their\nstance toward the topics",
+                    "type": "boolean"
+                },
+                "created_after": {
+                    "description": "CreatedAfter drops the posts created before it, no bound when zero. Posts whose source\ndoes not tell when they were created, such as feed items without a date, are kept.",
+                    "type": "string"
+                },
+                "deduplicate": {
+                    "description": "Deduplicate groups the crossposts, the posts linking to the same page and the posts whose\nembeddings are at least DuplicateThreshold similar under one canonical post",
+                    "type": "boolean"
+                },
+                "duplicate_threshold": {
+                    "description": "DuplicateThreshold is the cosine similarity from which two posts are near duplicates, 0.92 when zero",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.92
+                },
+                "exclude_topics": {
+                    "description": "ExcludeTopics penalize the posts that are about them",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "job postings"
+                    ]
+                },
+                "exclusion_penalty": {
+                    "description": "ExclusionPenalty is the share of the relevance score a penalized post loses, 0.5 when zero",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.5
+                },
+                "exclusion_threshold": {
+                    "description": "ExclusionThreshold is the score against an exclusion topic from which a post is penalized, 0.6 when zero",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.6
+                },
+                "expand_query": {
+                    "description": "ExpandQuery has the chat model rewrite the topics into several search queries, for the search method",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 25
+                },
+                "max_search_queries": {
+                    "description": "MaxSearchQueries caps the search queries run per subreddit or source when ExpandQuery is set, 3 when zero",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "min_num_comments": {
+                    "description": "MinNumComments drops the posts with fewer comments. Feed items have no comment count,\nso any value above zero drops them.",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "relevance_threshold": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.6
+                },
+                "scope": {
+                    "description": "Scope fetches the subreddits one by one, together as a multireddit or searches all of\nReddit, subreddit when empty. Limit applies to every Reddit request.",
+                    "enum": [
+                        "subreddit",
+                        "multireddit",
+                        "all"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchScope"
+                        }
+                    ]
+                },
+                "search_method": {
+                    "enum": [
+                        "search",
+                        "latest",
+                        "hot",
+                        "new",
+                        "top",
+                        "rising",
+                        "controversial"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchMethod"
+                        }
+                    ]
+                },
+                "search_sort": {
+                    "description": "SearchSort orders the results of the search method, relevance when empty",
+                    "enum": [
+                        "relevance",
+                        "new",
+                        "comments"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchSort"
+                        }
+                    ]
+                },
+                "sentiment_comments": {
+                    "description": "SentimentComments is how many top comments of every relevant Reddit post are classified\nalong with it when AnalyzeSentiment is set, none when zero",
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 0,
+                    "example": 10
+                },
+                "sources": {
+                    "description": "Sources are analyzed along with the subreddits: user:{name} for the posts a Reddit user\nsubmitted, domain:{host} for the Reddit posts linking to a domain, hackernews:{tag} for Hacker\nNews items, feed:{url} for RSS and Atom feeds and lemmy:{community@instance} for Lemmy\ncommunities. Hacker News and Lemmy run every search query of the search method, as the\nsubreddits do, the others are fetched from their listing, sorted as the listing search methods say.",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user:spez",
+                        "hackernews:story"
+                    ]
+                },
+                "subreddits": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "programming"
+                    ]
+                },
+                "time_window": {
+                    "description": "TimeWindow restricts the search, top and controversial methods to recent posts. Search\ncovers all time and top and controversial the last day when empty.",
+                    "enum": [
+                        "hour",
+                        "day",
+                        "week",
+                        "month",
+                        "year",
+                        "all"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TimeWindow"
+                        }
+                    ]
+                },
+                "topic": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "golang generics"
+                },
+                "topic_aggregation": {
+                    "description": "TopicAggregation combines the scores of the topics, max when empty",
+                    "enum": [
+                        "max",
+                        "mean"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TopicAggregation"
+                        }
+                    ]
+                },
+                "topics": {
+                    "description": "Topics are scored along with Topic, a post is relevant to their max or mean score",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang iterators"
+                    ]
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.RelevanceResponseDto": {
+            "type": "object",
+            "properties": {
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubRedditPostDto"
+                    }
+                },
+                "search_queries": {
+                    "description": "SearchQueries are the Reddit search queries that were run in every subreddit",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sentiment_by_subreddit": {
+                    "description": "SentimentBySubreddit totals the sentiments and stances of the relevant posts of every\nsubreddit or community, in the order they first appear, when the request analyzes sentiment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditSentimentDto"
+                    }
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchMethod": {
+            "type": "string",
+            "enum": [
+                "search",
+                "latest",
+                "hot",
+                "new",
+                "top",
+                "rising",
+                "controversial"
+            ],
+            "x-enum-varnames": [
+                "SearchMethodSearch",
+                "SearchMethodLatest",
+                "SearchMethodHot",
+                "SearchMethodNew",
+                "SearchMethodTop",
+                "SearchMethodRising",
+                "SearchMethodControversial"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchScope": {
+            "type": "string",
+            "enum": [
+                "subreddit",
+                "multireddit",
+                "all"
+            ],
+            "x-enum-varnames": [
+                "SearchScopeSubreddit",
+                "SearchScopeMultireddit",
+                "SearchScopeAll"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchSort": {
+            "type": "string",
+            "enum": [
+                "relevance",
+                "new",
+                "comments"
+            ],
+            "x-enum-varnames": [
+                "SearchSortRelevance",
+                "SearchSortNew",
+                "SearchSortComments"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.Sentiment": {
+            "type": "string",
+            "enum": [
+                "positive",
+                "negative",
+                "neutral",
+                "mixed"
+            ],
+            "x-enum-varnames": [
+                "SentimentPositive",
+                "SentimentNegative",
+                "SentimentNeutral",
+                "SentimentMixed"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentBreakdownDto": {
+            "type": "object",
+            "properties": {
+                "sentiments": {
+                    "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentCountsDto"
+                },
+                "stances": {
+                    "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.StanceCountsDto"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentCountsDto": {
+            "type": "object",
+            "properties": {
+                "mixed": {
+                    "type": "integer"
+                },
+                "negative": {
+                    "type": "integer"
+                },
+                "neutral": {
+                    "type": "integer"
+                },
+                "positive": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentDto": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "description": "Comments totals the classified comments, when the request classifies comments and the post has some",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentBreakdownDto"
+                        }
+                    ]
+                },
+                "sentiment": {
+                    "enum": [
+                        "positive",
+                        "negative",
+                        "neutral",
+                        "mixed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.Sentiment"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                    ]
+                },
+                "stance": {
+                    "enum": [
+                        "favor",
+                        "against",
+                        "neutral"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.Stance"
                         }
-                    }
+                    ]
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.RelevanceRequestDto": {
-            "type": "object",
-            "required": [
-                "search_method",
-                "topic"
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.Stance": {
+            "type": "string",
+            "enum": [
+                "favor",
+                "against",
+                "neutral"
             ],
+            "x-enum-varnames": [
+                "StanceFavor",
+                "StanceAgainst",
+                "StanceNeutral"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.StanceCountsDto": {
+            "type": "object",
             "properties": {
-                "created_after": {
+                "against": {
+                    "type": "integer"
+                },
+                "favor": {
+                    "type": "integer"
+                },
+                "neutral": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubRedditPostDto": {
+            "type": "object",
+            "properties": {
+                "author": {
                     "type": "string"
                 },
-                "limit": {
+                "combined_score": {
+                    "description": "CombinedScore is the score of the post and its duplicates, when the request deduplicates posts",
                     "type": "integer"
                 },
-                "min_num_comments": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duplicates": {
+                    "description": "Duplicates are the posts grouped under this one, which is the one with the highest score",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.DuplicatePostDto"
+                    }
+                },
+                "exclusion_scores": {
+                    "description": "ExclusionScores are the scores of the post against each exclusion topic of the request",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TopicScoreDto"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_excluded": {
+                    "description": "IsExcluded is set when the relevance score was penalized for an exclusion topic",
+                    "type": "boolean"
+                },
+                "is_relevant": {
+                    "type": "boolean"
+                },
+                "matched_queries": {
+                    "description": "MatchedQueries are the search queries that found the post",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "media": {
+                    "description": "Media describes the images or video of media posts",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.MediaDto"
+                        }
+                    ]
+                },
+                "num_comments": {
                     "type": "integer"
                 },
-                "relevance_threshold": {
+                "relevance_score": {
                     "type": "number"
                 },
-                "search_method": {
-                    "enum": [
-                        "search",
-                        "latest"
-                    ],
+                "relevance_summary": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "sentiment": {
+                    "description": "Sentiment is the sentiment and stance of relevant posts, when the request analyzes sentiment",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchMethod"
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentDto"
                         }
                     ]
                 },
+                "source": {
+                    "description": "Source is the source of the request that returned the post: r/{subreddit}, user:{name},\ndomain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}",
+                    "type": "string",
+                    "example": "r/golang"
+                },
+                "subreddit_name": {
+                    "type": "string"
+                },
                 "subreddits": {
+                    "description": "Subreddits are the subreddits and communities the post and its duplicates appeared in,\nthe one of the post first, when the request deduplicates posts",
                     "type": "array",
                     "items": {
                         "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "programming"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "topic_scores": {
+                    "description": "TopicScores are the scores of the post against each topic of the request",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TopicScoreDto"
                     }
                 },
-                "topic": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.RelevanceResponseDto": {
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditSentimentDto": {
             "type": "object",
             "properties": {
+                "comments": {
+                    "description": "Comments totals the classified comments of the posts, zero when comments are not classified",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentBreakdownDto"
+                        }
+                    ]
+                },
                 "posts": {
+                    "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentBreakdownDto"
+                },
+                "subreddit_name": {
+                    "type": "string",
+                    "example": "golang"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditSuggestionDto": {
+            "type": "object",
+            "properties": {
+                "active_users": {
+                    "type": "integer",
+                    "example": 420
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Ask questions and post articles about the Go programming language and related tools, events etc."
+                },
+                "name": {
+                    "type": "string",
+                    "example": "golang"
+                },
+                "relevance_score": {
+                    "type": "number",
+                    "example": 0.81
+                },
+                "subscribers": {
+                    "type": "integer",
+                    "example": 310000
+                },
+                "title": {
+                    "type": "string",
+                    "example": "The Go Programming Language"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.reddit.com/r/golang/"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditSuggestionsResponseDto": {
+            "type": "object",
+            "properties": {
+                "suggestions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubRedditPostDto"
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditSuggestionDto"
                     }
                 }
             }
         },
-        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SearchMethod": {
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditTrendDto": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TrendBucketDto"
+                    }
+                },
+                "relevant_posts": {
+                    "description": "RelevantPosts is the total of the buckets",
+                    "type": "integer"
+                },
+                "subreddit_name": {
+                    "type": "string",
+                    "example": "golang"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TimeWindow": {
             "type": "string",
             "enum": [
-                "search",
-                "latest"
+                "hour",
+                "day",
+                "week",
+                "month",
+                "year",
+                "all"
             ],
             "x-enum-varnames": [
-                "SearchMethodSearch",
-                "SearchMethodLatest"
+                "TimeWindowHour",
+                "TimeWindowDay",
+                "TimeWindowWeek",
+                "TimeWindowMonth",
+                "TimeWindowYear",
+                "TimeWindowAll"
             ]
         },
-        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubRedditPostDto": {
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TopicAggregation": {
+            "type": "string",
+            "enum": [
+                "max",
+                "mean"
+            ],
+            "x-enum-varnames": [
+                "TopicAggregationMax",
+                "TopicAggregationMean"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TopicScoreDto": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
+                "score": {
+                    "type": "number",
+                    "example": 0.72
                 },
-                "created_at": {
-                    "type": "string"
+                "topic": {
+                    "type": "string",
+                    "example": "golang generics"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TrendBucketDto": {
+            "type": "object",
+            "properties": {
+                "average_comments": {
+                    "type": "number"
                 },
-                "is_relevant": {
+                "average_relevance": {
+                    "description": "AverageRelevance is the mean relevance score of the evaluated posts",
+                    "type": "number"
+                },
+                "average_score": {
+                    "description": "AverageScore and AverageComments are the mean engagement of the relevant posts",
+                    "type": "number"
+                },
+                "baseline": {
+                    "description": "Baseline is the mean of the relevant posts of the previous buckets of the baseline window,\nwhich reaches before From for the first buckets",
+                    "type": "number"
+                },
+                "is_spike": {
+                    "description": "IsSpike is set when the relevant posts are unusually high compared to the baseline",
                     "type": "boolean"
                 },
-                "num_comments": {
+                "posts": {
+                    "description": "Posts is how many posts were evaluated, relevant or not",
                     "type": "integer"
                 },
-                "relevance_score": {
-                    "type": "number"
+                "relevant_posts": {
+                    "type": "integer"
                 },
-                "relevance_summary": {
+                "sentiments": {
+                    "description": "Sentiments and Stances count the relevant posts whose sentiment was analyzed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SentimentCountsDto"
+                        }
+                    ]
+                },
+                "stances": {
+                    "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.StanceCountsDto"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TrendGranularity": {
+            "type": "string",
+            "enum": [
+                "hour",
+                "day",
+                "week"
+            ],
+            "x-enum-varnames": [
+                "TrendGranularityHour",
+                "TrendGranularityDay",
+                "TrendGranularityWeek"
+            ]
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TrendResponseDto": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From is the start of the first bucket and To the end of the trend",
                     "type": "string"
                 },
-                "score": {
+                "granularity": {
+                    "enum": [
+                        "hour",
+                        "day",
+                        "week"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.TrendGranularity"
+                        }
+                    ]
+                },
+                "runs": {
+                    "description": "Runs is how many stored runs of the topic the trend is built from",
                     "type": "integer"
                 },
-                "subreddit_name": {
+                "subreddits": {
+                    "description": "Subreddits are the trends of the subreddits and communities with posts, most relevant posts first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_contracts.SubredditTrendDto"
+                    }
+                },
+                "to": {
                     "type": "string"
                 },
-                "title": {
+                "topic": {
+                    "type": "string",
+                    "example": "golang generics"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_infra_health.Report": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean"
+                },
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_ReyOrtiz_reddit-content-analyzer_internal_infra_health.Result"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_ReyOrtiz_reddit-content-analyzer_internal_infra_health.Result": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
                     "type": "string"
                 },
-                "url": {
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Required when auth.enabled is set. The key needs the search scope for searches and the admin scope for /admin routes.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+)
+
+type AdminHandler struct {
+	level  zap.AtomicLevel
+	logger *zap.Logger
+}
+
+func NewAdminHandler(level zap.AtomicLevel, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		level:  level,
+		logger: logger,
+	}
+}
+
+// GetLogLevel godoc
+// @Summary      Get the log level
+// @Description  Returns the minimum level of the log entries that are written
+// @Tags         admin
+// @Produce      json
+// @Produce      application/problem+json
+// @Success      200  {object}  contracts.LogLevelDto  "Current log level"
+// @Failure      401  {object}  contracts.ProblemDetails  "UNAUTHENTICATED - missing or invalid API key"
+// @Failure      403  {object}  contracts.ProblemDetails  "FORBIDDEN - API key lacks the admin scope"
+// @Security     ApiKeyAuth
+// @Router       /admin/log-level [get]
+func (h *AdminHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, contracts.LogLevelDto{Level: h.level.Level().String()})
+}
+
+// SetLogLevel godoc
+// @Summary      Change the log level
+// @Description  Changes the minimum level of the log entries that are written, until the process restarts
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Produce      application/problem+json
+// @Param        request  body      contracts.LogLevelDto  true  "New log level: debug, info, warn or error"
+// @Success      200      {object}  contracts.LogLevelDto  "Log level changed"
+// @Failure      400      {object}  contracts.ProblemDetails  "VALIDATION_FAILED - unknown log level"
+// @Failure      401      {object}  contracts.ProblemDetails  "UNAUTHENTICATED - missing or invalid API key"
+// @Failure      403      {object}  contracts.ProblemDetails  "FORBIDDEN - API key lacks the admin scope"
+// @Security     ApiKeyAuth
+// @Router       /admin/log-level [put]
+func (h *AdminHandler) SetLogLevel(c *gin.Context) {
+	var request contracts.LogLevelDto
+	if err := bindJSON(c, &request); err != nil {
+		AbortWithProblem(c, err)
+		return
+	}
+	level, err := logger.ParseLevel(request.Level)
+	if err != nil {
+		AbortWithProblem(c, apperrors.Validation(err, []apperrors.FieldError{{
+			Field:   "level",
+			Rule:    "oneof",
+			Message: "must be one of debug, info, warn, error",
+		}}))
+		return
+	}
+
+	previous := h.level.Level()
+	h.level.SetLevel(level)
+	logger.FromContext(c.Request.Context(), h.logger).Warn("Log level changed",
+		zap.Stringer("from", previous),
+		zap.Stringer("to", level),
+	)
+	c.JSON(http.StatusOK, contracts.LogLevelDto{Level: level.String()})
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// Admin Handler Tests
+// ============================================================================
+
+func TestAdminHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(handler gin.HandlerFunc, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PUT", "/admin/log-level", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler(c)
+		return w
+	}
+
+	t.Run("GetLogLevel", func(t *testing.T) {
+		// Arrange
+		handler := NewAdminHandler(zap.NewAtomicLevelAt(zap.WarnLevel), zap.NewNop())
+
+		// Act
+		w := serve(handler.GetLogLevel, "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"level":"warn"}`, w.Body.String())
+	})
+
+	t.Run("SetLogLevel", func(t *testing.T) {
+		// Arrange
+		level := zap.NewAtomicLevelAt(zap.InfoLevel)
+		handler := NewAdminHandler(level, zap.NewNop())
+
+		// Act
+		w := serve(handler.SetLogLevel, `{"level":"debug"}`)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"level":"debug"}`, w.Body.String())
+		assert.Equal(t, zap.DebugLevel, level.Level())
+	})
+
+	t.Run("UnknownLevel", func(t *testing.T) {
+		// Arrange
+		level := zap.NewAtomicLevelAt(zap.InfoLevel)
+		handler := NewAdminHandler(level, zap.NewNop())
+
+		// Act
+		w := serve(handler.SetLogLevel, `{"level":"verbose"}`)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, zap.InfoLevel, level.Level())
+	})
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/auth"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+)
+
+const (
+	// APIKeyHeader is the header carrying the API key. Authorization: Bearer <key> is accepted too.
+	APIKeyHeader = "X-API-Key"
+	// PostsEvaluatedKey is the gin context key handlers set to the number of posts they evaluated,
+	// which is charged to the daily post quota of the caller
+	PostsEvaluatedKey = "posts_evaluated"
+
+	apiKeyContextKey       = "api_key"
+	postReserverContextKey = "post_reserver"
+)
+
+// Auth authenticates requests with API keys and enforces their scopes, rate limits and quotas
+type Auth struct {
+	store  auth.KeyStore
+	limits *auth.Limits
+	logger *zap.Logger
+}
+
+func NewAuth(store auth.KeyStore, limits *auth.Limits, logger *zap.Logger) *Auth {
+	return &Auth{
+		store:  store,
+		limits: limits,
+		logger: logger,
+	}
+}
+
+// Require returns the middlewares that admit requests whose API key was granted scope and is
+// within its rate limit
+func (a *Auth) Require(scope auth.Scope) []gin.HandlerFunc {
+	return []gin.HandlerFunc{a.authenticate, a.requireScope(scope), a.rateLimit}
+}
+
+// authenticate resolves the API key of the request, answering 401 when it is missing or unknown
+func (a *Auth) authenticate(c *gin.Context) {
+	rawKey := c.GetHeader(APIKeyHeader)
+	if rawKey == "" {
+		if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			rawKey = strings.TrimSpace(token)
+		}
+	}
+	if rawKey == "" {
+		abortWithAuthError(c, apperrors.CodeUnauthenticated, "An API key is required")
+		return
+	}
+
+	key, err := a.store.Lookup(c.Request.Context(), rawKey)
+	if err != nil {
+		if !errors.Is(err, auth.ErrKeyNotFound) {
+			logger.FromContext(c.Request.Context(), a.logger).Error("Error looking up API key", zap.Error(err))
+		}
+		abortWithAuthError(c, apperrors.CodeUnauthenticated, "The API key is invalid")
+		return
+	}
+
+	c.Set(apiKeyContextKey, key)
+	c.Request = c.Request.WithContext(logger.WithFields(c.Request.Context(), a.logger, zap.String("api_key_id", key.ID)))
+	c.Next()
+}
+
+// requireScope answers 403 when the API key was not granted scope
+func (a *Auth) requireScope(scope auth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !apiKeyFromContext(c).HasScope(scope) {
+			abortWithAuthError(c, apperrors.CodeForbidden, "The API key is not allowed the "+string(scope)+" scope")
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimit answers 429 when the API key exceeded its request rate
+func (a *Auth) rateLimit(c *gin.Context) {
+	if ok, retryAfter := a.limits.Allow(apiKeyFromContext(c)); !ok {
+		setRetryAfter(c, retryAfter)
+		abortWithAuthError(c, apperrors.CodeRateLimited, "Too many requests for this API key")
+		return
+	}
+	c.Next()
+}
+
+// PostQuota answers 429 when the API key has used up its daily post quota. The handler reserves
+// the most posts the request can evaluate with reservePosts, and once it returns the reserved
+// posts beyond those it reports through PostsEvaluatedKey are refunded.
+func (a *Auth) PostQuota(c *gin.Context) {
+	key := apiKeyFromContext(c)
+	if remaining, ok := a.limits.RemainingPosts(key); ok && remaining == 0 {
+		setRetryAfter(c, a.limits.ResetIn())
+		abortWithAuthError(c, apperrors.CodeQuotaExceeded, "The daily post quota of this API key is used up")
+		return
+	}
+
+	reserved := 0
+	c.Set(postReserverContextKey, func(posts int) error {
+		if !a.limits.ReservePosts(key, posts) {
+			setRetryAfter(c, a.limits.ResetIn())
+			return apperrors.New(apperrors.CodeQuotaExceeded, "The request may evaluate more posts than remain in the daily post quota of this API key")
+		}
+		reserved += posts
+		return nil
+	})
+	c.Next()
+	a.limits.RefundPosts(key, reserved-c.GetInt(PostsEvaluatedKey))
+}
+
+// reservePosts charges posts to the daily post quota of the API key before they are evaluated,
+// failing with QUOTA_EXCEEDED when fewer remain. Requests outside PostQuota are not limited.
+func reservePosts(c *gin.Context, posts int) error {
+	reserve, ok := c.Value(postReserverContextKey).(func(posts int) error)
+	if !ok {
+		return nil
+	}
+	return reserve(posts)
+}
+
+func apiKeyFromContext(c *gin.Context) *auth.APIKey {
+	return c.MustGet(apiKeyContextKey).(*auth.APIKey)
+}
+
+func setRetryAfter(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+}
+
+func abortWithAuthError(c *gin.Context, code apperrors.Code, message string) {
+	AbortWithProblem(c, apperrors.New(code, message))
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/auth"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/health"
+	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
+)
+
+// ============================================================================
+// Auth Middleware Tests
+// ============================================================================
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(t *testing.T, relevanceService *mock_services.MockRelevanceService, keys ...auth.StaticKey) http.Handler {
+		store, err := auth.NewStaticKeyStore(keys)
+		require.NoError(t, err)
+		level := zap.NewAtomicLevel()
+		return NewRouter(Dependencies{
+			Logger:           zap.NewNop(),
+			RelevanceService: relevanceService,
+			Readiness:        health.NewChecker(time.Second, time.Second),
+			LogLevel:         &level,
+			Auth:             NewAuth(store, auth.NewLimits(), zap.NewNop()),
+		})
+	}
+	searchBody := func(router http.Handler, body, header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/v1/reddit/relevance/search", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	search := func(router http.Handler, header, value string) *httptest.ResponseRecorder {
+		return searchBody(router, `{"topic":"go","subreddits":["golang"],"limit":2,"search_method":"search"}`, header, value)
+	}
+	errorCode := func(w *httptest.ResponseRecorder) string {
+		var body map[string]string
+		json.Unmarshal(w.Body.Bytes(), &body)
+		return body["code"]
+	}
+	twoPosts := contracts.RelevanceResponseDto{Posts: []contracts.SubRedditPostDto{{Title: "a"}, {Title: "b"}}}
+
+	t.Run("MissingKey", func(t *testing.T) {
+		// Arrange
+		router := newRouter(t, mock_services.NewMockRelevanceService(t),
+			auth.StaticKey{APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}}, Key: "secret"})
+
+		// Act
+		w := search(router, "", "")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "UNAUTHENTICATED", errorCode(w))
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		// Arrange
+		router := newRouter(t, mock_services.NewMockRelevanceService(t),
+			auth.StaticKey{APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}}, Key: "secret"})
+
+		// Act
+		w := search(router, APIKeyHeader, "guess")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("ValidKey", func(t *testing.T) {
+		// Arrange
+		mockRelevanceService := mock_services.NewMockRelevanceService(t)
+		mockRelevanceService.EXPECT().GetRelevantPosts(mock.Anything, mock.Anything).Return(twoPosts, nil).Twice()
+		router := newRouter(t, mockRelevanceService,
+			auth.StaticKey{APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}}, Key: "secret"})
+
+		// Act
+		headerResponse := search(router, APIKeyHeader, "secret")
+		bearerResponse := search(router, "Authorization", "Bearer secret")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, headerResponse.Code)
+		assert.Equal(t, http.StatusOK, bearerResponse.Code)
+	})
+
+	t.Run("MissingScope", func(t *testing.T) {
+		// Arrange
+		router := newRouter(t, mock_services.NewMockRelevanceService(t),
+			auth.StaticKey{APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}}, Key: "secret"})
+		req := httptest.NewRequest("GET", "/admin/log-level", nil)
+		req.Header.Set(APIKeyHeader, "secret")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, "FORBIDDEN", errorCode(w))
+	})
+
+	t.Run("RateLimited", func(t *testing.T) {
+		// Arrange
+		mockRelevanceService := mock_services.NewMockRelevanceService(t)
+		mockRelevanceService.EXPECT().GetRelevantPosts(mock.Anything, mock.Anything).Return(twoPosts, nil).Once()
+		router := newRouter(t, mockRelevanceService, auth.StaticKey{
+			APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}, RateLimitPerMinute: 1, Burst: 1},
+			Key:    "secret",
+		})
+
+		// Act
+		first := search(router, APIKeyHeader, "secret")
+		second := search(router, APIKeyHeader, "secret")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusTooManyRequests, second.Code)
+		assert.Equal(t, "RATE_LIMITED", errorCode(second))
+		assert.NotEmpty(t, second.Header().Get("Retry-After"))
+	})
+
+	t.Run("DailyPostQuota", func(t *testing.T) {
+		// Arrange
+		mockRelevanceService := mock_services.NewMockRelevanceService(t)
+		mockRelevanceService.EXPECT().GetRelevantPosts(mock.Anything, mock.Anything).Return(twoPosts, nil).Once()
+		router := newRouter(t, mockRelevanceService, auth.StaticKey{
+			APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}, DailyPostQuota: 2},
+			Key:    "secret",
+		})
+
+		// Act
+		first := search(router, APIKeyHeader, "secret")
+		second := search(router, APIKeyHeader, "secret")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusTooManyRequests, second.Code)
+		assert.Equal(t, "QUOTA_EXCEEDED", errorCode(second))
+	})
+
+	t.Run("ReservesMaxPosts", func(t *testing.T) {
+		// Arrange
+		router := newRouter(t, mock_services.NewMockRelevanceService(t), auth.StaticKey{
+			APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}, DailyPostQuota: 5},
+			Key:    "secret",
+		})
+		// 2 posts for each of the 2 subreddits and the source
+		body := `{"topic":"go","subreddits":["golang","rust"],"sources":["hackernews:story"],"limit":2,"search_method":"search"}`
+
+		// Act
+		w := searchBody(router, body, APIKeyHeader, "secret")
+
+		// Assert
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "QUOTA_EXCEEDED", errorCode(w))
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("RefundsUnusedPosts", func(t *testing.T) {
+		// Arrange
+		onePost := contracts.RelevanceResponseDto{Posts: []contracts.SubRedditPostDto{{Title: "a"}}}
+		mockRelevanceService := mock_services.NewMockRelevanceService(t)
+		mockRelevanceService.EXPECT().GetRelevantPosts(mock.Anything, mock.Anything).Return(onePost, nil).Times(3)
+		router := newRouter(t, mockRelevanceService, auth.StaticKey{
+			APIKey: auth.APIKey{ID: "web", Scopes: []auth.Scope{auth.ScopeSearch}, DailyPostQuota: 4},
+			Key:    "secret",
+		})
+
+		// Act
+		first := search(router, APIKeyHeader, "secret")
+		second := search(router, APIKeyHeader, "secret")
+		third := search(router, APIKeyHeader, "secret")
+		fourth := search(router, APIKeyHeader, "secret")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, http.StatusOK, third.Code)
+		// 3 posts were evaluated, the 2 the fourth request may evaluate exceed the 1 left
+		assert.Equal(t, http.StatusTooManyRequests, fourth.Code)
+	})
+
+	t.Run("ProbesStayOpen", func(t *testing.T) {
+		// Arrange
+		router := newRouter(t, mock_services.NewMockRelevanceService(t),
+			auth.StaticKey{APIKey: auth.APIKey{ID: "web"}, Key: "secret"})
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -4,10 +4,14 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
 )
 
@@ -16,9 +20,9 @@ type RelevanceHandler struct {
 	relevanceService services.RelevanceService
 }
 
-func NewRelevanceHandler(relevanceService services.RelevanceService) *RelevanceHandler {
+func NewRelevanceHandler(relevanceService services.RelevanceService, logger *zap.Logger) *RelevanceHandler {
 	return &RelevanceHandler{
-		logger:           logger.GetLogger(),
+		logger:           logger,
 		relevanceService: relevanceService,
 	}
 }
@@ -29,26 +33,61 @@ func NewRelevanceHandler(relevanceService services.RelevanceService) *RelevanceH
 // @Tags         reddit
 // @Accept       json
 // @Produce      json
+// @Produce      application/problem+json
 // @Param        request  body      contracts.RelevanceRequestDto  true  "Search request parameters"
 // @Success      200      {object}  contracts.RelevanceResponseDto  "Successful response with relevant posts"
-// @Failure      400      {object}  map[string]string              "Bad request - invalid input parameters"
-// @Failure      500      {object}  map[string]string              "Internal server error"
+// @Failure      400      {object}  contracts.ProblemDetails       "VALIDATION_FAILED - invalid input parameters"
+// @Failure      401      {object}  contracts.ProblemDetails       "UNAUTHENTICATED - missing or invalid API key"
+// @Failure      403      {object}  contracts.ProblemDetails       "FORBIDDEN - API key lacks the search scope"
+// @Failure      404      {object}  contracts.ProblemDetails       "SUBREDDIT_NOT_FOUND or SOURCE_NOT_FOUND - a subreddit or source does not exist or is private"
+// @Failure      429      {object}  contracts.ProblemDetails       "RATE_LIMITED or QUOTA_EXCEEDED - rate limit or daily post quota exceeded"
+// @Failure      500      {object}  contracts.ProblemDetails       "INTERNAL - unexpected error"
+// @Failure      502      {object}  contracts.ProblemDetails       "REDDIT_UNAVAILABLE or SOURCE_UNAVAILABLE - Reddit or a source could not be reached"
+// @Failure      503      {object}  contracts.ProblemDetails       "REDDIT_RATE_LIMITED or LLM_UNAVAILABLE - an upstream service is unavailable"
+// @Security     ApiKeyAuth
 // @Router       /v1/reddit/relevance/search [post]
 func (h *RelevanceHandler) GetRelevantPosts(c *gin.Context) {
-	h.logger.Info("Searching Reddit posts", zap.Any("request", c.Request.Body))
+	ctx, span := tracing.Start(c.Request.Context(), "RelevanceHandler.GetRelevantPosts")
+	defer span.End()
 
 	var request contracts.RelevanceRequestDto
-	if err := c.ShouldBindJSON(&request); err != nil {
-		h.logger.Error("Error binding request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &request); err != nil {
+		logger.FromContext(ctx, h.logger).Warn("Invalid request", zap.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		AbortWithProblem(c, err)
 		return
 	}
 
-	response, err := h.relevanceService.GetRelevantPosts(c.Request.Context(), request)
+	topics := services.RequestTopics(request)
+	span.SetAttributes(
+		attribute.StringSlice("relevance.topics", topics),
+		attribute.StringSlice("relevance.exclude_topics", request.ExcludeTopics),
+		attribute.StringSlice("relevance.subreddits", request.Subreddits),
+		attribute.String("relevance.search_method", string(request.SearchMethod)),
+	)
+
+	ctx = logger.WithFields(ctx, h.logger,
+		zap.Strings("topics", topics),
+		zap.Strings("subreddits", request.Subreddits),
+	)
+	log := logger.FromContext(ctx, h.logger)
+	maxPosts := services.MaxPosts(request)
+	if err := reservePosts(c, maxPosts); err != nil {
+		log.Warn("Daily post quota exceeded", zap.Int("max_posts", maxPosts))
+		span.SetStatus(codes.Error, err.Error())
+		AbortWithProblem(c, err)
+		return
+	}
+	log.Info("Searching Reddit posts", zap.String("search_method", string(request.SearchMethod)))
+
+	response, err := h.relevanceService.GetRelevantPosts(ctx, request)
 	if err != nil {
-		h.logger.Error("Error searching Reddit posts", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		log.Error("Error searching Reddit posts", zap.Error(err), zap.String("code", string(apperrors.CodeOf(err))))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		AbortWithProblem(c, err)
 		return
 	}
+	c.Set(PostsEvaluatedKey, len(response.Posts))
 	c.JSON(http.StatusOK, response)
 }
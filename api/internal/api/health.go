@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/health"
+)
+
+type HealthHandler struct {
+	readiness *health.Checker
+}
+
+func NewHealthHandler(readiness *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		readiness: readiness,
+	}
+}
+
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is running and able to serve HTTP requests
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  map[string]string  "Process is alive"
+// @Router       /healthz [get]
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
+
+// Readiness godoc
+// @Summary      Readiness probe
+// @Description  Checks that the LLM endpoint answers an embedding call and that Reddit is reachable. Results are cached briefly.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Report  "Every dependency is up"
+// @Failure      503  {object}  health.Report  "At least one dependency is down"
+// @Router       /readyz [get]
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	report := h.readiness.Check(c.Request.Context())
+	if !report.Ready() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/health"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+)
+
+// ============================================================================
+// Health Handler Tests
+// ============================================================================
+
+func TestHealthHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(handler gin.HandlerFunc) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		handler(c)
+		return w
+	}
+
+	t.Run("Liveness", func(t *testing.T) {
+		// Arrange
+		handler := NewHealthHandler(health.NewChecker(time.Second, time.Second))
+
+		// Act
+		w := serve(handler.Liveness)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"up"}`, w.Body.String())
+	})
+
+	t.Run("ReadinessUp", func(t *testing.T) {
+		// Arrange
+		handler := NewHealthHandler(health.NewChecker(time.Second, time.Second,
+			health.Check{Name: "llm", Check: func(ctx context.Context) error { return nil }},
+		))
+
+		// Act
+		w := serve(handler.Readiness)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var report health.Report
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, health.StatusUp, report.Checks["llm"].Status)
+	})
+
+	t.Run("ReadinessDown", func(t *testing.T) {
+		// Arrange
+		handler := NewHealthHandler(health.NewChecker(time.Second, time.Second,
+			health.Check{Name: "llm", Check: func(ctx context.Context) error { return nil }},
+			health.Check{Name: "reddit", Check: func(ctx context.Context) error { return errors.New("reddit API returned status 503") }},
+		))
+
+		// Act
+		w := serve(handler.Readiness)
+
+		// Assert
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var report health.Report
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, "unreachable", report.Checks["reddit"].Error)
+	})
+
+	t.Run("ReadinessHidesUpstreamErrors", func(t *testing.T) {
+		// Arrange
+		upstreamBody := `{"error":"model not loaded on http://10.0.0.5:1234"}`
+		handler := NewHealthHandler(health.NewChecker(time.Second, time.Second,
+			health.Check{Name: "llm", Check: func(ctx context.Context) error {
+				return fmt.Errorf("failed to get embedding: %w", &llm.APIError{StatusCode: http.StatusServiceUnavailable, Body: upstreamBody})
+			}},
+			health.Check{Name: "reddit", Check: func(ctx context.Context) error {
+				return errors.New(`failed to make request: Get "http://reddit.internal/r/popular/.json": dial tcp 10.0.0.6:80: connection refused`)
+			}},
+		))
+
+		// Act
+		w := serve(handler.Readiness)
+
+		// Assert
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.NotContains(t, w.Body.String(), "10.0.0.")
+		assert.NotContains(t, w.Body.String(), "model not loaded")
+		assert.NotContains(t, w.Body.String(), "reddit.internal")
+		var report health.Report
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, "status 503", report.Checks["llm"].Error)
+		assert.Equal(t, "unreachable", report.Checks["reddit"].Error)
+	})
+}
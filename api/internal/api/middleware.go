@@ -0,0 +1,63 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+)
+
+// RequestIDHeader is the header that carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+const (
+	maxRequestIDLength  = 128
+	requestIDContextKey = "request_id"
+)
+
+// RequestID tags every request with an ID, taken from the X-Request-ID header when the caller
+// sends a valid one and generated otherwise. The ID is echoed in the response header and added
+// to the logger carried by the request context, so every log line of the request can be correlated.
+func RequestID(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Set(requestIDContextKey, requestID)
+
+		ctx := c.Request.Context()
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("http.request_id", requestID))
+		ctx = logger.WithFields(ctx, log, zap.String("request_id", requestID))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// validRequestID accepts short IDs made of characters that are safe to echo back and log
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read never returns an error
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
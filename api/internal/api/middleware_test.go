@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+)
+
+// ============================================================================
+// Request ID Middleware Tests
+// ============================================================================
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(requestID string) (*httptest.ResponseRecorder, *observer.ObservedLogs) {
+		core, logs := observer.New(zap.InfoLevel)
+		router := gin.New()
+		router.Use(RequestID(zap.New(core)))
+		router.GET("/", func(c *gin.Context) {
+			logger.FromContext(c.Request.Context(), zap.NewNop()).Info("handled")
+		})
+
+		req := httptest.NewRequest("GET", "/", nil)
+		if requestID != "" {
+			req.Header.Set(RequestIDHeader, requestID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w, logs
+	}
+
+	t.Run("GeneratesID", func(t *testing.T) {
+		// Act
+		w, logs := serve("")
+
+		// Assert
+		requestID := w.Header().Get(RequestIDHeader)
+		assert.Len(t, requestID, 32)
+		assert.Equal(t, requestID, logs.All()[0].ContextMap()["request_id"])
+	})
+
+	t.Run("PropagatesCallerID", func(t *testing.T) {
+		// Act
+		w, logs := serve("caller-id_1.2")
+
+		// Assert
+		assert.Equal(t, "caller-id_1.2", w.Header().Get(RequestIDHeader))
+		assert.Equal(t, "caller-id_1.2", logs.All()[0].ContextMap()["request_id"])
+	})
+
+	t.Run("ReplacesInvalidID", func(t *testing.T) {
+		for _, requestID := range []string{"bad id\n", strings.Repeat("a", 129)} {
+			// Act
+			w, _ := serve(requestID)
+
+			// Assert
+			assert.NotEqual(t, requestID, w.Header().Get(RequestIDHeader))
+			assert.Len(t, w.Header().Get(RequestIDHeader), 32)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+)
+
+// ProblemContentType is the media type of error responses
+const ProblemContentType = "application/problem+json"
+
+type problemType struct {
+	status int
+	title  string
+}
+
+var problemTypes = map[apperrors.Code]problemType{
+	apperrors.CodeValidationFailed:  {http.StatusBadRequest, "Validation failed"},
+	apperrors.CodeUnauthenticated:   {http.StatusUnauthorized, "Unauthenticated"},
+	apperrors.CodeForbidden:         {http.StatusForbidden, "Forbidden"},
+	apperrors.CodeSubredditNotFound: {http.StatusNotFound, "Subreddit not found"},
+	apperrors.CodeSourceNotFound:    {http.StatusNotFound, "Source not found"},
+	apperrors.CodeRateLimited:       {http.StatusTooManyRequests, "Rate limited"},
+	apperrors.CodeQuotaExceeded:     {http.StatusTooManyRequests, "Quota exceeded"},
+	apperrors.CodeRedditUnavailable: {http.StatusBadGateway, "Reddit unavailable"},
+	apperrors.CodeSourceUnavailable: {http.StatusBadGateway, "Source unavailable"},
+	apperrors.CodeRedditRateLimited: {http.StatusServiceUnavailable, "Reddit rate limited"},
+	apperrors.CodeLLMUnavailable:    {http.StatusServiceUnavailable, "Language model unavailable"},
+	apperrors.CodeInternal:          {http.StatusInternalServerError, "Internal server error"},
+}
+
+// HTTPStatus returns the status code errors with code are answered with
+func HTTPStatus(code apperrors.Code) int {
+	if problem, ok := problemTypes[code]; ok {
+		return problem.status
+	}
+	return http.StatusInternalServerError
+}
+
+// NewProblem describes err for clients. Only the message of a coded error is exposed, other
+// errors are reported as internal without any detail.
+func NewProblem(c *gin.Context, err error) contracts.ProblemDetails {
+	appErr, ok := apperrors.As(err)
+	if !ok {
+		appErr = apperrors.New(apperrors.CodeInternal, "An unexpected error occurred")
+	}
+	problem, ok := problemTypes[appErr.Code]
+	if !ok {
+		problem = problemTypes[apperrors.CodeInternal]
+	}
+	details := contracts.ProblemDetails{
+		Type:      "/problems/" + strings.ReplaceAll(strings.ToLower(string(appErr.Code)), "_", "-"),
+		Title:     problem.title,
+		Status:    problem.status,
+		Detail:    appErr.Message,
+		Instance:  c.Request.URL.Path,
+		Code:      string(appErr.Code),
+		RequestID: c.GetString(requestIDContextKey),
+	}
+	for _, field := range appErr.Fields {
+		details.Errors = append(details.Errors, contracts.FieldErrorDto{
+			Field:   field.Field,
+			Rule:    field.Rule,
+			Message: field.Message,
+		})
+	}
+	return details
+}
+
+// AbortWithProblem answers the request with the problem+json body describing err
+func AbortWithProblem(c *gin.Context, err error) {
+	problem := NewProblem(c, err)
+	c.Abort()
+	c.Header("Content-Type", ProblemContentType)
+	c.Render(problem.Status, render.JSON{Data: problem})
+}
@@ -2,26 +2,118 @@ package api
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
 	_ "github.com/ReyOrtiz/reddit-content-analyzer/docs" // docs is generated by Swag CLI, you have to import it.
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/auth"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/health"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/metrics"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
 )
 
-func StartServer() {
-	cfg := config.GetConfig()
-	relevanceService := services.NewRelevanceService()
-	relevanceHandler := NewRelevanceHandler(relevanceService)
+// Dependencies are the services the API routes delegate to
+type Dependencies struct {
+	Logger           *zap.Logger
+	RelevanceService services.RelevanceService
+	DiscoveryService services.SubredditDiscoveryService
+	Readiness        *health.Checker
+	// TrendService is optional, when set /v1/reddit/trends serves the trends of the stored runs
+	TrendService services.TrendService
+	// Metrics is optional, when set every request is instrumented and /metrics is exposed
+	Metrics *metrics.Metrics
+	// TracerProvider is optional, when set every API request starts a trace
+	TracerProvider trace.TracerProvider
+	// LogLevel is optional, when set along with Auth /admin/log-level reads and changes the log
+	// level at runtime. Without Auth anyone could turn on the logging of request payloads.
+	LogLevel *zap.AtomicLevel
+	// Auth is optional, when set the API and admin routes require an API key with the matching scope
+	Auth *Auth
+}
+
+// NewRouter creates the HTTP handler serving the API routes, the health probes, the metrics and the Swagger documentation
+func NewRouter(deps Dependencies) http.Handler {
+	relevanceHandler := NewRelevanceHandler(deps.RelevanceService, deps.Logger)
+	subredditHandler := NewSubredditHandler(deps.DiscoveryService, deps.Logger)
+	healthHandler := NewHealthHandler(deps.Readiness)
 
 	router := gin.Default()
-	router.POST("/v1/reddit/relevance/search", relevanceHandler.GetRelevantPosts)
-	
+	if deps.TracerProvider != nil {
+		router.Use(otelgin.Middleware("reddit-content-analyzer",
+			otelgin.WithTracerProvider(deps.TracerProvider),
+			otelgin.WithPropagators(tracing.Propagator()),
+			otelgin.WithGinFilter(func(c *gin.Context) bool {
+				// Probes and scrapes run every few seconds and would drown out the request traces
+				switch c.FullPath() {
+				case "/healthz", "/readyz", "/metrics":
+					return false
+				}
+				return true
+			}),
+		))
+	}
+	router.Use(RequestID(deps.Logger))
+	if deps.Metrics != nil {
+		router.Use(deps.Metrics.Middleware())
+		router.GET("/metrics", gin.WrapH(deps.Metrics.Handler()))
+	}
+
+	router.POST("/v1/reddit/relevance/search", deps.protect(auth.ScopeSearch, true, relevanceHandler.GetRelevantPosts)...)
+	router.GET("/v1/reddit/subreddits/suggestions", deps.protect(auth.ScopeSearch, false, subredditHandler.SuggestSubreddits)...)
+	if deps.TrendService != nil {
+		trendHandler := NewTrendHandler(deps.TrendService, deps.Logger)
+		router.GET("/v1/reddit/trends", deps.protect(auth.ScopeSearch, false, trendHandler.GetTrends)...)
+	}
+
+	// Health probes
+	router.GET("/healthz", healthHandler.Liveness)
+	router.GET("/readyz", healthHandler.Readiness)
+
+	if deps.LogLevel != nil && deps.Auth != nil {
+		adminHandler := NewAdminHandler(*deps.LogLevel, deps.Logger)
+		router.GET("/admin/log-level", deps.protect(auth.ScopeAdmin, false, adminHandler.GetLogLevel)...)
+		router.PUT("/admin/log-level", deps.protect(auth.ScopeAdmin, false, adminHandler.SetLogLevel)...)
+	}
+
 	// Swagger documentation endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	
-	router.Run(fmt.Sprintf(":%s", cfg.GetString("api.port")))
+
+	return router
+}
+
+// protect prepends the authentication middlewares for scope to handler when auth is enabled.
+// chargePosts also enforces the daily post quota for routes that evaluate posts.
+func (deps Dependencies) protect(scope auth.Scope, chargePosts bool, handler gin.HandlerFunc) []gin.HandlerFunc {
+	if deps.Auth == nil {
+		return []gin.HandlerFunc{handler}
+	}
+	handlers := deps.Auth.Require(scope)
+	if chargePosts {
+		handlers = append(handlers, deps.Auth.PostQuota)
+	}
+	return append(handlers, handler)
+}
+
+// NewServer creates an HTTP server listening on api.port that serves handler, with the
+// timeouts configured in the api section. The caller is responsible for starting and stopping it.
+func NewServer(cfg *viper.Viper, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.GetString("api.port")),
+		Handler:           handler,
+		ReadTimeout:       config.DurationOrDefault(cfg, "api.read_timeout", 15*time.Second),
+		ReadHeaderTimeout: config.DurationOrDefault(cfg, "api.read_header_timeout", 5*time.Second),
+		// Searches embed and summarize every post, so responses can take minutes
+		WriteTimeout: config.DurationOrDefault(cfg, "api.write_timeout", 5*time.Minute),
+		IdleTimeout:  config.DurationOrDefault(cfg, "api.idle_timeout", 2*time.Minute),
+	}
 }
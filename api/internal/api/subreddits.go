@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+)
+
+type SubredditHandler struct {
+	logger           *zap.Logger
+	discoveryService services.SubredditDiscoveryService
+}
+
+func NewSubredditHandler(discoveryService services.SubredditDiscoveryService, logger *zap.Logger) *SubredditHandler {
+	return &SubredditHandler{
+		logger:           logger,
+		discoveryService: discoveryService,
+	}
+}
+
+// SuggestSubreddits godoc
+// @Summary      Suggest subreddits for a topic
+// @Description  Finds subreddits with Reddit's subreddit search and name autocomplete, and ranks them by how close their public description is to the topic. NSFW and private subreddits are left out.
+// @Tags         reddit
+// @Produce      json
+// @Produce      application/problem+json
+// @Param        topic  query     string                                     true   "Topic to find subreddits for"
+// @Param        limit  query     int                                        false  "Maximum number of suggestions, 10 by default"  minimum(1)  maximum(25)
+// @Success      200    {object}  contracts.SubredditSuggestionsResponseDto  "Suggested subreddits, most relevant first"
+// @Failure      400    {object}  contracts.ProblemDetails                   "VALIDATION_FAILED - invalid query parameters"
+// @Failure      401    {object}  contracts.ProblemDetails                   "UNAUTHENTICATED - missing or invalid API key"
+// @Failure      403    {object}  contracts.ProblemDetails                   "FORBIDDEN - API key lacks the search scope"
+// @Failure      429    {object}  contracts.ProblemDetails                   "RATE_LIMITED - rate limit exceeded"
+// @Failure      500    {object}  contracts.ProblemDetails                   "INTERNAL - unexpected error"
+// @Failure      502    {object}  contracts.ProblemDetails                   "REDDIT_UNAVAILABLE - Reddit could not be reached"
+// @Failure      503    {object}  contracts.ProblemDetails                   "REDDIT_RATE_LIMITED or LLM_UNAVAILABLE - an upstream service is unavailable"
+// @Security     ApiKeyAuth
+// @Router       /v1/reddit/subreddits/suggestions [get]
+func (h *SubredditHandler) SuggestSubreddits(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request contracts.SubredditSuggestionRequestDto
+	if err := bindQuery(c, &request); err != nil {
+		logger.FromContext(ctx, h.logger).Warn("Invalid request", zap.Error(err))
+		AbortWithProblem(c, err)
+		return
+	}
+
+	ctx = logger.WithFields(ctx, h.logger, zap.String("topic", request.Topic))
+	response, err := h.discoveryService.SuggestSubreddits(ctx, request)
+	if err != nil {
+		logger.FromContext(ctx, h.logger).Error("Error suggesting subreddits", zap.Error(err), zap.String("code", string(apperrors.CodeOf(err))))
+		AbortWithProblem(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
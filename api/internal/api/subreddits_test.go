@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/health"
+	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
+)
+
+// ============================================================================
+// SubredditHandler Tests
+// ============================================================================
+
+func TestSubredditHandler_SuggestSubreddits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(discoveryService *mock_services.MockSubredditDiscoveryService) http.Handler {
+		return NewRouter(Dependencies{
+			Logger:           zap.NewNop(),
+			DiscoveryService: discoveryService,
+			Readiness:        health.NewChecker(time.Second, time.Second),
+		})
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockDiscoveryService := mock_services.NewMockSubredditDiscoveryService(t)
+		router := newRouter(mockDiscoveryService)
+
+		expected := contracts.SubredditSuggestionsResponseDto{
+			Suggestions: []contracts.SubredditSuggestionDto{{Name: "golang", Subscribers: 250000, RelevanceScore: 0.82}},
+		}
+		mockDiscoveryService.EXPECT().
+			SuggestSubreddits(mock.Anything, contracts.SubredditSuggestionRequestDto{Topic: "golang generics", Limit: 5}).
+			Return(expected, nil)
+
+		req := httptest.NewRequest("GET", "/v1/reddit/subreddits/suggestions?topic=golang+generics&limit=5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response contracts.SubredditSuggestionsResponseDto
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		// Arrange
+		router := newRouter(mock_services.NewMockSubredditDiscoveryService(t))
+		req := httptest.NewRequest("GET", "/v1/reddit/subreddits/suggestions?limit=50", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem contracts.ProblemDetails
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+		assert.ElementsMatch(t, []contracts.FieldErrorDto{
+			{Field: "topic", Rule: "required", Message: "is required"},
+			{Field: "limit", Rule: "max", Message: "must be at most 25"},
+		}, problem.Errors)
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		// Arrange
+		mockDiscoveryService := mock_services.NewMockSubredditDiscoveryService(t)
+		router := newRouter(mockDiscoveryService)
+		mockDiscoveryService.EXPECT().SuggestSubreddits(mock.Anything, mock.Anything).
+			Return(contracts.SubredditSuggestionsResponseDto{}, apperrors.New(apperrors.CodeRedditUnavailable, "Reddit is unavailable"))
+
+		req := httptest.NewRequest("GET", "/v1/reddit/subreddits/suggestions?topic=go", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+)
+
+type TrendHandler struct {
+	logger       *zap.Logger
+	trendService services.TrendService
+}
+
+func NewTrendHandler(trendService services.TrendService, logger *zap.Logger) *TrendHandler {
+	return &TrendHandler{
+		logger:       logger,
+		trendService: trendService,
+	}
+}
+
+// GetTrends godoc
+// @Summary      Get the trend of a topic
+// @Description  Buckets the posts the stored searches of a topic evaluated by the time they were created, per subreddit, with their relevant posts, average relevance, engagement and sentiment. Buckets with unusually many relevant posts compared to the previous buckets are flagged as spikes. Searches are stored when runs are enabled.
+// @Tags         reddit
+// @Produce      json
+// @Produce      application/problem+json
+// @Param        topic            query     string                      true   "Topic of the stored searches"
+// @Param        subreddits       query     []string                    false  "Subreddits or communities to include, all by default"  collectionFormat(multi)
+// @Param        granularity      query     string                      false  "Length of the buckets, day by default"  Enums(hour, day, week)
+// @Param        from             query     string                      false  "Start of the trend in RFC 3339 format, 30 buckets before to by default"
+// @Param        to               query     string                      false  "End of the trend in RFC 3339 format, now by default"
+// @Param        baseline_window  query     int                         false  "Previous buckets the spike baseline averages, 7 by default"  minimum(2)  maximum(90)
+// @Param        spike_threshold  query     number                      false  "Standard deviations above the baseline a spike is, 2 by default"  minimum(0)  maximum(10)
+// @Success      200              {object}  contracts.TrendResponseDto  "Trend of every subreddit, most relevant posts first"
+// @Failure      400              {object}  contracts.ProblemDetails    "VALIDATION_FAILED - invalid query parameters"
+// @Failure      401              {object}  contracts.ProblemDetails    "UNAUTHENTICATED - missing or invalid API key"
+// @Failure      403              {object}  contracts.ProblemDetails    "FORBIDDEN - API key lacks the search scope"
+// @Failure      429              {object}  contracts.ProblemDetails    "RATE_LIMITED - rate limit exceeded"
+// @Failure      500              {object}  contracts.ProblemDetails    "INTERNAL - unexpected error"
+// @Security     ApiKeyAuth
+// @Router       /v1/reddit/trends [get]
+func (h *TrendHandler) GetTrends(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request contracts.TrendRequestDto
+	if err := bindQuery(c, &request); err != nil {
+		logger.FromContext(ctx, h.logger).Warn("Invalid request", zap.Error(err))
+		AbortWithProblem(c, err)
+		return
+	}
+
+	ctx = logger.WithFields(ctx, h.logger, zap.String("topic", request.Topic))
+	response, err := h.trendService.GetTrends(ctx, request)
+	if err != nil {
+		logger.FromContext(ctx, h.logger).Error("Error getting trends", zap.Error(err), zap.String("code", string(apperrors.CodeOf(err))))
+		AbortWithProblem(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/health"
+	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
+)
+
+// ============================================================================
+// TrendHandler Tests
+// ============================================================================
+
+func TestTrendHandler_GetTrends(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(trendService *mock_services.MockTrendService) http.Handler {
+		return NewRouter(Dependencies{
+			Logger:       zap.NewNop(),
+			TrendService: trendService,
+			Readiness:    health.NewChecker(time.Second, time.Second),
+		})
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockTrendService := mock_services.NewMockTrendService(t)
+		router := newRouter(mockTrendService)
+
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		expected := contracts.TrendResponseDto{
+			Topic:       "go generics",
+			Granularity: contracts.TrendGranularityWeek,
+			From:        from,
+			To:          from.AddDate(0, 0, 14),
+			Runs:        3,
+			Subreddits: []contracts.SubredditTrendDto{{
+				SubredditName: "golang",
+				RelevantPosts: 4,
+				Buckets:       []contracts.TrendBucketDto{{Start: from, Posts: 5, RelevantPosts: 4, AverageRelevance: 0.7}},
+			}},
+		}
+		mockTrendService.EXPECT().GetTrends(mock.Anything, contracts.TrendRequestDto{
+			Topic:       "go generics",
+			Subreddits:  []string{"golang", "programming"},
+			Granularity: contracts.TrendGranularityWeek,
+			From:        from,
+		}).Return(expected, nil)
+
+		req := httptest.NewRequest("GET", "/v1/reddit/trends?topic=go+generics&subreddits=golang&subreddits=programming&granularity=week&from=2026-03-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response contracts.TrendResponseDto
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		// Arrange
+		router := newRouter(mock_services.NewMockTrendService(t))
+		req := httptest.NewRequest("GET", "/v1/reddit/trends?granularity=month&baseline_window=1", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem contracts.ProblemDetails
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+		assert.ElementsMatch(t, []contracts.FieldErrorDto{
+			{Field: "topic", Rule: "required", Message: "is required"},
+			{Field: "granularity", Rule: "oneof", Message: "must be one of hour, day, week"},
+			{Field: "baseline_window", Rule: "min", Message: "must be at least 2"},
+		}, problem.Errors)
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		// Arrange
+		mockTrendService := mock_services.NewMockTrendService(t)
+		router := newRouter(mockTrendService)
+		mockTrendService.EXPECT().GetTrends(mock.Anything, mock.Anything).Return(contracts.TrendResponseDto{}, apperrors.Validation(nil,
+			[]apperrors.FieldError{{Field: "from", Rule: "before_to", Message: "must be before to"}},
+		))
+
+		req := httptest.NewRequest("GET", "/v1/reddit/trends?topic=go&from=2026-03-02T00:00:00Z&to=2026-03-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("RunsDisabled", func(t *testing.T) {
+		// Arrange
+		router := NewRouter(Dependencies{Logger: zap.NewNop(), Readiness: health.NewChecker(time.Second, time.Second)})
+		req := httptest.NewRequest("GET", "/v1/reddit/trends?topic=go", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
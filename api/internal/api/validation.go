@@ -0,0 +1,236 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+)
+
+// subredditNamePattern matches subreddit names as Reddit allows them, without the r/ prefix
+var subredditNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_]{1,20}$`)
+
+// hostnamePattern matches hostnames of at least two labels
+const hostnamePattern = `[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)+`
+
+// sourcePattern matches the user:{name}, domain:{host}, hackernews:{tag} and
+// lemmy:{community@instance} sources. Reddit usernames have 3 to 20 letters, digits, underscores
+// or dashes. Hacker News tags are Algolia tag filters such as story, show_hn or author_pg.
+var sourcePattern = regexp.MustCompile(`^(user:[A-Za-z0-9_-]{3,20}|domain:` + hostnamePattern +
+	`|hackernews:[A-Za-z0-9_,()]{1,100}|lemmy:[A-Za-z0-9_]{1,50}@` + hostnamePattern + `)$`)
+
+// redditLaunch is the day Reddit went online, nothing can be created before it
+var redditLaunch = time.Date(2005, time.June, 23, 0, 0, 0, 0, time.UTC)
+
+var registerValidatorsOnce sync.Once
+
+// registerValidators adds the custom validation rules used by the request DTOs to the gin
+// validator and makes field errors use JSON field names
+func registerValidators() {
+	registerValidatorsOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" {
+				name, _, _ = strings.Cut(field.Tag.Get("form"), ",")
+			}
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+		_ = v.RegisterValidation("subreddit", validateSubreddit)
+		_ = v.RegisterValidation("created_after", validateCreatedAfter)
+		_ = v.RegisterValidation("source", validateSource)
+		v.RegisterStructValidation(validateRelevanceRequest, contracts.RelevanceRequestDto{})
+	})
+}
+
+func validateSubreddit(fl validator.FieldLevel) bool {
+	return subredditNamePattern.MatchString(fl.Field().String())
+}
+
+func validateSource(fl validator.FieldLevel) bool {
+	source := fl.Field().String()
+	if feedURL, found := strings.CutPrefix(source, services.SourceFeedPrefix); found {
+		parsed, err := url.Parse(feedURL)
+		return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" &&
+			!isPrivateHost(parsed.Hostname())
+	}
+	if target, found := strings.CutPrefix(source, services.SourceLemmyPrefix); found {
+		_, instance, _ := strings.Cut(target, "@")
+		if isPrivateHost(instance) {
+			return false
+		}
+	}
+	return sourcePattern.MatchString(source)
+}
+
+// isPrivateHost reports whether host is localhost or a private IP address. Hostnames resolving
+// to private addresses are refused when the sources client dials them.
+func isPrivateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && netguard.IsPrivate(addr)
+}
+
+// validateRelevanceRequest requires subreddits unless the request searches all of Reddit or
+// only analyzes sources
+func validateRelevanceRequest(sl validator.StructLevel) {
+	request := sl.Current().Interface().(contracts.RelevanceRequestDto)
+	if request.Subreddits == nil && len(request.Sources) == 0 && request.Scope != contracts.SearchScopeAll {
+		sl.ReportError(request.Subreddits, "subreddits", "Subreddits", "required_without_sources", "")
+	}
+}
+
+// validateCreatedAfter accepts the zero time, which means no lower bound, and times between
+// the launch of Reddit and now
+func validateCreatedAfter(fl validator.FieldLevel) bool {
+	createdAfter, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+	if createdAfter.IsZero() {
+		return true
+	}
+	return !createdAfter.Before(redditLaunch) && !createdAfter.After(time.Now())
+}
+
+// bindJSON decodes the JSON body of the request into obj and validates it, returning
+// a VALIDATION_FAILED error that lists the rejected fields
+func bindJSON(c *gin.Context, obj any) error {
+	registerValidators()
+	return bindingError(c.ShouldBindJSON(obj), "The request body is not valid JSON")
+}
+
+// bindQuery decodes the query string of the request into obj and validates it like bindJSON
+func bindQuery(c *gin.Context, obj any) error {
+	registerValidators()
+	return bindingError(c.ShouldBindQuery(obj), "The query string has a value of the wrong type")
+}
+
+// bindingError converts a binding error to a VALIDATION_FAILED error, using message when
+// the rejected field cannot be told
+func bindingError(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]apperrors.FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, apperrors.FieldError{
+				Field:   fieldPath(fieldErr),
+				Rule:    fieldErr.Tag(),
+				Message: fieldErrorMessage(fieldErr),
+			})
+		}
+		return apperrors.Validation(err, fields)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperrors.Validation(err, []apperrors.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: "must be a " + jsonTypeName(typeErr.Type),
+		}})
+	}
+
+	var timeErr *time.ParseError
+	if errors.As(err, &timeErr) {
+		return apperrors.Wrap(err, apperrors.CodeValidationFailed, "The request body has a time that is not in RFC 3339 format")
+	}
+
+	return apperrors.Wrap(err, apperrors.CodeValidationFailed, message)
+}
+
+// fieldPath returns the JSON path of the field without the name of the top-level struct,
+// for example subreddits[0]
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+	return path
+}
+
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	kind := fieldErr.Kind()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required unless " + strings.ToLower(fieldErr.Param()) + " is set"
+	case "required_without_sources":
+		return "is required unless sources are set or scope is all"
+	case "subreddit":
+		return "must be a subreddit name of 2 to 21 letters, digits or underscores"
+	case "source":
+		return services.SourceRuleMessage
+	case "created_after":
+		return "must be after " + redditLaunch.Format(time.DateOnly) + " and not in the future"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "min", "gte":
+		return boundMessage(kind, "at least", fieldErr.Param())
+	case "max", "lte":
+		return boundMessage(kind, "at most", fieldErr.Param())
+	default:
+		return fmt.Sprintf("failed the %s rule", fieldErr.Tag())
+	}
+}
+
+func boundMessage(kind reflect.Kind, bound, param string) string {
+	switch kind {
+	case reflect.String:
+		return fmt.Sprintf("must be %s %s characters long", bound, param)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if param == "1" {
+			return fmt.Sprintf("must have %s 1 item", bound)
+		}
+		return fmt.Sprintf("must have %s %s items", bound, param)
+	default:
+		return fmt.Sprintf("must be %s %s", bound, param)
+	}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "whole number"
+	default:
+		return "number"
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
+)
+
+// ============================================================================
+// Request Validation Tests
+// ============================================================================
+
+func TestRelevanceRequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(t *testing.T, body string) (*httptest.ResponseRecorder, contracts.ProblemDetails) {
+		handler := NewRelevanceHandler(mock_services.NewMockRelevanceService(t), zap.NewNop())
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/v1/reddit/relevance/search", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler.GetRelevantPosts(c)
+
+		var problem contracts.ProblemDetails
+		_ = json.Unmarshal(w.Body.Bytes(), &problem)
+		return w, problem
+	}
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"topic":               "golang generics",
+			"subreddits":          []string{"golang"},
+			"relevance_threshold": 0.6,
+			"limit":               25,
+			"search_method":       "search",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		field    string
+		value    any
+		expected contracts.FieldErrorDto
+	}{
+		{"MissingSubreddits", "subreddits", nil, contracts.FieldErrorDto{Field: "subreddits", Rule: "required_without_sources", Message: "is required unless sources are set or scope is all"}},
+		{"EmptySubreddits", "subreddits", []string{}, contracts.FieldErrorDto{Field: "subreddits", Rule: "min", Message: "must have at least 1 item"}},
+		{"TooManySubreddits", "subreddits", make([]string, 11), contracts.FieldErrorDto{Field: "subreddits", Rule: "max", Message: "must have at most 10 items"}},
+		{"SubredditWithPath", "subreddits", []string{"golang", "golang/../../api"}, contracts.FieldErrorDto{Field: "subreddits[1]", Rule: "subreddit", Message: "must be a subreddit name of 2 to 21 letters, digits or underscores"}},
+		{"SubredditWithPrefix", "subreddits", []string{"r/golang"}, contracts.FieldErrorDto{Field: "subreddits[0]", Rule: "subreddit", Message: "must be a subreddit name of 2 to 21 letters, digits or underscores"}},
+		{"NegativeThreshold", "relevance_threshold", -0.1, contracts.FieldErrorDto{Field: "relevance_threshold", Rule: "gte", Message: "must be at least 0"}},
+		{"ThresholdAboveOne", "relevance_threshold", 1.5, contracts.FieldErrorDto{Field: "relevance_threshold", Rule: "lte", Message: "must be at most 1"}},
+		{"NegativeLimit", "limit", -5, contracts.FieldErrorDto{Field: "limit", Rule: "min", Message: "must be at least 1"}},
+		{"LimitAboveMax", "limit", 500, contracts.FieldErrorDto{Field: "limit", Rule: "max", Message: "must be at most 100"}},
+		{"LongTopic", "topic", string(bytes.Repeat([]byte("a"), 501)), contracts.FieldErrorDto{Field: "topic", Rule: "max", Message: "must be at most 500 characters long"}},
+		{"FutureCreatedAfter", "created_after", time.Now().Add(48 * time.Hour), contracts.FieldErrorDto{Field: "created_after", Rule: "created_after", Message: "must be after 2005-06-23 and not in the future"}},
+		{"AncientCreatedAfter", "created_after", time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), contracts.FieldErrorDto{Field: "created_after", Rule: "created_after", Message: "must be after 2005-06-23 and not in the future"}},
+		{"UnknownSearchMethod", "search_method", "random", contracts.FieldErrorDto{Field: "search_method", Rule: "oneof", Message: "must be one of search, latest, hot, new, top, rising, controversial"}},
+		{"UnknownTimeWindow", "time_window", "decade", contracts.FieldErrorDto{Field: "time_window", Rule: "oneof", Message: "must be one of hour, day, week, month, year, all"}},
+		{"UnknownSearchSort", "search_sort", "top", contracts.FieldErrorDto{Field: "search_sort", Rule: "oneof", Message: "must be one of relevance, new, comments"}},
+		{"UnknownSourceKind", "sources", []string{"user:spez", "comments:golang"}, contracts.FieldErrorDto{Field: "sources[1]", Rule: "source", Message: "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"}},
+		{"DomainWithPath", "sources", []string{"domain:go.dev/blog"}, contracts.FieldErrorDto{Field: "sources[0]", Rule: "source", Message: "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"}},
+		{"FeedWithoutScheme", "sources", []string{"feed:go.dev/blog/feed.atom"}, contracts.FieldErrorDto{Field: "sources[0]", Rule: "source", Message: "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"}},
+		{"FeedOnLoopback", "sources", []string{"feed:http://127.0.0.1:8080/admin/log-level"}, contracts.FieldErrorDto{Field: "sources[0]", Rule: "source", Message: "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"}},
+		{"FeedOnMetadataAddress", "sources", []string{"feed:http://169.254.169.254/latest/meta-data/"}, contracts.FieldErrorDto{Field: "sources[0]", Rule: "source", Message: "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"}},
+		{"LemmyOnPrivateAddress", "sources", []string{"lemmy:golang@10.0.0.1"}, contracts.FieldErrorDto{Field: "sources[0]", Rule: "source", Message: "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"}},
+		{"LemmyWithoutInstance", "sources", []string{"hackernews:story", "lemmy:golang"}, contracts.FieldErrorDto{Field: "sources[1]", Rule: "source", Message: "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"}},
+		{"UnknownScope", "scope", "world", contracts.FieldErrorDto{Field: "scope", Rule: "oneof", Message: "must be one of subreddit, multireddit, all"}},
+		{"MissingTopic", "topic", nil, contracts.FieldErrorDto{Field: "topic", Rule: "required_without", Message: "is required unless topics is set"}},
+		{"BlankTopicInTopics", "topics", []string{"golang", ""}, contracts.FieldErrorDto{Field: "topics[1]", Rule: "required", Message: "is required"}},
+		{"UnknownAggregation", "topic_aggregation", "median", contracts.FieldErrorDto{Field: "topic_aggregation", Rule: "oneof", Message: "must be one of max, mean"}},
+		{"DuplicateThresholdAboveOne", "duplicate_threshold", 1.5, contracts.FieldErrorDto{Field: "duplicate_threshold", Rule: "lte", Message: "must be at most 1"}},
+		{"PenaltyAboveOne", "exclusion_penalty", 2, contracts.FieldErrorDto{Field: "exclusion_penalty", Rule: "lte", Message: "must be at most 1"}},
+		{"TooManySentimentComments", "sentiment_comments", 100, contracts.FieldErrorDto{Field: "sentiment_comments", Rule: "lte", Message: "must be at most 50"}},
+		{"WrongType", "limit", "ten", contracts.FieldErrorDto{Field: "limit", Rule: "type", Message: "must be a whole number"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := valid()
+			if tt.value == nil {
+				delete(request, tt.field)
+			} else {
+				request[tt.field] = tt.value
+			}
+			body, _ := json.Marshal(request)
+
+			// Act
+			w, problem := serve(t, string(body))
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+			assert.Equal(t, []contracts.FieldErrorDto{tt.expected}, problem.Errors)
+		})
+	}
+
+	t.Run("ReportsEveryField", func(t *testing.T) {
+		// Act
+		w, problem := serve(t, `{"subreddits":["golang"],"relevance_threshold":2}`)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		fields := make([]string, 0, len(problem.Errors))
+		for _, fieldErr := range problem.Errors {
+			fields = append(fields, fieldErr.Field)
+		}
+		assert.ElementsMatch(t, []string{"topic", "relevance_threshold", "search_method"}, fields)
+	})
+
+	t.Run("SourcesOutsideReddit", func(t *testing.T) {
+		// Arrange
+		registerValidators()
+		request := contracts.RelevanceRequestDto{
+			Topic:        "golang generics",
+			SearchMethod: contracts.SearchMethodSearch,
+			Sources:      []string{"hackernews:show_hn", "feed:https://go.dev/blog/feed.atom", "lemmy:golang@programming.dev"},
+		}
+
+		// Act
+		err := binding.Validator.ValidateStruct(request)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvalidTime", func(t *testing.T) {
+		// Arrange
+		request := valid()
+		request["created_after"] = "yesterday"
+		body, _ := json.Marshal(request)
+
+		// Act
+		w, problem := serve(t, string(body))
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+		assert.Contains(t, problem.Detail, "RFC 3339")
+	})
+}
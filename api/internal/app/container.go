@@ -0,0 +1,262 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/api"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/article"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/auth"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/feed"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/hackernews"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/health"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/lemmy"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/metrics"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/recorder"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/runstore"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+)
+
+// Container holds the application dependencies wired together from a single config.
+// Several containers with different configs can live in the same process.
+type Container struct {
+	Config           *viper.Viper
+	Logger           *zap.Logger
+	LogLevel         zap.AtomicLevel
+	Metrics          *metrics.Metrics
+	TracerProvider   *tracing.Provider
+	RedditClient     reddit.ClientInterface
+	LLMClient        llm.ClientInterface
+	RedditService    services.RedditService
+	RelevanceService services.RelevanceService
+	DiscoveryService services.SubredditDiscoveryService
+	TrendService     services.TrendService // nil unless runs.enabled is set
+	Readiness        *health.Checker
+	Handler          http.Handler
+	Server           *http.Server
+
+	lifecycle *lifecycle
+	logOutput io.Closer
+}
+
+// NewContainer builds every application dependency from cfg
+func NewContainer(cfg *viper.Viper) (*Container, error) {
+	appLogger, err := logger.New(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating logger")
+	}
+	built := false
+	defer func() {
+		if !built {
+			appLogger.Close()
+		}
+	}()
+	log := appLogger.Logger
+
+	tracerProvider, err := tracing.New(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating tracer provider")
+	}
+
+	// The cassettes of the recorder are saved once the container shuts down
+	var cassettes []io.Closer
+	redditHTTPClient, redditCassette, err := recorder.NewHTTPClient(cfg, "reddit", 30*time.Second, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating Reddit HTTP client")
+	}
+	cassettes = append(cassettes, redditCassette)
+	traceHTTPClient(redditHTTPClient, "reddit", tracerProvider, false)
+	llmHTTPClient, llmCassette, err := recorder.NewHTTPClient(cfg, "llm", 0, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating LLM HTTP client")
+	}
+	cassettes = append(cassettes, llmCassette)
+	traceHTTPClient(llmHTTPClient, "llm", tracerProvider, true)
+	// Feeds and Lemmy instances are named by the request, the dialer refuses their private addresses
+	sourcesHTTPClient, sourcesCassette, err := recorder.NewHTTPClient(cfg, "sources", 30*time.Second, netguard.Guard{}.Transport())
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating content sources HTTP client")
+	}
+	cassettes = append(cassettes, sourcesCassette)
+	traceHTTPClient(sourcesHTTPClient, "sources", tracerProvider, false)
+
+	appMetrics := metrics.New()
+	redditClient := metrics.InstrumentRedditClient(reddit.NewClientFromConfig(cfg, redditHTTPClient), appMetrics)
+	baseLLMClient := llm.NewClientFromConfig(cfg, llmHTTPClient, log)
+	llmClient := metrics.InstrumentLLMClient(baseLLMClient, appMetrics)
+
+	preprocess, err := PreprocessOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var enrichers services.ContentEnrichers
+	if cfg.GetBool("links.enabled") {
+		// Links come from untrusted posts, the dialer refuses the private addresses they may point to
+		linksHTTPClient, linksCassette, err := recorder.NewHTTPClient(cfg, "links", 0, netguard.Guard{}.Transport())
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating linked pages HTTP client")
+		}
+		cassettes = append(cassettes, linksCassette)
+		traceHTTPClient(linksHTTPClient, "links", tracerProvider, false)
+		linkEnricher := services.NewLinkEnricher(article.NewClientFromConfig(cfg, linksHTTPClient), services.EnricherOptions{
+			CacheTTL:    config.DurationOrDefault(cfg, "links.cache_ttl", services.DefaultEnricherCacheTTL),
+			CacheSize:   cfg.GetInt("links.cache_size"),
+			Concurrency: cfg.GetInt("links.max_concurrency"),
+		}, log)
+		linkEnricher.ObserveCache(func(hit bool) {
+			appMetrics.ObserveCacheLookup("links", hit)
+		})
+		enrichers = append(enrichers, linkEnricher)
+	}
+	if visionModel := baseLLMClient.VisionModel(); visionModel != "" {
+		mediaCaptioner := services.NewMediaCaptioner(metrics.InstrumentCaptioner(baseLLMClient, visionModel, appMetrics), services.EnricherOptions{
+			CacheTTL:    config.DurationOrDefault(cfg, "media.cache_ttl", services.DefaultEnricherCacheTTL),
+			CacheSize:   cfg.GetInt("media.cache_size"),
+			Concurrency: cfg.GetInt("media.max_concurrency"),
+		}, log)
+		mediaCaptioner.ObserveCache(func(hit bool) {
+			appMetrics.ObserveCacheLookup("captions", hit)
+		})
+		enrichers = append(enrichers, mediaCaptioner)
+	}
+	var enricher services.ContentEnricher
+	if len(enrichers) > 0 {
+		enricher = enrichers
+	}
+
+	redditService := services.NewRedditService(redditClient, log)
+	relevance := services.NewRelevanceService(llmClient, redditService, services.ContentSources{
+		services.SourceHackerNewsPrefix: services.NewHackerNewsSource(hackernews.NewClientFromConfig(cfg, sourcesHTTPClient)),
+		services.SourceFeedPrefix:       services.NewFeedSource(feed.NewClientWithHTTPClient(sourcesHTTPClient)),
+		services.SourceLemmyPrefix:      services.NewLemmySource(lemmy.NewClientWithHTTPClient(sourcesHTTPClient)),
+	}, preprocess, enricher, log)
+	var trendService services.TrendService
+	if cfg.GetBool("runs.enabled") {
+		runStore, err := runstore.NewStoreFromConfig(cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating run store")
+		}
+		relevance = services.NewRunRecordingService(relevance, runStore, log)
+		trendService = services.NewTrendService(runStore, log)
+	}
+	relevanceService := metrics.InstrumentRelevanceService(relevance, appMetrics)
+	discoveryService := services.NewSubredditDiscoveryService(llmClient, redditService, log)
+
+	readiness := health.NewChecker(
+		config.DurationOrDefault(cfg, "health.readiness_cache_ttl", 15*time.Second),
+		config.DurationOrDefault(cfg, "health.check_timeout", 5*time.Second),
+		health.Check{
+			Name: "llm",
+			// The probe goes around the instrumented client so it is not counted as embedding traffic
+			Check: func(ctx context.Context) error {
+				_, err := baseLLMClient.GetEmbedding(ctx, "ping")
+				return err
+			},
+		},
+		health.Check{
+			Name:  "reddit",
+			Check: redditClient.Ping,
+		},
+	)
+	readiness.ObserveCache(func(hit bool) {
+		appMetrics.ObserveCacheLookup("readiness", hit)
+	})
+	readiness.ObserveFailure(func(name string, err error) {
+		log.Warn("Readiness check failed", zap.String("check", name), zap.Error(err))
+	})
+
+	var apiAuth *api.Auth
+	if cfg.GetBool("auth.enabled") {
+		keyStore, err := auth.NewKeyStoreFromConfig(cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating API key store")
+		}
+		apiAuth = api.NewAuth(keyStore, auth.NewLimits(), log)
+	}
+
+	handler := api.NewRouter(api.Dependencies{
+		Logger:           log,
+		RelevanceService: relevanceService,
+		DiscoveryService: discoveryService,
+		TrendService:     trendService,
+		Readiness:        readiness,
+		Metrics:          appMetrics,
+		TracerProvider:   tracerProvider,
+		LogLevel:         &appLogger.Level,
+		Auth:             apiAuth,
+	})
+	server := api.NewServer(cfg, handler)
+
+	container := &Container{
+		Config:           cfg,
+		Logger:           log,
+		LogLevel:         appLogger.Level,
+		Metrics:          appMetrics,
+		TracerProvider:   tracerProvider,
+		RedditClient:     redditClient,
+		LLMClient:        llmClient,
+		RedditService:    redditService,
+		RelevanceService: relevanceService,
+		DiscoveryService: discoveryService,
+		TrendService:     trendService,
+		Readiness:        readiness,
+		Handler:          handler,
+		Server:           server,
+		lifecycle:        newLifecycle(),
+		logOutput:        appLogger,
+	}
+	container.OnShutdown(func(context.Context) error {
+		return closeAll(cassettes)
+	})
+	// Flush the spans of the drained requests before exiting
+	container.OnShutdown(tracerProvider.Shutdown)
+	built = true
+	return container, nil
+}
+
+// PreprocessOptions reads how posts are chunked and scored from the relevance section of cfg.
+// Unset values use the defaults of the services package.
+func PreprocessOptions(cfg *viper.Viper) (services.PreprocessOptions, error) {
+	options := services.PreprocessOptions{
+		ChunkSize:    cfg.GetInt("relevance.chunk_size"),
+		ChunkOverlap: cfg.GetInt("relevance.chunk_overlap"),
+		MaxChunks:    cfg.GetInt("relevance.max_chunks"),
+		Strategy:     services.ChunkStrategy(cfg.GetString("relevance.chunk_strategy")),
+		TitleWeight:  cfg.GetFloat64("relevance.title_weight"),
+	}
+	if !options.Strategy.Valid() {
+		return services.PreprocessOptions{}, errors.Errorf("unknown relevance.chunk_strategy %q, must be max, mean or title_weighted", options.Strategy)
+	}
+	return options, nil
+}
+
+// traceHTTPClient wraps the transport of httpClient so every outbound request is a span named after the dependency.
+// The trace context is only sent along when propagate is set, third-party hosts must not receive it.
+func traceHTTPClient(httpClient *http.Client, name string, tracerProvider *tracing.Provider, propagate bool) {
+	propagator := propagation.NewCompositeTextMapPropagator()
+	if propagate {
+		propagator = tracing.Propagator()
+	}
+	httpClient.Transport = otelhttp.NewTransport(httpClient.Transport,
+		otelhttp.WithTracerProvider(tracerProvider),
+		otelhttp.WithPropagators(propagator),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return name + " " + r.Method
+		}),
+	)
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+)
+
+func newReplayConfig(port string) *viper.Viper {
+	cfg := viper.New()
+	cfg.Set("api.port", port)
+	cfg.Set("logging.level", "error")
+	cfg.Set("recorder.mode", "replay")
+	cfg.Set("recorder.cassette_dir", filepath.Join("..", "services", "testdata", "cassettes", "relevance_search"))
+	return cfg
+}
+
+// ============================================================================
+// NewContainer Tests
+// ============================================================================
+
+func TestNewContainer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ServesReplayedSearch", func(t *testing.T) {
+		// Arrange
+		container, err := NewContainer(newReplayConfig("8080"))
+		assert.NoError(t, err)
+
+		requestBody, _ := json.Marshal(contracts.RelevanceRequestDto{
+			Topic:              "golang generics",
+			Subreddits:         []string{"golang"},
+			RelevanceThreshold: 0.6,
+			Limit:              3,
+			SearchMethod:       contracts.SearchMethodSearch,
+		})
+		req := httptest.NewRequest("POST", "/v1/reddit/relevance/search", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		container.Handler.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response contracts.RelevanceResponseDto
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Posts, 3)
+	})
+
+	t.Run("IndependentContainers", func(t *testing.T) {
+		// Act
+		first, firstErr := NewContainer(newReplayConfig("8081"))
+		second, secondErr := NewContainer(newReplayConfig("8082"))
+
+		// Assert
+		assert.NoError(t, firstErr)
+		assert.NoError(t, secondErr)
+		assert.Equal(t, ":8081", first.Server.Addr)
+		assert.Equal(t, ":8082", second.Server.Addr)
+		assert.NotSame(t, first.RelevanceService, second.RelevanceService)
+	})
+
+	t.Run("InvalidRecorderMode", func(t *testing.T) {
+		// Arrange
+		cfg := newReplayConfig("8080")
+		cfg.Set("recorder.mode", "rewind")
+
+		// Act
+		_, err := NewContainer(cfg)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("LinksEnabled", func(t *testing.T) {
+		// Arrange
+		cfg := newReplayConfig("8080")
+		cfg.Set("links.enabled", true)
+
+		// Act
+		container, err := NewContainer(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, container.RelevanceService)
+	})
+
+	t.Run("VisionModel", func(t *testing.T) {
+		// Arrange
+		cfg := newReplayConfig("8080")
+		cfg.Set("links.enabled", true)
+		cfg.Set("llm.vision_model", "qwen2.5-vl-7b-instruct")
+
+		// Act
+		container, err := NewContainer(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, container.RelevanceService)
+	})
+
+	t.Run("RunsEnabled", func(t *testing.T) {
+		// Arrange
+		cfg := newReplayConfig("8080")
+		cfg.Set("runs.enabled", true)
+		cfg.Set("runs.path", filepath.Join(t.TempDir(), "runs.jsonl"))
+
+		// Act
+		container, err := NewContainer(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, container.TrendService)
+	})
+
+	t.Run("UnknownChunkStrategy", func(t *testing.T) {
+		// Arrange
+		cfg := newReplayConfig("8080")
+		cfg.Set("relevance.chunk_strategy", "median")
+
+		// Act
+		_, err := NewContainer(cfg)
+
+		// Assert
+		assert.ErrorContains(t, err, "relevance.chunk_strategy")
+	})
+
+	t.Run("AuthWithoutKeys", func(t *testing.T) {
+		// Arrange
+		cfg := newReplayConfig("8080")
+		cfg.Set("auth.enabled", true)
+		cfg.Set("auth.hashed_keys_file", filepath.Join(t.TempDir(), "apikeys.json"))
+
+		// Act
+		_, err := NewContainer(cfg)
+
+		// Assert
+		assert.ErrorContains(t, err, "no API keys are configured")
+	})
+}
+
+// ============================================================================
+// traceHTTPClient Tests
+// ============================================================================
+
+func TestTraceHTTPClient(t *testing.T) {
+	tracerProvider := &tracing.Provider{TracerProvider: sdktrace.NewTracerProvider()}
+
+	tests := []struct {
+		name      string
+		propagate bool
+		expected  bool
+	}{
+		{"PropagatesTraceContext", true, true},
+		{"KeepsTraceContextFromThirdParties", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var traceparent string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				traceparent = r.Header.Get("traceparent")
+			}))
+			defer server.Close()
+			httpClient := &http.Client{}
+			traceHTTPClient(httpClient, "test", tracerProvider, tt.propagate)
+
+			// Act
+			resp, err := httpClient.Get(server.URL)
+
+			// Assert
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.expected, traceparent != "")
+		})
+	}
+}
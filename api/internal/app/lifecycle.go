@@ -0,0 +1,153 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
+)
+
+// closeTimeout is how long the shutdown functions have once the application has drained. They get
+// their own deadline, so a drain that used up the shutdown timeout does not cancel them, e.g. the
+// export of the spans of the slowest requests.
+const closeTimeout = 5 * time.Second
+
+// lifecycle tracks background jobs and the functions to run once the application has drained
+type lifecycle struct {
+	jobsCtx    context.Context
+	cancelJobs context.CancelFunc
+	jobs       sync.WaitGroup
+	mu         sync.Mutex
+	closers    []func(ctx context.Context) error
+}
+
+func newLifecycle() *lifecycle {
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	return &lifecycle{
+		jobsCtx:    jobsCtx,
+		cancelJobs: cancelJobs,
+	}
+}
+
+// Go runs fn as a background job. Shutdown waits for background jobs to return and
+// cancels the context passed to fn only when the shutdown deadline expires.
+func (c *Container) Go(fn func(ctx context.Context)) {
+	c.lifecycle.jobs.Add(1)
+	go func() {
+		defer c.lifecycle.jobs.Done()
+		fn(c.lifecycle.jobsCtx)
+	}()
+}
+
+// OnShutdown registers fn to run after in-flight requests and background jobs have drained.
+// Functions run in reverse registration order.
+func (c *Container) OnShutdown(fn func(ctx context.Context) error) {
+	c.lifecycle.mu.Lock()
+	defer c.lifecycle.mu.Unlock()
+	c.lifecycle.closers = append(c.lifecycle.closers, fn)
+}
+
+// Run serves HTTP on the configured address until ctx is cancelled (typically by SIGTERM
+// or SIGINT), then shuts down gracefully within api.shutdown_timeout
+func (c *Container) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", c.Server.Addr)
+	if err != nil {
+		return err
+	}
+	return c.Serve(ctx, listener)
+}
+
+// Serve is like Run but accepts connections on listener
+func (c *Container) Serve(ctx context.Context, listener net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- c.Server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		// The server stopped on its own, release everything else before reporting why
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), c.shutdownTimeout())
+		defer cancel()
+		return errors.Join(err, c.Shutdown(shutdownCtx))
+	case <-ctx.Done():
+	}
+
+	c.Logger.Info("Shutdown signal received, draining", zap.Duration("timeout", c.shutdownTimeout()))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), c.shutdownTimeout())
+	defer cancel()
+	if err := c.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting new requests, waits for in-flight requests and background jobs
+// until ctx expires, then runs the shutdown functions within closeTimeout and flushes and closes
+// the logger
+func (c *Container) Shutdown(ctx context.Context) error {
+	var shutdownErr error
+	if err := c.Server.Shutdown(ctx); err != nil {
+		c.Logger.Error("Error draining HTTP requests", zap.Error(err))
+		shutdownErr = errors.Join(shutdownErr, err)
+	}
+
+	jobsDone := make(chan struct{})
+	go func() {
+		c.lifecycle.jobs.Wait()
+		close(jobsDone)
+	}()
+	select {
+	case <-jobsDone:
+	case <-ctx.Done():
+		c.Logger.Error("Background jobs did not finish before the shutdown deadline")
+		shutdownErr = errors.Join(shutdownErr, ctx.Err())
+	}
+	c.lifecycle.cancelJobs()
+
+	c.lifecycle.mu.Lock()
+	closers := c.lifecycle.closers
+	c.lifecycle.closers = nil
+	c.lifecycle.mu.Unlock()
+	closeCtx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i](closeCtx); err != nil {
+			c.Logger.Error("Error running shutdown function", zap.Error(err))
+			shutdownErr = errors.Join(shutdownErr, err)
+		}
+	}
+
+	c.Logger.Info("Shutdown complete")
+	// Sync commonly fails on stdout/stderr, which are not real files, so the error is ignored
+	_ = c.Logger.Sync()
+	if c.logOutput != nil {
+		if err := c.logOutput.Close(); err != nil {
+			shutdownErr = errors.Join(shutdownErr, err)
+		}
+	}
+	return shutdownErr
+}
+
+func (c *Container) shutdownTimeout() time.Duration {
+	return config.DurationOrDefault(c.Config, "api.shutdown_timeout", 30*time.Second)
+}
+
+// closeAll closes every closer and joins their errors
+func closeAll(closers []io.Closer) error {
+	var errs []error
+	for _, closer := range closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newLifecycleContainer(handler http.Handler, shutdownTimeout string) *Container {
+	cfg := viper.New()
+	cfg.Set("api.shutdown_timeout", shutdownTimeout)
+	return &Container{
+		Config:    cfg,
+		Logger:    zap.NewNop(),
+		Server:    &http.Server{Handler: handler},
+		lifecycle: newLifecycle(),
+	}
+}
+
+// ============================================================================
+// Graceful Shutdown Tests
+// ============================================================================
+
+func TestContainer_Serve(t *testing.T) {
+	t.Run("DrainsInFlightRequests", func(t *testing.T) {
+		// Arrange
+		started := make(chan struct{})
+		release := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			w.Write([]byte("done"))
+		})
+		container := newLifecycleContainer(handler, "5s")
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		serveErr := make(chan error, 1)
+		go func() {
+			serveErr <- container.Serve(ctx, listener)
+		}()
+
+		responseBody := make(chan string, 1)
+		go func() {
+			resp, err := http.Get("http://" + listener.Addr().String())
+			if err != nil {
+				responseBody <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			responseBody <- string(body)
+		}()
+		<-started
+
+		// Act
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		// Assert
+		assert.Equal(t, "done", <-responseBody)
+		assert.NoError(t, <-serveErr)
+	})
+
+	t.Run("WaitsForBackgroundJobsAndRunsClosers", func(t *testing.T) {
+		// Arrange
+		container := newLifecycleContainer(http.NotFoundHandler(), "5s")
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		var jobFinished, closerRan atomic.Bool
+		container.Go(func(ctx context.Context) {
+			time.Sleep(50 * time.Millisecond)
+			jobFinished.Store(true)
+		})
+		container.OnShutdown(func(ctx context.Context) error {
+			closerRan.Store(jobFinished.Load())
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		err = container.Serve(ctx, listener)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, jobFinished.Load())
+		assert.True(t, closerRan.Load(), "closers run after background jobs finish")
+	})
+
+	t.Run("CancelsBackgroundJobsAfterDeadline", func(t *testing.T) {
+		// Arrange
+		container := newLifecycleContainer(http.NotFoundHandler(), "50ms")
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		jobCancelled := make(chan struct{})
+		container.Go(func(ctx context.Context) {
+			<-ctx.Done()
+			close(jobCancelled)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		err = container.Serve(ctx, listener)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		select {
+		case <-jobCancelled:
+		case <-time.After(time.Second):
+			t.Fatal("background job was not cancelled")
+		}
+	})
+
+	t.Run("ClosersOutliveDrainDeadline", func(t *testing.T) {
+		// Arrange
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		})
+		container := newLifecycleContainer(handler, "50ms")
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		var closerErr error
+		var closerDeadline time.Time
+		container.OnShutdown(func(ctx context.Context) error {
+			closerErr = ctx.Err()
+			closerDeadline, _ = ctx.Deadline()
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		serveErr := make(chan error, 1)
+		go func() {
+			serveErr <- container.Serve(ctx, listener)
+		}()
+		go http.Get("http://" + listener.Addr().String())
+		<-started
+
+		// Act
+		cancel()
+		err = <-serveErr
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NoError(t, closerErr, "closers get a live context after the drain times out")
+		assert.True(t, closerDeadline.After(time.Now()))
+	})
+}
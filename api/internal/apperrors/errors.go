@@ -0,0 +1,84 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code identifies a class of failure that clients can act on
+type Code string
+
+const (
+	CodeValidationFailed  Code = "VALIDATION_FAILED"
+	CodeSubredditNotFound Code = "SUBREDDIT_NOT_FOUND"
+	CodeSourceNotFound    Code = "SOURCE_NOT_FOUND"
+	CodeSourceUnavailable Code = "SOURCE_UNAVAILABLE"
+	CodeRedditRateLimited Code = "REDDIT_RATE_LIMITED"
+	CodeRedditUnavailable Code = "REDDIT_UNAVAILABLE"
+	CodeLLMUnavailable    Code = "LLM_UNAVAILABLE"
+	CodeUnauthenticated   Code = "UNAUTHENTICATED"
+	CodeForbidden         Code = "FORBIDDEN"
+	CodeRateLimited       Code = "RATE_LIMITED"
+	CodeQuotaExceeded     Code = "QUOTA_EXCEEDED"
+	CodeInternal          Code = "INTERNAL"
+)
+
+// Error is a failure with a code and a message that are safe to show to clients.
+// The cause is kept for logs and never sent to clients.
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+	// Fields lists the rejected request fields of a validation failure
+	Fields []FieldError
+}
+
+// FieldError describes why the value of a request field was rejected
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// New creates an error without an underlying cause
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap creates an error with code and message for the failure err
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// Validation creates a validation failure for the rejected fields of a request
+func Validation(err error, fields []FieldError) *Error {
+	return &Error{Code: CodeValidationFailed, Message: "The request has invalid fields", Err: err, Fields: fields}
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// As returns the first *Error in the chain of err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// CodeOf returns the code of the first *Error in the chain of err, or CodeInternal when there is none
+func CodeOf(err error) Code {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+	return CodeInternal
+}
@@ -0,0 +1,59 @@
+package apperrors
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// CodeOf Tests
+// ============================================================================
+
+func TestCodeOf(t *testing.T) {
+	t.Run("WrappedError", func(t *testing.T) {
+		// Arrange
+		err := errors.Wrap(Wrap(assert.AnError, CodeLLMUnavailable, "The language model is unavailable"), "error getting topic embedding")
+
+		// Act
+		code := CodeOf(err)
+
+		// Assert
+		assert.Equal(t, CodeLLMUnavailable, code)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("UncodedError", func(t *testing.T) {
+		// Act
+		code := CodeOf(assert.AnError)
+
+		// Assert
+		assert.Equal(t, CodeInternal, code)
+	})
+}
+
+// ============================================================================
+// Error Tests
+// ============================================================================
+
+func TestError_Error(t *testing.T) {
+	t.Run("WithCause", func(t *testing.T) {
+		// Arrange
+		err := Wrap(errors.New("reddit API returned status 404"), CodeSubredditNotFound, "Subreddit r/golang does not exist or is private")
+
+		// Act
+		message := err.Error()
+
+		// Assert
+		assert.Equal(t, "Subreddit r/golang does not exist or is private: reddit API returned status 404", message)
+	})
+
+	t.Run("WithoutCause", func(t *testing.T) {
+		// Act
+		message := New(CodeForbidden, "Not allowed").Error()
+
+		// Assert
+		assert.Equal(t, "Not allowed", message)
+	})
+}
@@ -0,0 +1,6 @@
+package contracts
+
+// LogLevelDto is the minimum level of the log entries that are written
+type LogLevelDto struct {
+	Level string `json:"level" binding:"required" example:"debug"`
+}
@@ -0,0 +1,21 @@
+package contracts
+
+// ProblemDetails is an RFC 7807 error body, served as application/problem+json
+type ProblemDetails struct {
+	Type      string `json:"type" example:"/problems/subreddit-not-found"`
+	Title     string `json:"title" example:"Subreddit not found"`
+	Status    int    `json:"status" example:"404"`
+	Detail    string `json:"detail,omitempty" example:"Subreddit r/doesnotexist does not exist or is private"`
+	Instance  string `json:"instance,omitempty" example:"/v1/reddit/relevance/search"`
+	Code      string `json:"code" example:"SUBREDDIT_NOT_FOUND"`
+	RequestID string `json:"request_id,omitempty" example:"4f9c2a7be1d04c3f9a6e2b8d7c1f0e5a"`
+	// Errors lists the rejected fields of a VALIDATION_FAILED problem
+	Errors []FieldErrorDto `json:"errors,omitempty"`
+}
+
+// FieldErrorDto describes why the value of a request field was rejected
+type FieldErrorDto struct {
+	Field   string `json:"field" example:"subreddits[0]"`
+	Rule    string `json:"rule" example:"subreddit"`
+	Message string `json:"message" example:"must be a subreddit name of 2 to 21 letters, digits or underscores"`
+}
@@ -6,15 +6,105 @@ type SearchMethod string
 
 const (
 	SearchMethodSearch SearchMethod = "search"
-	SearchMethodLatest SearchMethod = "latest"
+	// SearchMethodLatest is the newest posts of the subreddits, same as SearchMethodNew
+	SearchMethodLatest        SearchMethod = "latest"
+	SearchMethodHot           SearchMethod = "hot"
+	SearchMethodNew           SearchMethod = "new"
+	SearchMethodTop           SearchMethod = "top"
+	SearchMethodRising        SearchMethod = "rising"
+	SearchMethodControversial SearchMethod = "controversial"
+)
+
+// SearchScope is how the subreddits of a request are fetched
+type SearchScope string
+
+const (
+	// SearchScopeSubreddit fetches every subreddit with its own Reddit request
+	SearchScopeSubreddit SearchScope = "subreddit"
+	// SearchScopeMultireddit fetches all the subreddits with one Reddit request, as r/a+b+c
+	SearchScopeMultireddit SearchScope = "multireddit"
+	// SearchScopeAll searches all of Reddit, or lists r/all, and needs no subreddits
+	SearchScopeAll SearchScope = "all"
+)
+
+// SearchSort is the order of the results of the search method
+type SearchSort string
+
+const (
+	SearchSortRelevance SearchSort = "relevance"
+	SearchSortNew       SearchSort = "new"
+	SearchSortComments  SearchSort = "comments"
+)
+
+// TimeWindow restricts the posts of the search, top and controversial methods to the last hour, day, ...
+type TimeWindow string
+
+const (
+	TimeWindowHour  TimeWindow = "hour"
+	TimeWindowDay   TimeWindow = "day"
+	TimeWindowWeek  TimeWindow = "week"
+	TimeWindowMonth TimeWindow = "month"
+	TimeWindowYear  TimeWindow = "year"
+	TimeWindowAll   TimeWindow = "all"
+)
+
+// TopicAggregation is how the scores of a post against several topics are combined
+type TopicAggregation string
+
+const (
+	TopicAggregationMax  TopicAggregation = "max"
+	TopicAggregationMean TopicAggregation = "mean"
 )
 
 type RelevanceRequestDto struct {
-	Topic              string       `json:"topic" binding:"required"`
-	Subreddits         []string     `json:"subreddits"`
-	RelevanceThreshold float64      `json:"relevance_threshold"`
-	Limit              int          `json:"limit"`
-	CreatedAfter       time.Time    `json:"created_after"`
-	MinNumComments     int          `json:"min_num_comments"`
-	SearchMethod       SearchMethod `json:"search_method" binding:"required,oneof=search latest"`
+	Topic              string   `json:"topic" binding:"required_without=Topics,max=500" maxLength:"500" example:"golang generics"`
+	Subreddits         []string `json:"subreddits" binding:"omitempty,min=1,max=10,dive,subreddit" minItems:"1" maxItems:"10" example:"golang,programming"`
+	RelevanceThreshold float64  `json:"relevance_threshold" binding:"gte=0,lte=1" minimum:"0" maximum:"1" example:"0.6"`
+	Limit              int      `json:"limit" binding:"omitempty,min=1,max=100" minimum:"1" maximum:"100" example:"25"`
+	// CreatedAfter drops the posts created before it, no bound when zero. Posts whose source
+	// does not tell when they were created, such as feed items without a date, are kept.
+	CreatedAfter time.Time `json:"created_after" binding:"created_after"`
+	// MinNumComments drops the posts with fewer comments. Feed items have no comment count,
+	// so any value above zero drops them.
+	MinNumComments int          `json:"min_num_comments" binding:"gte=0" minimum:"0"`
+	SearchMethod   SearchMethod `json:"search_method" binding:"required,oneof=search latest hot new top rising controversial" enums:"search,latest,hot,new,top,rising,controversial"`
+	// Sources are analyzed along with the subreddits: user:{name} for the posts a Reddit user
+	// submitted, domain:{host} for the Reddit posts linking to a domain, hackernews:{tag} for Hacker
+	// News items, feed:{url} for RSS and Atom feeds and lemmy:{community@instance} for Lemmy
+	// communities. Hacker News and Lemmy run every search query of the search method, as the
+	// subreddits do, the others are fetched from their listing, sorted as the listing search methods say.
+	Sources []string `json:"sources" binding:"omitempty,max=10,dive,source" maxItems:"10" example:"user:spez,hackernews:story"`
+	// Scope fetches the subreddits one by one, together as a multireddit or searches all of
+	// Reddit, subreddit when empty. Limit applies to every Reddit request.
+	Scope SearchScope `json:"scope" binding:"omitempty,oneof=subreddit multireddit all" enums:"subreddit,multireddit,all"`
+	// SearchSort orders the results of the search method, relevance when empty
+	SearchSort SearchSort `json:"search_sort" binding:"omitempty,oneof=relevance new comments" enums:"relevance,new,comments"`
+	// TimeWindow restricts the search, top and controversial methods to recent posts. Search
+	// covers all time and top and controversial the last day when empty.
+	TimeWindow TimeWindow `json:"time_window" binding:"omitempty,oneof=hour day week month year all" enums:"hour,day,week,month,year,all"`
+	// Topics are scored along with Topic, a post is relevant to their max or mean score
+	Topics []string `json:"topics" binding:"omitempty,max=10,dive,required,max=500" maxItems:"10" example:"golang iterators"`
+	// ExcludeTopics penalize the posts that are about them
+	ExcludeTopics []string `json:"exclude_topics" binding:"omitempty,max=10,dive,required,max=500" maxItems:"10" example:"job postings"`
+	// TopicAggregation combines the scores of the topics, max when empty
+	TopicAggregation TopicAggregation `json:"topic_aggregation" binding:"omitempty,oneof=max mean" enums:"max,mean"`
+	// ExclusionThreshold is the score against an exclusion topic from which a post is penalized, 0.6 when zero
+	ExclusionThreshold float64 `json:"exclusion_threshold" binding:"gte=0,lte=1" minimum:"0" maximum:"1" example:"0.6"`
+	// ExclusionPenalty is the share of the relevance score a penalized post loses, 0.5 when zero
+	ExclusionPenalty float64 `json:"exclusion_penalty" binding:"gte=0,lte=1" minimum:"0" maximum:"1" example:"0.5"`
+	// ExpandQuery has the chat model rewrite the topics into several search queries, for the search method
+	ExpandQuery bool `json:"expand_query"`
+	// Deduplicate groups the crossposts, the posts linking to the same page and the posts whose
+	// embeddings are at least DuplicateThreshold similar under one canonical post
+	Deduplicate bool `json:"deduplicate"`
+	// DuplicateThreshold is the cosine similarity from which two posts are near duplicates, 0.92 when zero
+	DuplicateThreshold float64 `json:"duplicate_threshold" binding:"gte=0,lte=1" minimum:"0" maximum:"1" example:"0.92"`
+	// MaxSearchQueries caps the search queries run per subreddit or source when ExpandQuery is set, 3 when zero
+	MaxSearchQueries int `json:"max_search_queries" binding:"omitempty,min=1,max=5" minimum:"1" maximum:"5" example:"3"`
+	// AnalyzeSentiment has the chat model classify the sentiment of the relevant posts and their
+	// stance toward the topics
+	AnalyzeSentiment bool `json:"analyze_sentiment"`
+	// SentimentComments is how many top comments of every relevant Reddit post are classified
+	// along with it when AnalyzeSentiment is set, none when zero
+	SentimentComments int `json:"sentiment_comments" binding:"gte=0,lte=50" minimum:"0" maximum:"50" example:"10"`
 }
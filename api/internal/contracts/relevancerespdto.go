@@ -4,10 +4,17 @@ import "time"
 
 type RelevanceResponseDto struct {
 	Posts []SubRedditPostDto `json:"posts"`
+	// SearchQueries are the Reddit search queries that were run in every subreddit
+	SearchQueries []string `json:"search_queries,omitempty"`
+	// SentimentBySubreddit totals the sentiments and stances of the relevant posts of every
+	// subreddit or community, in the order they first appear, when the request analyzes sentiment
+	SentimentBySubreddit []SubredditSentimentDto `json:"sentiment_by_subreddit,omitempty"`
 }
 
 type SubRedditPostDto struct {
+	ID               string    `json:"id"`
 	SubredditName    string    `json:"subreddit_name"`
+	Author           string    `json:"author"`
 	Title            string    `json:"title"`
 	Content          string    `json:"content"`
 	Url              string    `json:"url"`
@@ -17,4 +24,145 @@ type SubRedditPostDto struct {
 	IsRelevant       bool      `json:"is_relevant"`
 	RelevanceScore   float64   `json:"relevance_score"`
 	RelevanceSummary string    `json:"relevance_summary"`
+	// TopicScores are the scores of the post against each topic of the request
+	TopicScores []TopicScoreDto `json:"topic_scores"`
+	// ExclusionScores are the scores of the post against each exclusion topic of the request
+	ExclusionScores []TopicScoreDto `json:"exclusion_scores,omitempty"`
+	// IsExcluded is set when the relevance score was penalized for an exclusion topic
+	IsExcluded bool `json:"is_excluded"`
+	// Source is the source of the request that returned the post: r/{subreddit}, user:{name},
+	// domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}
+	Source string `json:"source" example:"r/golang"`
+	// MatchedQueries are the search queries that found the post
+	MatchedQueries []string `json:"matched_queries,omitempty"`
+	// Subreddits are the subreddits and communities the post and its duplicates appeared in,
+	// the one of the post first, when the request deduplicates posts
+	Subreddits []string `json:"subreddits,omitempty" example:"golang,programming"`
+	// CombinedScore is the score of the post and its duplicates, when the request deduplicates posts
+	CombinedScore int `json:"combined_score,omitempty"`
+	// Duplicates are the posts grouped under this one, which is the one with the highest score
+	Duplicates []DuplicatePostDto `json:"duplicates,omitempty"`
+	// Media describes the images or video of media posts
+	Media *MediaDto `json:"media,omitempty"`
+	// Sentiment is the sentiment and stance of relevant posts, when the request analyzes sentiment
+	Sentiment *SentimentDto `json:"sentiment,omitempty"`
+}
+
+// Sentiment is the overall tone of a post or comment
+type Sentiment string
+
+const (
+	SentimentPositive Sentiment = "positive"
+	SentimentNegative Sentiment = "negative"
+	SentimentNeutral  Sentiment = "neutral"
+	// SentimentMixed is a post or comment with both positive and negative parts
+	SentimentMixed Sentiment = "mixed"
+)
+
+// Stance is the position of a post or comment toward the topics of the request
+type Stance string
+
+const (
+	StanceFavor   Stance = "favor"
+	StanceAgainst Stance = "against"
+	StanceNeutral Stance = "neutral"
+)
+
+// SentimentDto is the sentiment and stance of a post, and the totals of its classified comments
+type SentimentDto struct {
+	Sentiment Sentiment `json:"sentiment" enums:"positive,negative,neutral,mixed"`
+	Stance    Stance    `json:"stance" enums:"favor,against,neutral"`
+	// Comments totals the classified comments, when the request classifies comments and the post has some
+	Comments *SentimentBreakdownDto `json:"comments,omitempty"`
+}
+
+// SentimentBreakdownDto counts posts or comments by sentiment and by stance
+type SentimentBreakdownDto struct {
+	Total      int                `json:"total"`
+	Sentiments SentimentCountsDto `json:"sentiments"`
+	Stances    StanceCountsDto    `json:"stances"`
+}
+
+type SentimentCountsDto struct {
+	Positive int `json:"positive"`
+	Negative int `json:"negative"`
+	Neutral  int `json:"neutral"`
+	Mixed    int `json:"mixed"`
+}
+
+type StanceCountsDto struct {
+	Favor   int `json:"favor"`
+	Against int `json:"against"`
+	Neutral int `json:"neutral"`
+}
+
+// SubredditSentimentDto totals the sentiments of the relevant posts of a subreddit or community
+type SubredditSentimentDto struct {
+	SubredditName string                `json:"subreddit_name" example:"golang"`
+	Posts         SentimentBreakdownDto `json:"posts"`
+	// Comments totals the classified comments of the posts, zero when comments are not classified
+	Comments SentimentBreakdownDto `json:"comments"`
+}
+
+// MediaKind is the kind of media a post is
+type MediaKind string
+
+const (
+	// MediaKindImage is a post of a single image, or a text post with inline images
+	MediaKindImage MediaKind = "image"
+	// MediaKindGallery is a Reddit gallery of several images
+	MediaKindGallery MediaKind = "gallery"
+	// MediaKindVideo is a video hosted by Reddit or an embedded video, such as a YouTube link
+	MediaKindVideo MediaKind = "video"
+)
+
+// MediaDto describes the media of a post and the text found about it
+type MediaDto struct {
+	Kind  MediaKind      `json:"kind" enums:"image,gallery,video"`
+	Items []MediaItemDto `json:"items,omitempty"`
+	// Description is the description of linked media, such as the oEmbed description of a video
+	Description string `json:"description,omitempty"`
+	// GeneratedCaption is the caption written by the multimodal model, when one is configured
+	GeneratedCaption string `json:"generated_caption,omitempty"`
+}
+
+// MediaItemDto is an image or video of a post
+type MediaItemDto struct {
+	Url string `json:"url"`
+	// Caption is the gallery caption or alt text of the item
+	Caption string `json:"caption,omitempty"`
+}
+
+// DuplicateReason is why a post is a duplicate of another
+type DuplicateReason string
+
+const (
+	// DuplicateReasonSamePost is the same post returned by several subreddits or sources
+	DuplicateReasonSamePost DuplicateReason = "same_post"
+	// DuplicateReasonCrosspost is a crosspost of the post, the post of a crosspost or a sibling crosspost
+	DuplicateReasonCrosspost DuplicateReason = "crosspost"
+	// DuplicateReasonURL links to the same page once tracking parameters are removed
+	DuplicateReasonURL DuplicateReason = "url"
+	// DuplicateReasonSimilar has an embedding at least as similar as the duplicate threshold
+	DuplicateReasonSimilar DuplicateReason = "similar"
+)
+
+// DuplicatePostDto is a post grouped under a canonical post
+type DuplicatePostDto struct {
+	ID            string          `json:"id"`
+	SubredditName string          `json:"subreddit_name"`
+	Source        string          `json:"source" example:"r/programming"`
+	Title         string          `json:"title"`
+	Url           string          `json:"url"`
+	Score         int             `json:"score"`
+	NumComments   int             `json:"num_comments"`
+	Reason        DuplicateReason `json:"reason" enums:"same_post,crosspost,url,similar"`
+	// Similarity is the cosine similarity with the canonical post, for similar duplicates
+	Similarity float64 `json:"similarity,omitempty" example:"0.95"`
+}
+
+// TopicScoreDto is the cosine similarity between a post and a topic
+type TopicScoreDto struct {
+	Topic string  `json:"topic" example:"golang generics"`
+	Score float64 `json:"score" example:"0.72"`
 }
@@ -0,0 +1,25 @@
+package contracts
+
+import "time"
+
+// SubredditSuggestionRequestDto asks for the subreddits that best match a topic
+type SubredditSuggestionRequestDto struct {
+	Topic string `json:"topic" form:"topic" binding:"required,max=500"`
+	Limit int    `json:"limit" form:"limit" binding:"omitempty,min=1,max=25"`
+}
+
+type SubredditSuggestionsResponseDto struct {
+	Suggestions []SubredditSuggestionDto `json:"suggestions"`
+}
+
+// SubredditSuggestionDto is a subreddit ranked by how close its description is to the topic
+type SubredditSuggestionDto struct {
+	Name           string    `json:"name" example:"golang"`
+	Title          string    `json:"title" example:"The Go Programming Language"`
+	Description    string    `json:"description" example:"Ask questions and post articles about the Go programming language and related tools, events etc."`
+	Url            string    `json:"url" example:"https://www.reddit.com/r/golang/"`
+	Subscribers    int       `json:"subscribers" example:"310000"`
+	ActiveUsers    int       `json:"active_users" example:"420"`
+	CreatedAt      time.Time `json:"created_at"`
+	RelevanceScore float64   `json:"relevance_score" example:"0.81"`
+}
@@ -0,0 +1,72 @@
+package contracts
+
+import "time"
+
+// TrendGranularity is the length of the buckets of a trend
+type TrendGranularity string
+
+const (
+	TrendGranularityHour TrendGranularity = "hour"
+	TrendGranularityDay  TrendGranularity = "day"
+	// TrendGranularityWeek buckets start on Mondays
+	TrendGranularityWeek TrendGranularity = "week"
+)
+
+// TrendRequestDto asks for the trend of a topic over the stored runs that searched it
+type TrendRequestDto struct {
+	Topic string `json:"topic" form:"topic" binding:"required,max=500"`
+	// Subreddits restricts the trend to these subreddits or communities, all of them when empty
+	Subreddits []string `json:"subreddits" form:"subreddits" binding:"omitempty,max=10,dive,required,max=100"`
+	// Granularity is the length of the buckets, day when empty
+	Granularity TrendGranularity `json:"granularity" form:"granularity" binding:"omitempty,oneof=hour day week" enums:"hour,day,week"`
+	// From is the start of the trend, 30 buckets before To when empty
+	From time.Time `json:"from" form:"from"`
+	// To is the end of the trend, now when empty
+	To time.Time `json:"to" form:"to"`
+	// BaselineWindow is how many previous buckets the spike baseline averages, 7 when zero
+	BaselineWindow int `json:"baseline_window" form:"baseline_window" binding:"omitempty,min=2,max=90" minimum:"2" maximum:"90" example:"7"`
+	// SpikeThreshold is how many standard deviations above the baseline a spike is, 2 when zero
+	SpikeThreshold float64 `json:"spike_threshold" form:"spike_threshold" binding:"gte=0,lte=10" minimum:"0" maximum:"10" example:"2"`
+}
+
+type TrendResponseDto struct {
+	Topic       string           `json:"topic" example:"golang generics"`
+	Granularity TrendGranularity `json:"granularity" enums:"hour,day,week"`
+	// From is the start of the first bucket and To the end of the trend
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Runs is how many stored runs of the topic the trend is built from
+	Runs int `json:"runs"`
+	// Subreddits are the trends of the subreddits and communities with posts, most relevant posts first
+	Subreddits []SubredditTrendDto `json:"subreddits"`
+}
+
+// SubredditTrendDto is the trend of a topic in a subreddit or community
+type SubredditTrendDto struct {
+	SubredditName string `json:"subreddit_name" example:"golang"`
+	// RelevantPosts is the total of the buckets
+	RelevantPosts int              `json:"relevant_posts"`
+	Buckets       []TrendBucketDto `json:"buckets"`
+}
+
+// TrendBucketDto describes the posts created in a bucket. Posts evaluated by several runs count
+// once, as the latest run evaluated them.
+type TrendBucketDto struct {
+	Start time.Time `json:"start"`
+	// Posts is how many posts were evaluated, relevant or not
+	Posts         int `json:"posts"`
+	RelevantPosts int `json:"relevant_posts"`
+	// AverageRelevance is the mean relevance score of the evaluated posts
+	AverageRelevance float64 `json:"average_relevance"`
+	// AverageScore and AverageComments are the mean engagement of the relevant posts
+	AverageScore    float64 `json:"average_score"`
+	AverageComments float64 `json:"average_comments"`
+	// Sentiments and Stances count the relevant posts whose sentiment was analyzed
+	Sentiments SentimentCountsDto `json:"sentiments"`
+	Stances    StanceCountsDto    `json:"stances"`
+	// Baseline is the mean of the relevant posts of the previous buckets of the baseline window,
+	// which reaches before From for the first buckets
+	Baseline float64 `json:"baseline"`
+	// IsSpike is set when the relevant posts are unusually high compared to the baseline
+	IsSpike bool `json:"is_spike"`
+}
@@ -0,0 +1,56 @@
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Example is a post labeled by a human as relevant or not relevant to a topic
+type Example struct {
+	ID       string `json:"id"`
+	Topic    string `json:"topic"`
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	Relevant bool   `json:"relevant"`
+}
+
+// LoadDataset reads a labeled dataset in JSON Lines format, one Example per line.
+// Blank lines and lines starting with # are ignored.
+func LoadDataset(path string) ([]Example, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer file.Close()
+
+	examples := make([]Example, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var example Example
+		if err := json.Unmarshal([]byte(line), &example); err != nil {
+			return nil, fmt.Errorf("failed to decode dataset line %d: %w", lineNumber, err)
+		}
+		if example.Topic == "" {
+			return nil, fmt.Errorf("dataset line %d has no topic", lineNumber)
+		}
+		if example.ID == "" {
+			example.ID = fmt.Sprintf("line-%d", lineNumber)
+		}
+		examples = append(examples, example)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	return examples, nil
+}
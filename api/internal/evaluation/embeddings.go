@@ -0,0 +1,137 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+)
+
+// EmbeddingStore holds recorded embeddings keyed by the exact text that was embedded
+type EmbeddingStore struct {
+	mu         sync.RWMutex
+	Model      string               `json:"model,omitempty"`
+	Embeddings map[string][]float32 `json:"embeddings"`
+}
+
+// NewEmbeddingStore creates an empty embedding store
+func NewEmbeddingStore() *EmbeddingStore {
+	return &EmbeddingStore{
+		Embeddings: make(map[string][]float32),
+	}
+}
+
+// LoadEmbeddingStore reads recorded embeddings from a JSON file
+func LoadEmbeddingStore(path string) (*EmbeddingStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings: %w", err)
+	}
+
+	store := NewEmbeddingStore()
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings: %w", err)
+	}
+	if store.Embeddings == nil {
+		store.Embeddings = make(map[string][]float32)
+	}
+	return store, nil
+}
+
+// Save writes the recorded embeddings to a JSON file
+func (s *EmbeddingStore) Save(path string) error {
+	s.mu.RLock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode embeddings: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write embeddings: %w", err)
+	}
+	return nil
+}
+
+// Get returns the recorded embedding for text
+func (s *EmbeddingStore) Get(text string) ([]float32, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	embedding, ok := s.Embeddings[text]
+	return embedding, ok
+}
+
+// Put records the embedding for text
+func (s *EmbeddingStore) Put(text string, embedding []float32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Embeddings[text] = embedding
+}
+
+// ReplayClient is an llm.ClientInterface that answers embedding calls from recorded embeddings
+// and never touches the network
+type ReplayClient struct {
+	store *EmbeddingStore
+}
+
+// NewReplayClient creates a client that replays embeddings from store
+func NewReplayClient(store *EmbeddingStore) *ReplayClient {
+	return &ReplayClient{store: store}
+}
+
+// GetEmbedding returns the recorded embedding for text
+func (c *ReplayClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	embedding, ok := c.store.Get(text)
+	if !ok {
+		return nil, fmt.Errorf("no recorded embedding for text %q", truncate(text, 80))
+	}
+	return embedding, nil
+}
+
+// Chat is not supported when replaying recorded embeddings
+func (c *ReplayClient) Chat(ctx context.Context, messages []llm.Message) (string, error) {
+	return "", fmt.Errorf("chat is not available when replaying recorded embeddings")
+}
+
+// RecordingClient forwards calls to another client and records every embedding it returns
+type RecordingClient struct {
+	client llm.ClientInterface
+	store  *EmbeddingStore
+}
+
+// NewRecordingClient creates a client that records the embeddings returned by client into store
+func NewRecordingClient(client llm.ClientInterface, store *EmbeddingStore) *RecordingClient {
+	return &RecordingClient{
+		client: client,
+		store:  store,
+	}
+}
+
+// GetEmbedding returns a recorded embedding when available, otherwise it calls the wrapped client and records the result
+func (c *RecordingClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if embedding, ok := c.store.Get(text); ok {
+		return embedding, nil
+	}
+
+	embedding, err := c.client.GetEmbedding(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	c.store.Put(text, embedding)
+	return embedding, nil
+}
+
+// Chat forwards to the wrapped client
+func (c *RecordingClient) Chat(ctx context.Context, messages []llm.Message) (string, error) {
+	return c.client.Chat(ctx, messages)
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length]) + "..."
+}
@@ -0,0 +1,52 @@
+package evaluation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+)
+
+// Evaluator scores labeled examples with the same scorer used by the relevance service
+type Evaluator struct {
+	llmClient llm.ClientInterface
+	scorer    services.RelevanceScorer
+}
+
+// NewEvaluator creates an evaluator that embeds topics with llmClient and scores examples with scorer
+func NewEvaluator(llmClient llm.ClientInterface, scorer services.RelevanceScorer) *Evaluator {
+	return &Evaluator{
+		llmClient: llmClient,
+		scorer:    scorer,
+	}
+}
+
+// Score runs the scorer on every example. Topic embeddings are computed once per distinct topic.
+func (e *Evaluator) Score(ctx context.Context, examples []Example) ([]ScoredExample, error) {
+	topicEmbeddings := make(map[string][]float32)
+	scored := make([]ScoredExample, 0, len(examples))
+
+	for _, example := range examples {
+		topicEmbedding, ok := topicEmbeddings[example.Topic]
+		if !ok {
+			var err error
+			topicEmbedding, err = e.llmClient.GetEmbedding(ctx, example.Topic)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error getting topic embedding for %q", example.Topic)
+			}
+			topicEmbeddings[example.Topic] = topicEmbedding
+		}
+
+		score, err := e.scorer.Score(ctx, example.Title, example.Content, topicEmbedding)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error scoring example %s", example.ID)
+		}
+		scored = append(scored, ScoredExample{
+			Example: example,
+			Score:   score,
+		})
+	}
+	return scored, nil
+}
@@ -0,0 +1,130 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ============================================================================
+// Evaluator Tests
+// ============================================================================
+
+func TestEvaluator_Score(t *testing.T) {
+	t.Run("ReplaysRecordedDataset", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		examples, err := LoadDataset(filepath.Join("testdata", "dataset.jsonl"))
+		assert.NoError(t, err)
+		store, err := LoadEmbeddingStore(filepath.Join("testdata", "embeddings.json"))
+		assert.NoError(t, err)
+
+		client := NewReplayClient(store)
+		evaluator := NewEvaluator(client, services.NewEmbeddingScorer(client))
+
+		// Act
+		scored, err := evaluator.Score(ctx, examples)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, scored, len(examples))
+		report, err := NewReport(scored, 0.5)
+		assert.NoError(t, err)
+		assert.Greater(t, report.ROCAUC, 0.5)
+	})
+
+	t.Run("EmbedsEachTopicOnce", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		evaluator := NewEvaluator(mockLLMClient, services.NewEmbeddingScorer(mockLLMClient))
+
+		examples := []Example{
+			{ID: "1", Topic: "go", Title: "A", Content: "a", Relevant: true},
+			{ID: "2", Topic: "go", Title: "B", Content: "b", Relevant: false},
+		}
+
+		mockLLMClient.EXPECT().GetEmbedding(ctx, "go").Return([]float32{1, 0}, nil).Once()
+		mockLLMClient.EXPECT().GetEmbedding(ctx, "A. a").Return([]float32{1, 0}, nil)
+		mockLLMClient.EXPECT().GetEmbedding(ctx, "B. b").Return([]float32{0, 1}, nil)
+
+		// Act
+		scored, err := evaluator.Score(ctx, examples)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.0, scored[0].Score, 0.0001)
+		assert.InDelta(t, 0.0, scored[1].Score, 0.0001)
+	})
+
+	t.Run("MissingRecordedEmbedding", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		client := NewReplayClient(NewEmbeddingStore())
+		evaluator := NewEvaluator(client, services.NewEmbeddingScorer(client))
+
+		// Act
+		_, err := evaluator.Score(ctx, []Example{{ID: "1", Topic: "go", Title: "A"}})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded embedding")
+	})
+}
+
+// ============================================================================
+// RecordingClient Tests
+// ============================================================================
+
+func TestRecordingClient(t *testing.T) {
+	t.Run("RecordsAndSaves", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		store := NewEmbeddingStore()
+		client := NewRecordingClient(mockLLMClient, store)
+		path := filepath.Join(t.TempDir(), "embeddings.json")
+
+		mockLLMClient.EXPECT().GetEmbedding(ctx, "text").Return([]float32{0.1, 0.2}, nil).Once()
+
+		// Act
+		first, err := client.GetEmbedding(ctx, "text")
+		assert.NoError(t, err)
+		second, err := client.GetEmbedding(ctx, "text")
+		assert.NoError(t, err)
+		saveErr := store.Save(path)
+		loaded, loadErr := LoadEmbeddingStore(path)
+
+		// Assert
+		assert.Equal(t, first, second)
+		assert.NoError(t, saveErr)
+		assert.NoError(t, loadErr)
+		embedding, ok := loaded.Get("text")
+		assert.True(t, ok)
+		assert.Equal(t, []float32{0.1, 0.2}, embedding)
+	})
+
+	t.Run("DoesNotRecordErrors", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		store := NewEmbeddingStore()
+		client := NewRecordingClient(mockLLMClient, store)
+
+		mockLLMClient.EXPECT().GetEmbedding(ctx, mock.Anything).Return(nil, errors.New("unavailable"))
+
+		// Act
+		_, err := client.GetEmbedding(ctx, "text")
+
+		// Assert
+		assert.Error(t, err)
+		_, ok := store.Get("text")
+		assert.False(t, ok)
+	})
+}
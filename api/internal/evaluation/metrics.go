@@ -0,0 +1,180 @@
+package evaluation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ScoredExample is a labeled example together with the score assigned by the scorer
+type ScoredExample struct {
+	Example
+	Score float64 `json:"score"`
+}
+
+// ThresholdMetrics holds the classification metrics obtained when posts with a score
+// greater than or equal to Threshold are considered relevant
+type ThresholdMetrics struct {
+	Threshold      float64 `json:"threshold"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+	F1             float64 `json:"f1"`
+	TruePositives  int     `json:"true_positives"`
+	FalsePositives int     `json:"false_positives"`
+	TrueNegatives  int     `json:"true_negatives"`
+	FalseNegatives int     `json:"false_negatives"`
+}
+
+// Report summarizes how well the scorer separates relevant from not relevant examples
+type Report struct {
+	Examples    int              `json:"examples"`
+	Positives   int              `json:"positives"`
+	Negatives   int              `json:"negatives"`
+	ROCAUC      float64          `json:"roc_auc"`
+	AtThreshold ThresholdMetrics `json:"at_threshold"`
+	BestF1      ThresholdMetrics `json:"best_f1"`
+}
+
+// NewReport computes the metrics at the given threshold, the ROC-AUC and the threshold that maximizes F1
+func NewReport(scored []ScoredExample, threshold float64) (Report, error) {
+	positives, negatives := countLabels(scored)
+	if positives == 0 || negatives == 0 {
+		return Report{}, fmt.Errorf(
+			"dataset needs both relevant and not relevant examples (relevant: %d, not relevant: %d)",
+			positives, negatives,
+		)
+	}
+
+	return Report{
+		Examples:    len(scored),
+		Positives:   positives,
+		Negatives:   negatives,
+		ROCAUC:      ROCAUC(scored),
+		AtThreshold: MetricsAtThreshold(scored, threshold),
+		BestF1:      BestF1Threshold(scored),
+	}, nil
+}
+
+// MetricsAtThreshold computes precision, recall and F1 when scores >= threshold are predicted relevant
+func MetricsAtThreshold(scored []ScoredExample, threshold float64) ThresholdMetrics {
+	metrics := ThresholdMetrics{Threshold: threshold}
+	for _, example := range scored {
+		predicted := example.Score >= threshold
+		switch {
+		case predicted && example.Relevant:
+			metrics.TruePositives++
+		case predicted && !example.Relevant:
+			metrics.FalsePositives++
+		case !predicted && example.Relevant:
+			metrics.FalseNegatives++
+		default:
+			metrics.TrueNegatives++
+		}
+	}
+	metrics.fill()
+	return metrics
+}
+
+// BestF1Threshold returns the metrics of the threshold that maximizes F1.
+// Every distinct score is tried as a candidate threshold; ties keep the highest threshold.
+func BestF1Threshold(scored []ScoredExample) ThresholdMetrics {
+	if len(scored) == 0 {
+		return ThresholdMetrics{}
+	}
+
+	sorted := sortedByScoreDesc(scored)
+	positives, negatives := countLabels(scored)
+
+	var best ThresholdMetrics
+	found := false
+	truePositives, falsePositives := 0, 0
+	for i := 0; i < len(sorted); {
+		// Move every example sharing this score above the threshold at once
+		score := sorted[i].Score
+		for i < len(sorted) && sorted[i].Score == score {
+			if sorted[i].Relevant {
+				truePositives++
+			} else {
+				falsePositives++
+			}
+			i++
+		}
+
+		candidate := ThresholdMetrics{
+			Threshold:      score,
+			TruePositives:  truePositives,
+			FalsePositives: falsePositives,
+			FalseNegatives: positives - truePositives,
+			TrueNegatives:  negatives - falsePositives,
+		}
+		candidate.fill()
+		if !found || candidate.F1 > best.F1 {
+			best = candidate
+			found = true
+		}
+	}
+	return best
+}
+
+// ROCAUC computes the area under the ROC curve using the Mann-Whitney U statistic.
+// It is the probability that a random relevant example scores higher than a random
+// not relevant one, with ties counting as one half. Returns 0 when a class is missing.
+func ROCAUC(scored []ScoredExample) float64 {
+	positives, negatives := countLabels(scored)
+	if positives == 0 || negatives == 0 {
+		return 0
+	}
+
+	sorted := sortedByScoreDesc(scored)
+	// Ranks are assigned in ascending score order, tied scores share their average rank
+	var positiveRankSum float64
+	n := len(sorted)
+	for i := 0; i < n; {
+		j := i
+		for j < n && sorted[j].Score == sorted[i].Score {
+			j++
+		}
+		// Positions i..j-1 in descending order are ranks n-j+1..n-i in ascending order
+		averageRank := float64((n-j+1)+(n-i)) / 2
+		for k := i; k < j; k++ {
+			if sorted[k].Relevant {
+				positiveRankSum += averageRank
+			}
+		}
+		i = j
+	}
+
+	p, q := float64(positives), float64(negatives)
+	return (positiveRankSum - p*(p+1)/2) / (p * q)
+}
+
+func (m *ThresholdMetrics) fill() {
+	if m.TruePositives+m.FalsePositives > 0 {
+		m.Precision = float64(m.TruePositives) / float64(m.TruePositives+m.FalsePositives)
+	}
+	if m.TruePositives+m.FalseNegatives > 0 {
+		m.Recall = float64(m.TruePositives) / float64(m.TruePositives+m.FalseNegatives)
+	}
+	if m.Precision+m.Recall > 0 {
+		m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+	}
+}
+
+func countLabels(scored []ScoredExample) (positives, negatives int) {
+	for _, example := range scored {
+		if example.Relevant {
+			positives++
+		} else {
+			negatives++
+		}
+	}
+	return positives, negatives
+}
+
+func sortedByScoreDesc(scored []ScoredExample) []ScoredExample {
+	sorted := make([]ScoredExample, len(scored))
+	copy(sorted, scored)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+	return sorted
+}
@@ -0,0 +1,211 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scoredExamples(scores []float64, labels []bool) []ScoredExample {
+	scored := make([]ScoredExample, len(scores))
+	for i := range scores {
+		scored[i] = ScoredExample{
+			Example: Example{Relevant: labels[i]},
+			Score:   scores[i],
+		}
+	}
+	return scored
+}
+
+// ============================================================================
+// MetricsAtThreshold Tests
+// ============================================================================
+
+func TestMetricsAtThreshold(t *testing.T) {
+	t.Run("CountsConfusionMatrix", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples(
+			[]float64{0.9, 0.8, 0.6, 0.4, 0.3},
+			[]bool{true, false, true, true, false},
+		)
+
+		// Act
+		result := MetricsAtThreshold(scored, 0.5)
+
+		// Assert
+		assert.Equal(t, 2, result.TruePositives)
+		assert.Equal(t, 1, result.FalsePositives)
+		assert.Equal(t, 1, result.TrueNegatives)
+		assert.Equal(t, 1, result.FalseNegatives)
+		assert.InDelta(t, 2.0/3.0, result.Precision, 0.0001)
+		assert.InDelta(t, 2.0/3.0, result.Recall, 0.0001)
+		assert.InDelta(t, 2.0/3.0, result.F1, 0.0001)
+	})
+
+	t.Run("ScoreEqualToThresholdIsRelevant", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.5}, []bool{true})
+
+		// Act
+		result := MetricsAtThreshold(scored, 0.5)
+
+		// Assert
+		assert.Equal(t, 1, result.TruePositives)
+	})
+
+	t.Run("NoPredictedRelevant", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.1, 0.2}, []bool{true, false})
+
+		// Act
+		result := MetricsAtThreshold(scored, 0.9)
+
+		// Assert
+		assert.Equal(t, 0.0, result.Precision)
+		assert.Equal(t, 0.0, result.Recall)
+		assert.Equal(t, 0.0, result.F1)
+	})
+}
+
+// ============================================================================
+// BestF1Threshold Tests
+// ============================================================================
+
+func TestBestF1Threshold(t *testing.T) {
+	t.Run("FindsSeparatingThreshold", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples(
+			[]float64{0.9, 0.7, 0.65, 0.4, 0.2},
+			[]bool{true, true, true, false, false},
+		)
+
+		// Act
+		result := BestF1Threshold(scored)
+
+		// Assert
+		assert.Equal(t, 0.65, result.Threshold)
+		assert.Equal(t, 1.0, result.F1)
+	})
+
+	t.Run("TiedScoresMoveTogether", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples(
+			[]float64{0.8, 0.6, 0.6, 0.1},
+			[]bool{true, true, false, false},
+		)
+
+		// Act
+		result := BestF1Threshold(scored)
+
+		// Assert
+		assert.Equal(t, 0.6, result.Threshold)
+		assert.Equal(t, 2, result.TruePositives)
+		assert.Equal(t, 1, result.FalsePositives)
+		assert.InDelta(t, 0.8, result.F1, 0.0001)
+	})
+
+	t.Run("EmptyInput", func(t *testing.T) {
+		// Act
+		result := BestF1Threshold(nil)
+
+		// Assert
+		assert.Equal(t, ThresholdMetrics{}, result)
+	})
+}
+
+// ============================================================================
+// ROCAUC Tests
+// ============================================================================
+
+func TestROCAUC(t *testing.T) {
+	t.Run("PerfectSeparation", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.9, 0.8, 0.3, 0.1}, []bool{true, true, false, false})
+
+		// Act
+		result := ROCAUC(scored)
+
+		// Assert
+		assert.InDelta(t, 1.0, result, 0.0001)
+	})
+
+	t.Run("InvertedSeparation", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.9, 0.8, 0.3, 0.1}, []bool{false, false, true, true})
+
+		// Act
+		result := ROCAUC(scored)
+
+		// Assert
+		assert.InDelta(t, 0.0, result, 0.0001)
+	})
+
+	t.Run("PartialOverlap", func(t *testing.T) {
+		// Arrange
+		// Pairs (pos, neg): (0.9,0.7) (0.9,0.2) (0.5,0.7) (0.5,0.2) -> 3 of 4 ranked correctly
+		scored := scoredExamples([]float64{0.9, 0.7, 0.5, 0.2}, []bool{true, false, true, false})
+
+		// Act
+		result := ROCAUC(scored)
+
+		// Assert
+		assert.InDelta(t, 0.75, result, 0.0001)
+	})
+
+	t.Run("TiesCountHalf", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.5, 0.5}, []bool{true, false})
+
+		// Act
+		result := ROCAUC(scored)
+
+		// Assert
+		assert.InDelta(t, 0.5, result, 0.0001)
+	})
+
+	t.Run("SingleClass", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.5, 0.6}, []bool{true, true})
+
+		// Act
+		result := ROCAUC(scored)
+
+		// Assert
+		assert.Equal(t, 0.0, result)
+	})
+}
+
+// ============================================================================
+// NewReport Tests
+// ============================================================================
+
+func TestNewReport(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.9, 0.6, 0.4, 0.1}, []bool{true, true, false, false})
+
+		// Act
+		report, err := NewReport(scored, 0.5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, report.Examples)
+		assert.Equal(t, 2, report.Positives)
+		assert.Equal(t, 2, report.Negatives)
+		assert.Equal(t, 0.5, report.AtThreshold.Threshold)
+		assert.Equal(t, 0.6, report.BestF1.Threshold)
+		assert.InDelta(t, 1.0, report.ROCAUC, 0.0001)
+	})
+
+	t.Run("MissingClass", func(t *testing.T) {
+		// Arrange
+		scored := scoredExamples([]float64{0.9, 0.6}, []bool{true, true})
+
+		// Act
+		_, err := NewReport(scored, 0.5)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "both relevant and not relevant")
+	})
+}
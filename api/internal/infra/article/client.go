@@ -0,0 +1,299 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+)
+
+const (
+	// DefaultTimeout bounds the download of a page, robots.txt included
+	DefaultTimeout = 10 * time.Second
+	// DefaultMaxBytes caps the bytes read from a page, the rest of the page is ignored
+	DefaultMaxBytes = 2 << 20
+	// DefaultMaxTextLength caps the characters of the extracted text
+	DefaultMaxTextLength = 20000
+
+	userAgent = "reddit-content-analyzer/1.0"
+	// robotsTTL is how long the robots.txt of a site is reused
+	robotsTTL = time.Hour
+	// maxRobotsSites caps the sites whose robots.txt rules are kept, the oldest are dropped first
+	maxRobotsSites = 1000
+	// maxRobotsSize caps the bytes read from a robots.txt, as Google does
+	maxRobotsSize = 500 << 10
+	// maxRedirects is how many redirects are followed, as http.Client does by default
+	maxRedirects = 10
+)
+
+// ClientInterface defines the interface for article client operations
+type ClientInterface interface {
+	GetArticle(ctx context.Context, pageURL string) (*Article, error)
+}
+
+// Options controls which pages are fetched and how much of them is read. Zero values use the defaults.
+type Options struct {
+	Timeout       time.Duration
+	MaxBytes      int64
+	MaxTextLength int
+	// AllowedDomains are the only domains, with their subdomains, pages are fetched from. All
+	// domains are allowed when it is empty.
+	AllowedDomains []string
+	// BlockedDomains are the domains, with their subdomains, pages are never fetched from
+	BlockedDomains []string
+	// IgnoreRobots fetches pages their robots.txt disallows
+	IgnoreRobots bool
+}
+
+// Client fetches web pages and extracts their readable text. Links come from untrusted posts, so
+// the HTTP client must refuse private addresses in its dialer, as a netguard.Guard transport does.
+type Client struct {
+	httpClient *http.Client
+	options    Options
+	robots     *robotsCache
+}
+
+// robotsCache keeps the robots.txt rules of the last size sites for ttl
+type robotsCache struct {
+	ttl     time.Duration
+	size    int
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]robotsEntry
+	order   []string
+}
+
+type robotsEntry struct {
+	rules     *robotsRules
+	fetchedAt time.Time
+}
+
+func newRobotsCache(ttl time.Duration, size int, now func() time.Time) *robotsCache {
+	return &robotsCache{
+		ttl:     ttl,
+		size:    size,
+		now:     now,
+		entries: make(map[string]robotsEntry),
+	}
+}
+
+func (c *robotsCache) get(site string) (*robotsRules, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, found := c.entries[site]
+	if !found || c.now().Sub(entry.fetchedAt) >= c.ttl {
+		return nil, false
+	}
+	return entry.rules, true
+}
+
+func (c *robotsCache) set(site string, rules *robotsRules) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.entries[site]; !found {
+		c.order = append(c.order, site)
+	}
+	c.entries[site] = robotsEntry{rules: rules, fetchedAt: c.now()}
+	for len(c.order) > c.size {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// NewClientWithHTTPClient creates a new article client that sends requests through a copy of
+// httpClient, which checks the allowed and blocked domains on every redirect
+func NewClientWithHTTPClient(httpClient *http.Client, options Options) *Client {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.MaxBytes <= 0 {
+		options.MaxBytes = DefaultMaxBytes
+	}
+	if options.MaxTextLength <= 0 {
+		options.MaxTextLength = DefaultMaxTextLength
+	}
+	client := &Client{
+		options: options,
+		robots:  newRobotsCache(robotsTTL, maxRobotsSites, time.Now),
+	}
+	redirectingClient := *httpClient
+	redirectingClient.CheckRedirect = client.checkRedirect
+	client.httpClient = &redirectingClient
+	return client
+}
+
+// NewTestClient creates a new article client for testing against local servers
+func NewTestClient(options Options) *Client {
+	return NewClientWithHTTPClient(&http.Client{Timeout: 30 * time.Second}, options)
+}
+
+// NewClientFromConfig creates a new article client configured from the links section of cfg
+func NewClientFromConfig(cfg *viper.Viper, httpClient *http.Client) *Client {
+	return NewClientWithHTTPClient(httpClient, Options{
+		Timeout:        config.DurationOrDefault(cfg, "links.timeout", DefaultTimeout),
+		MaxBytes:       cfg.GetInt64("links.max_bytes"),
+		MaxTextLength:  cfg.GetInt("links.max_text_length"),
+		AllowedDomains: cfg.GetStringSlice("links.allowed_domains"),
+		BlockedDomains: cfg.GetStringSlice("links.blocked_domains"),
+		IgnoreRobots:   cfg.GetBool("links.ignore_robots"),
+	})
+}
+
+// GetArticle downloads the page at pageURL and extracts its title and readable text. Pages that
+// the options or robots.txt do not allow are not requested.
+func (c *Client) GetArticle(ctx context.Context, pageURL string) (*Article, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.options.Timeout)
+	defer cancel()
+
+	parsed, err := url.Parse(pageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, fmt.Errorf("invalid page URL %q: %w", pageURL, ErrNotAllowed)
+	}
+	if err := c.checkHost(parsed.Hostname()); err != nil {
+		return nil, err
+	}
+	if !c.options.IgnoreRobots {
+		rules, err := c.robotsRules(ctx, parsed)
+		if err != nil {
+			return nil, err
+		}
+		if !rules.allowed(parsed.EscapedPath()) {
+			return nil, ErrDisallowedByRobots
+		}
+	}
+
+	resp, err := c.get(ctx, pageURL, "text/html, application/xhtml+xml;q=0.9")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "" &&
+		mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.options.MaxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	article, err := Extract(data)
+	if err != nil {
+		return nil, err
+	}
+	article.URL = resp.Request.URL.String()
+	article.Text = truncate(article.Text, c.options.MaxTextLength)
+	return article, nil
+}
+
+func (c *Client) get(ctx context.Context, pageURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.httpClient.Do(req)
+	if errors.Is(err, netguard.ErrPrivateAddress) {
+		return nil, fmt.Errorf("%w: %w", ErrNotAllowed, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	return resp, nil
+}
+
+// checkRedirect checks the host of every redirect as GetArticle checks the page it is given
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return c.checkHost(req.URL.Hostname())
+}
+
+// checkHost returns ErrNotAllowed for hosts outside the allowed domains or in a blocked domain.
+// Private addresses are refused by the dialer of the HTTP client instead, where the address
+// actually connected to is known.
+func (c *Client) checkHost(host string) error {
+	host = strings.ToLower(host)
+	if len(c.options.AllowedDomains) > 0 && !inDomains(host, c.options.AllowedDomains) {
+		return fmt.Errorf("%s is not an allowed domain: %w", host, ErrNotAllowed)
+	}
+	if inDomains(host, c.options.BlockedDomains) {
+		return fmt.Errorf("%s is a blocked domain: %w", host, ErrNotAllowed)
+	}
+	return nil
+}
+
+// inDomains reports whether host is one of domains or a subdomain of one
+func inDomains(host string, domains []string) bool {
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "."))
+		if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// robotsRules returns the robots.txt rules of the site of page, fetched at most once per robotsTTL
+// while the site is among the last maxRobotsSites. A missing robots.txt allows everything, one
+// that cannot be read disallows everything.
+func (c *Client) robotsRules(ctx context.Context, page *url.URL) (*robotsRules, error) {
+	site := page.Scheme + "://" + page.Host
+	if rules, found := c.robots.get(site); found {
+		return rules, nil
+	}
+
+	resp, err := c.get(ctx, site+"/robots.txt", "text/plain")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rules *robotsRules
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read robots.txt: %w", err)
+		}
+		rules = parseRobots(string(data), userAgent)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		rules = &robotsRules{}
+	default:
+		rules = &robotsRules{disallowAll: true}
+	}
+
+	c.robots.set(site, rules)
+	return rules, nil
+}
+
+// truncate cuts text to at most maxLength characters, at the last space before the limit
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	cut := string(runes[:maxLength])
+	if i := strings.LastIndexAny(cut, " \n"); i > 0 {
+		cut = cut[:i]
+	}
+	return cut
+}
@@ -0,0 +1,251 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+)
+
+// pageServer serves testdata/page.html at every path but those of robots.txt and the non-HTML
+// and missing pages, and counts the requests for robots.txt
+func pageServer(t *testing.T, robots string, robotsRequests *atomic.Int32) *httptest.Server {
+	page, err := os.ReadFile("testdata/page.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "reddit-content-analyzer/1.0", r.Header.Get("User-Agent"))
+		switch r.URL.Path {
+		case "/robots.txt":
+			robotsRequests.Add(1)
+			if robots == "" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(robots))
+		case "/image.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte{0x89, 'P', 'N', 'G'})
+		case "/missing":
+			http.NotFound(w, r)
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write(page)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// GetArticle Tests
+// ============================================================================
+
+func TestClient_GetArticle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Article", func(t *testing.T) {
+		// Arrange
+		var robotsRequests atomic.Int32
+		server := pageServer(t, "", &robotsRequests)
+		client := NewTestClient(Options{})
+
+		// Act
+		article, err := client.GetArticle(ctx, server.URL+"/blog/range-functions")
+		_, secondErr := client.GetArticle(ctx, server.URL+"/blog/other")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, secondErr)
+		assert.Equal(t, server.URL+"/blog/range-functions", article.URL)
+		assert.Equal(t, "Range over function types", article.Title)
+		assert.Contains(t, article.Text, "Go 1.23 lets a for loop range over iterator functions.")
+		// robots.txt is fetched once per site
+		assert.Equal(t, int32(1), robotsRequests.Load())
+	})
+
+	t.Run("MaxTextLength", func(t *testing.T) {
+		// Arrange
+		var robotsRequests atomic.Int32
+		server := pageServer(t, "", &robotsRequests)
+
+		// Act
+		article, err := NewTestClient(Options{MaxTextLength: 20}).GetArticle(ctx, server.URL+"/blog")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Range over function", article.Text)
+	})
+
+	t.Run("DisallowedByRobots", func(t *testing.T) {
+		// Arrange
+		var robotsRequests atomic.Int32
+		server := pageServer(t, "User-agent: *\nDisallow: /private/\n", &robotsRequests)
+
+		// Act
+		_, err := NewTestClient(Options{}).GetArticle(ctx, server.URL+"/private/page")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrDisallowedByRobots)
+	})
+
+	t.Run("IgnoreRobots", func(t *testing.T) {
+		// Arrange
+		var robotsRequests atomic.Int32
+		server := pageServer(t, "User-agent: *\nDisallow: /\n", &robotsRequests)
+
+		// Act
+		_, err := NewTestClient(Options{IgnoreRobots: true}).GetArticle(ctx, server.URL+"/blog")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Zero(t, robotsRequests.Load())
+	})
+
+	t.Run("NotHTML", func(t *testing.T) {
+		// Arrange
+		var robotsRequests atomic.Int32
+		server := pageServer(t, "", &robotsRequests)
+
+		// Act
+		_, err := NewTestClient(Options{}).GetArticle(ctx, server.URL+"/image.png")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNotHTML)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		var robotsRequests atomic.Int32
+		server := pageServer(t, "", &robotsRequests)
+
+		// Act
+		_, err := NewTestClient(Options{}).GetArticle(ctx, server.URL+"/missing")
+
+		// Assert
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+
+	t.Run("NotAllowed", func(t *testing.T) {
+		// Arrange
+		var robotsRequests atomic.Int32
+		server := pageServer(t, "", &robotsRequests)
+
+		tests := []struct {
+			name    string
+			client  *Client
+			pageURL string
+		}{
+			{"OutsideAllowedDomains", NewTestClient(Options{AllowedDomains: []string{"go.dev"}}), server.URL},
+			{"BlockedDomain", NewTestClient(Options{BlockedDomains: []string{"127.0.0.1"}}), server.URL},
+			{"PrivateAddress", NewClientWithHTTPClient(&http.Client{Transport: netguard.Guard{}.Transport()}, Options{}), server.URL},
+			{"NotHTTP", NewTestClient(Options{}), "ftp://" + strings.TrimPrefix(server.URL, "http://")},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Act
+				_, err := tt.client.GetArticle(ctx, tt.pageURL)
+
+				// Assert
+				assert.ErrorIs(t, err, ErrNotAllowed)
+			})
+		}
+		assert.Zero(t, robotsRequests.Load())
+	})
+
+	t.Run("RedirectNotAllowed", func(t *testing.T) {
+		// Arrange
+		// The test server is on loopback, so only its address is let through the guard
+		guard := netguard.Guard{Allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/metadata":
+				http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+			case "/robots.txt":
+				http.Redirect(w, r, "http://10.0.0.1/robots.txt", http.StatusFound)
+			case "/blocked":
+				http.Redirect(w, r, "http://example.com/", http.StatusFound)
+			}
+		}))
+		defer server.Close()
+
+		tests := []struct {
+			name    string
+			options Options
+			pageURL string
+		}{
+			{"ToPrivateAddress", Options{IgnoreRobots: true}, server.URL + "/metadata"},
+			{"RobotsToPrivateAddress", Options{}, server.URL + "/page"},
+			{"ToBlockedDomain", Options{IgnoreRobots: true, BlockedDomains: []string{"example.com"}}, server.URL + "/blocked"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				client := NewClientWithHTTPClient(&http.Client{Transport: guard.Transport()}, tt.options)
+
+				// Act
+				_, err := client.GetArticle(ctx, tt.pageURL)
+
+				// Assert
+				assert.ErrorIs(t, err, ErrNotAllowed)
+			})
+		}
+	})
+}
+
+// ============================================================================
+// Robots Cache Tests
+// ============================================================================
+
+func TestRobotsCache(t *testing.T) {
+	t.Run("DropsOldestSites", func(t *testing.T) {
+		// Arrange
+		cache := newRobotsCache(time.Hour, 2, time.Now)
+
+		// Act
+		cache.set("https://a.example", &robotsRules{})
+		cache.set("https://b.example", &robotsRules{})
+		cache.set("https://a.example", &robotsRules{disallowAll: true})
+		cache.set("https://c.example", &robotsRules{})
+
+		// Assert
+		_, foundA := cache.get("https://a.example")
+		_, foundB := cache.get("https://b.example")
+		_, foundC := cache.get("https://c.example")
+		assert.False(t, foundA)
+		assert.True(t, foundB)
+		assert.True(t, foundC)
+		assert.Len(t, cache.entries, 2)
+	})
+
+	t.Run("Expires", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		cache := newRobotsCache(time.Hour, 2, func() time.Time { return now })
+		cache.set("https://a.example", &robotsRules{})
+
+		// Act
+		_, fresh := cache.get("https://a.example")
+		now = now.Add(time.Hour)
+		_, expired := cache.get("https://a.example")
+
+		// Assert
+		assert.True(t, fresh)
+		assert.False(t, expired)
+	})
+}
@@ -0,0 +1,11 @@
+package article
+
+// Article is the readable content of a web page
+type Article struct {
+	// URL is the address of the page that answered, after redirects
+	URL string
+	// Title is the og:title of the page, or its <title> element
+	Title string
+	// Text is the main text of the page, one block per line, without navigation, scripts and forms
+	Text string
+}
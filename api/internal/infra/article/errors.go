@@ -0,0 +1,29 @@
+package article
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotAllowed is returned for pages outside the allowed domains, in a blocked domain or on a
+	// private address
+	ErrNotAllowed = errors.New("page is not allowed")
+	// ErrDisallowedByRobots is returned for pages the robots.txt of their site disallows
+	ErrDisallowedByRobots = errors.New("page is disallowed by robots.txt")
+	// ErrNotHTML is returned for pages that are not HTML documents, such as images or PDFs
+	ErrNotHTML = errors.New("page is not an HTML document")
+)
+
+// APIError is returned when a page answers with a non-200 status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("page returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("page returned status %d: %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,173 @@
+package article
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// skippedElements never hold the main text of a page
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Svg:      true,
+	atom.Iframe:   true,
+	atom.Nav:      true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Aside:    true,
+	atom.Form:     true,
+	atom.Button:   true,
+	atom.Select:   true,
+}
+
+// blockElements break the text into lines
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Li: true, atom.Ul: true, atom.Ol: true, atom.Dt: true, atom.Dd: true,
+	atom.Pre: true, atom.Blockquote: true, atom.Figcaption: true, atom.Table: true, atom.Tr: true,
+	atom.Br: true, atom.Hr: true,
+}
+
+// boilerplateMarkers are found in the class or id of page parts beside the main text
+var boilerplateMarkers = []string{"cookie", "consent", "newsletter", "subscribe", "share", "social", "related", "sidebar", "comments", "advert", "promo", "breadcrumb"}
+
+// Extract returns the title and readable text of an HTML document. The text is that of the
+// <article> element, else of the <main> element, else of the body, without the parts that are not
+// content such as navigation, scripts, forms and cookie banners.
+func Extract(data []byte) (*Article, error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	root := findElement(doc, func(n *html.Node) bool { return n.DataAtom == atom.Article })
+	if root == nil {
+		root = findElement(doc, func(n *html.Node) bool {
+			return n.DataAtom == atom.Main || attribute(n, "role") == "main"
+		})
+	}
+	if root == nil {
+		root = findElement(doc, func(n *html.Node) bool { return n.DataAtom == atom.Body })
+	}
+
+	var text strings.Builder
+	if root != nil {
+		writeText(&text, root)
+	}
+	return &Article{
+		Title: pageTitle(doc),
+		Text:  normalizeLines(text.String()),
+	}, nil
+}
+
+// pageTitle returns the og:title of the page, or its <title> element
+func pageTitle(doc *html.Node) string {
+	meta := findElement(doc, func(n *html.Node) bool {
+		return n.DataAtom == atom.Meta && attribute(n, "property") == "og:title" && attribute(n, "content") != ""
+	})
+	if meta != nil {
+		return strings.Join(strings.Fields(attribute(meta, "content")), " ")
+	}
+	title := findElement(doc, func(n *html.Node) bool { return n.DataAtom == atom.Title })
+	if title == nil {
+		return ""
+	}
+	var text strings.Builder
+	writeText(&text, title)
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// sourceLineBreaks are the line breaks of the HTML source, which are spaces outside <pre>
+var sourceLineBreaks = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+func writeText(text *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		if inPre(n) {
+			text.WriteString(n.Data)
+		} else {
+			text.WriteString(sourceLineBreaks.Replace(n.Data))
+		}
+		return
+	case html.ElementNode:
+		if skippedElements[n.DataAtom] || isBoilerplate(n) || attribute(n, "hidden") != "" || attribute(n, "aria-hidden") == "true" {
+			return
+		}
+	}
+
+	block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+	if block {
+		text.WriteString("\n")
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeText(text, child)
+	}
+	if block {
+		text.WriteString("\n")
+	}
+}
+
+func inPre(n *html.Node) bool {
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		if parent.DataAtom == atom.Pre {
+			return true
+		}
+	}
+	return false
+}
+
+// isBoilerplate reports whether the class or id of n marks a part beside the main text. The
+// elements that hold the main text are never boilerplate, whatever their class, such as a body of
+// class has-sidebar.
+func isBoilerplate(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Html, atom.Body, atom.Main, atom.Article:
+		return false
+	}
+	names := strings.ToLower(attribute(n, "class") + " " + attribute(n, "id"))
+	for _, marker := range boilerplateMarkers {
+		if strings.Contains(names, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeLines collapses the whitespace of every line and drops the empty ones
+func normalizeLines(text string) string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func findElement(n *html.Node, match func(n *html.Node) bool) *html.Node {
+	if n.Type == html.ElementNode && match(n) {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func attribute(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package article
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// Extract Tests
+// ============================================================================
+
+func TestExtract(t *testing.T) {
+	t.Run("Article", func(t *testing.T) {
+		// Arrange
+		data, err := os.ReadFile("testdata/page.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Act
+		article, err := Extract(data)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Range over function types", article.Title)
+		assert.Equal(t, "Range over function types\n"+
+			"Go 1.23 lets a for loop range over iterator functions.\n"+
+			"Push iterators\n"+
+			"Pull iterators\n"+
+			"Standard library packages now return iterators.", article.Text)
+	})
+
+	t.Run("BodyWithoutArticle", func(t *testing.T) {
+		// Act
+		article, err := Extract([]byte(`<html><head><title> Release
+			notes </title></head><body><nav>Menu</nav><p>Go 1.24 is out.</p><script>track()</script></body></html>`))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Release notes", article.Title)
+		assert.Equal(t, "Go 1.24 is out.", article.Text)
+	})
+
+	t.Run("MainElement", func(t *testing.T) {
+		// Act
+		article, err := Extract([]byte(`<body><div>Sign in</div><div role="main"><p>Content</p></div></body>`))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, article.Title)
+		assert.Equal(t, "Content", article.Text)
+	})
+}
@@ -0,0 +1,109 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+)
+
+// robotsRules are the rules of a robots.txt that apply to this client
+type robotsRules struct {
+	disallowAll bool
+	rules       []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	path    string
+	pattern *regexp.Regexp
+}
+
+// parseRobots returns the rules of the group of robots.txt for agent, or of the * group when
+// there is none. Paths support the * and $ wildcards.
+func parseRobots(robots, agent string) *robotsRules {
+	agent = strings.ToLower(strings.SplitN(agent, "/", 2)[0])
+
+	var agentRules, defaultRules []robotsRule
+	var agentFound bool
+	var groupAgents []string
+	inRules := false
+	for _, line := range strings.Split(robots, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		field, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "user-agent":
+			// A user-agent line after rules starts a new group
+			if inRules {
+				groupAgents = nil
+				inRules = false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				// An empty disallow allows everything
+				continue
+			}
+			rule := robotsRule{allow: field == "allow", path: value, pattern: robotsPattern(value)}
+			for _, groupAgent := range groupAgents {
+				switch {
+				case groupAgent == agent:
+					agentFound = true
+					agentRules = append(agentRules, rule)
+				case groupAgent == "*":
+					defaultRules = append(defaultRules, rule)
+				}
+			}
+		}
+	}
+
+	if agentFound {
+		return &robotsRules{rules: agentRules}
+	}
+	return &robotsRules{rules: defaultRules}
+}
+
+// robotsPattern compiles a robots.txt path, where * matches any characters and a trailing $
+// anchors the end of the path
+func robotsPattern(path string) *regexp.Regexp {
+	anchored := strings.HasSuffix(path, "$")
+	path = strings.TrimSuffix(path, "$")
+	parts := strings.Split(path, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expression := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expression += "$"
+	}
+	return regexp.MustCompile(expression)
+}
+
+// allowed reports whether path may be fetched. The longest matching rule wins, allow rules win ties.
+func (r *robotsRules) allowed(path string) bool {
+	if r.disallowAll {
+		return false
+	}
+	if path == "" {
+		path = "/"
+	}
+	allowed := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if len(rule.path) > longest || (len(rule.path) == longest && rule.allow) {
+			longest = len(rule.path)
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,51 @@
+package article
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// Robots Tests
+// ============================================================================
+
+func TestRobotsRules_Allowed(t *testing.T) {
+	robots := `# Rules
+User-agent: *
+Disallow: /private/
+Allow: /private/press/
+Disallow: /*.pdf$
+
+User-agent: GPTBot
+User-agent: CCBot
+Disallow: /
+`
+
+	tests := []struct {
+		name     string
+		robots   string
+		agent    string
+		path     string
+		expected bool
+	}{
+		{"NoRules", "", userAgent, "/blog", true},
+		{"NotMatched", robots, userAgent, "/blog/post", true},
+		{"Disallowed", robots, userAgent, "/private/notes", false},
+		{"LongestMatchAllows", robots, userAgent, "/private/press/release", true},
+		{"WildcardAndAnchor", robots, userAgent, "/docs/spec.pdf", false},
+		{"AnchorNotAtEnd", robots, userAgent, "/docs/spec.pdf.html", true},
+		{"AgentGroup", robots, "CCBot/2.0", "/blog", false},
+		{"EmptyDisallow", "User-agent: *\nDisallow:\n", userAgent, "/blog", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			allowed := parseRobots(tt.robots, tt.agent).allowed(tt.path)
+
+			// Assert
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
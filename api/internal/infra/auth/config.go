@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// NewKeyStoreFromConfig creates a store for the keys declared in auth.static_keys followed by
+// the hashed keys in the auth.hashed_keys_file JSON file
+func NewKeyStoreFromConfig(cfg *viper.Viper) (KeyStore, error) {
+	var staticKeys []StaticKey
+	if err := cfg.UnmarshalKey("auth.static_keys", &staticKeys); err != nil {
+		return nil, fmt.Errorf("failed to decode auth.static_keys: %w", err)
+	}
+	staticStore, err := NewStaticKeyStore(staticKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashedKeys []HashedKey
+	if path := cfg.GetString("auth.hashed_keys_file"); path != "" {
+		hashedKeys, err = LoadHashedKeys(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(staticKeys) == 0 && len(hashedKeys) == 0 {
+		return nil, fmt.Errorf("authentication is enabled but no API keys are configured")
+	}
+	return NewChainKeyStore(staticStore, NewHashedKeyStore(hashedKeys)), nil
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+)
+
+// Scope is a permission granted to an API key
+type Scope string
+
+const (
+	// ScopeSearch allows relevance searches
+	ScopeSearch Scope = "search"
+	// ScopeJobs allows managing background jobs
+	ScopeJobs Scope = "jobs"
+	// ScopeAdmin allows the operational endpoints, such as changing the log level
+	ScopeAdmin Scope = "admin"
+)
+
+// APIKey is an authenticated caller along with its permissions and limits
+type APIKey struct {
+	ID     string  `json:"id" mapstructure:"id"`
+	Name   string  `json:"name" mapstructure:"name"`
+	Scopes []Scope `json:"scopes" mapstructure:"scopes"`
+	// RateLimitPerMinute is the sustained number of requests allowed, 0 means unlimited
+	RateLimitPerMinute int `json:"rate_limit_per_minute" mapstructure:"rate_limit_per_minute"`
+	// Burst is the number of requests allowed at once above the sustained rate
+	Burst int `json:"burst" mapstructure:"burst"`
+	// DailyPostQuota is the number of posts that can be evaluated per UTC day, 0 means unlimited
+	DailyPostQuota int `json:"daily_post_quota" mapstructure:"daily_post_quota"`
+}
+
+// HasScope reports whether the key was granted scope
+func (k *APIKey) HasScope(scope Scope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// HashKey returns the hex SHA-256 of a raw API key. Keys are random and long, so a fast hash is enough.
+func HashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateKey returns a new random API key
+func GenerateKey() string {
+	b := make([]byte, 32)
+	// crypto/rand.Read never returns an error
+	rand.Read(b)
+	return "rca_" + hex.EncodeToString(b)
+}
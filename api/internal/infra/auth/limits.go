@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type dailyUsage struct {
+	day   string
+	posts int
+}
+
+// Limits enforces the per-key request rate and daily post quota. Usage is kept in memory,
+// so quotas restart from zero when the process restarts.
+type Limits struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	usage    map[string]dailyUsage
+	now      func() time.Time
+}
+
+// NewLimits creates limits with no recorded usage
+func NewLimits() *Limits {
+	return &Limits{
+		limiters: make(map[string]*rate.Limiter),
+		usage:    make(map[string]dailyUsage),
+		now:      time.Now,
+	}
+}
+
+// Allow reports whether key may make a request now. When it may not, it returns how long to wait.
+func (l *Limits) Allow(key *APIKey) (bool, time.Duration) {
+	if key.RateLimitPerMinute <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	limiter, ok := l.limiters[key.ID]
+	if !ok {
+		burst := max(key.Burst, 1)
+		limiter = rate.NewLimiter(rate.Limit(float64(key.RateLimitPerMinute)/60), burst)
+		l.limiters[key.ID] = limiter
+	}
+	l.mu.Unlock()
+
+	reservation := limiter.ReserveN(l.now(), 1)
+	delay := reservation.DelayFrom(l.now())
+	if delay == 0 {
+		return true, 0
+	}
+	reservation.CancelAt(l.now())
+	return false, delay
+}
+
+// RemainingPosts returns how many posts key may still evaluate today. ok is false when the key has no quota.
+func (l *Limits) RemainingPosts(key *APIKey) (remaining int, ok bool) {
+	if key.DailyPostQuota <= 0 {
+		return 0, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return max(key.DailyPostQuota-l.todayUsage(key.ID).posts, 0), true
+}
+
+// ReservePosts charges posts to the daily quota of key before they are evaluated. It reports
+// false and charges nothing when fewer posts remain, so concurrent requests cannot overrun the
+// quota. Keys without a quota are always allowed.
+func (l *Limits) ReservePosts(key *APIKey, posts int) bool {
+	if key.DailyPostQuota <= 0 || posts <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	usage := l.todayUsage(key.ID)
+	if usage.posts+posts > key.DailyPostQuota {
+		return false
+	}
+	usage.posts += posts
+	l.usage[key.ID] = usage
+	return true
+}
+
+// RefundPosts gives back to the daily quota of key the reserved posts that were not evaluated
+func (l *Limits) RefundPosts(key *APIKey, posts int) {
+	if key.DailyPostQuota <= 0 || posts <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	usage := l.todayUsage(key.ID)
+	usage.posts = max(usage.posts-posts, 0)
+	l.usage[key.ID] = usage
+}
+
+// todayUsage returns the usage of the current UTC day, l.mu must be held
+func (l *Limits) todayUsage(keyID string) dailyUsage {
+	today := l.now().UTC().Format(time.DateOnly)
+	usage := l.usage[keyID]
+	if usage.day != today {
+		usage = dailyUsage{day: today}
+	}
+	return usage
+}
+
+// ResetIn returns the time left until the daily quotas reset at UTC midnight
+func (l *Limits) ResetIn() time.Duration {
+	now := l.now().UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	return midnight.Sub(now)
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// Limits Tests
+// ============================================================================
+
+func TestLimits_Allow(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		// Arrange
+		limits := NewLimits()
+		key := &APIKey{ID: "web"}
+
+		// Act & Assert
+		for range 100 {
+			ok, _ := limits.Allow(key)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("LimitsBurstThenRefills", func(t *testing.T) {
+		// Arrange
+		limits := NewLimits()
+		now := time.Now()
+		limits.now = func() time.Time { return now }
+		key := &APIKey{ID: "web", RateLimitPerMinute: 60, Burst: 2}
+
+		// Act
+		first, _ := limits.Allow(key)
+		second, _ := limits.Allow(key)
+		third, retryAfter := limits.Allow(key)
+		now = now.Add(time.Second)
+		afterRefill, _ := limits.Allow(key)
+
+		// Assert
+		assert.True(t, first)
+		assert.True(t, second)
+		assert.False(t, third)
+		assert.Equal(t, time.Second, retryAfter)
+		assert.True(t, afterRefill)
+	})
+
+	t.Run("PerKey", func(t *testing.T) {
+		// Arrange
+		limits := NewLimits()
+		web := &APIKey{ID: "web", RateLimitPerMinute: 1, Burst: 1}
+		cli := &APIKey{ID: "cli", RateLimitPerMinute: 1, Burst: 1}
+
+		// Act
+		limits.Allow(web)
+		webAgain, _ := limits.Allow(web)
+		cliFirst, _ := limits.Allow(cli)
+
+		// Assert
+		assert.False(t, webAgain)
+		assert.True(t, cliFirst)
+	})
+}
+
+func TestLimits_Posts(t *testing.T) {
+	t.Run("NoQuota", func(t *testing.T) {
+		// Arrange
+		limits := NewLimits()
+
+		// Act
+		_, ok := limits.RemainingPosts(&APIKey{ID: "web"})
+
+		// Assert
+		assert.False(t, ok)
+	})
+
+	t.Run("ReservesRefundsAndResetsDaily", func(t *testing.T) {
+		// Arrange
+		limits := NewLimits()
+		now := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+		limits.now = func() time.Time { return now }
+		key := &APIKey{ID: "web", DailyPostQuota: 10}
+
+		// Act
+		reservedFour := limits.ReservePosts(key, 4)
+		afterFour, _ := limits.RemainingPosts(key)
+		reservedTooMany := limits.ReservePosts(key, 7)
+		limits.RefundPosts(key, 1)
+		afterRefund, _ := limits.RemainingPosts(key)
+		reservedRest := limits.ReservePosts(key, 7)
+		exhausted, _ := limits.RemainingPosts(key)
+		resetIn := limits.ResetIn()
+		now = now.Add(3 * time.Hour)
+		nextDay, ok := limits.RemainingPosts(key)
+
+		// Assert
+		assert.True(t, reservedFour)
+		assert.Equal(t, 6, afterFour)
+		assert.False(t, reservedTooMany)
+		assert.Equal(t, 7, afterRefund)
+		assert.True(t, reservedRest)
+		assert.Equal(t, 0, exhausted)
+		assert.Equal(t, 2*time.Hour, resetIn)
+		assert.True(t, ok)
+		assert.Equal(t, 10, nextDay)
+	})
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrKeyNotFound is returned when an API key is unknown or revoked
+var ErrKeyNotFound = errors.New("API key not found")
+
+// KeyStore resolves raw API keys to the keys they authenticate
+type KeyStore interface {
+	Lookup(ctx context.Context, rawKey string) (*APIKey, error)
+}
+
+// StaticKey is an API key declared in config along with its raw value
+type StaticKey struct {
+	APIKey `mapstructure:",squash"`
+	Key    string `mapstructure:"key"`
+}
+
+// hashedKeyStore holds keys by the hash of their raw value
+type hashedKeyStore struct {
+	keys map[string]APIKey
+}
+
+// NewStaticKeyStore creates a store for keys declared with their raw value, typically in config
+func NewStaticKeyStore(keys []StaticKey) (KeyStore, error) {
+	store := &hashedKeyStore{keys: make(map[string]APIKey, len(keys))}
+	for _, key := range keys {
+		if key.Key == "" {
+			return nil, fmt.Errorf("static API key %q has no key", key.ID)
+		}
+		store.keys[HashKey(key.Key)] = key.APIKey
+	}
+	return store, nil
+}
+
+// HashedKey is an API key stored with the hash of its raw value only
+type HashedKey struct {
+	APIKey
+	KeyHash string `json:"key_hash"`
+}
+
+// NewHashedKeyStore creates a store for keys persisted as hashes, so a leaked store does not leak keys
+func NewHashedKeyStore(keys []HashedKey) KeyStore {
+	store := &hashedKeyStore{keys: make(map[string]APIKey, len(keys))}
+	for _, key := range keys {
+		store.keys[key.KeyHash] = key.APIKey
+	}
+	return store
+}
+
+// LoadHashedKeys reads hashed keys from a JSON array file. A missing file yields no keys.
+func LoadHashedKeys(path string) ([]HashedKey, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hashed API keys: %w", err)
+	}
+	var keys []HashedKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode hashed API keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *hashedKeyStore) Lookup(ctx context.Context, rawKey string) (*APIKey, error) {
+	key, ok := s.keys[HashKey(rawKey)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return &key, nil
+}
+
+// chainKeyStore looks keys up in several stores in order
+type chainKeyStore []KeyStore
+
+// NewChainKeyStore creates a store that returns the first key found in stores
+func NewChainKeyStore(stores ...KeyStore) KeyStore {
+	return chainKeyStore(stores)
+}
+
+func (s chainKeyStore) Lookup(ctx context.Context, rawKey string) (*APIKey, error) {
+	for _, store := range s {
+		key, err := store.Lookup(ctx, rawKey)
+		if errors.Is(err, ErrKeyNotFound) {
+			continue
+		}
+		return key, err
+	}
+	return nil, ErrKeyNotFound
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// KeyStore Tests
+// ============================================================================
+
+func TestStaticKeyStore(t *testing.T) {
+	t.Run("FindsKey", func(t *testing.T) {
+		// Arrange
+		store, err := NewStaticKeyStore([]StaticKey{
+			{APIKey: APIKey{ID: "web", Scopes: []Scope{ScopeSearch}}, Key: "secret"},
+		})
+		require.NoError(t, err)
+
+		// Act
+		key, err := store.Lookup(context.Background(), "secret")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "web", key.ID)
+		assert.True(t, key.HasScope(ScopeSearch))
+		assert.False(t, key.HasScope(ScopeAdmin))
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		// Arrange
+		store, _ := NewStaticKeyStore([]StaticKey{{APIKey: APIKey{ID: "web"}, Key: "secret"}})
+
+		// Act
+		key, err := store.Lookup(context.Background(), "guess")
+
+		// Assert
+		assert.Nil(t, key)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("RejectsEmptyKey", func(t *testing.T) {
+		// Act
+		_, err := NewStaticKeyStore([]StaticKey{{APIKey: APIKey{ID: "web"}}})
+
+		// Assert
+		assert.EqualError(t, err, `static API key "web" has no key`)
+	})
+}
+
+func TestHashedKeyStore(t *testing.T) {
+	t.Run("LoadsKeysFromFile", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "apikeys.json")
+		content := `[{"id":"cli","scopes":["admin"],"key_hash":"` + HashKey("raw") + `"}]`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		// Act
+		keys, err := LoadHashedKeys(path)
+		require.NoError(t, err)
+		key, lookupErr := NewHashedKeyStore(keys).Lookup(context.Background(), "raw")
+
+		// Assert
+		assert.NoError(t, lookupErr)
+		assert.Equal(t, "cli", key.ID)
+		assert.True(t, key.HasScope(ScopeAdmin))
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		// Act
+		keys, err := LoadHashedKeys(filepath.Join(t.TempDir(), "missing.json"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+}
+
+func TestNewKeyStoreFromConfig(t *testing.T) {
+	t.Run("ChainsStaticAndHashedKeys", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "apikeys.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{"id":"hashed","key_hash":"`+HashKey("h")+`"}]`), 0600))
+		cfg := viper.New()
+		cfg.Set("auth.static_keys", []map[string]interface{}{
+			{"id": "static", "key": "s", "scopes": []string{"search"}, "daily_post_quota": 10},
+		})
+		cfg.Set("auth.hashed_keys_file", path)
+
+		// Act
+		store, err := NewKeyStoreFromConfig(cfg)
+		require.NoError(t, err)
+		staticKey, staticErr := store.Lookup(context.Background(), "s")
+		hashedKey, hashedErr := store.Lookup(context.Background(), "h")
+		_, unknownErr := store.Lookup(context.Background(), "x")
+
+		// Assert
+		assert.NoError(t, staticErr)
+		assert.Equal(t, "static", staticKey.ID)
+		assert.Equal(t, 10, staticKey.DailyPostQuota)
+		assert.Equal(t, []Scope{ScopeSearch}, staticKey.Scopes)
+		assert.NoError(t, hashedErr)
+		assert.Equal(t, "hashed", hashedKey.ID)
+		assert.ErrorIs(t, unknownErr, ErrKeyNotFound)
+	})
+
+	t.Run("NoKeys", func(t *testing.T) {
+		// Act
+		_, err := NewKeyStoreFromConfig(viper.New())
+
+		// Assert
+		assert.EqualError(t, err, "authentication is enabled but no API keys are configured")
+	})
+}
@@ -2,42 +2,50 @@ package config
 
 import (
 	"log"
-	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
-var (
-	config *viper.Viper
-	once   sync.Once
-)
+// DefaultPaths are the directories searched for config.yaml when New is called without paths
+var DefaultPaths = []string{
+	"./",
+	"../",    // For tests running from subdirectories
+	"../../", // For tests running from deeper subdirectories
+}
+
+// New creates a config instance that reads config.yaml from the given search paths
+// (DefaultPaths when none are given) and overrides it with environment variables
+func New(paths ...string) (*viper.Viper, error) {
+	if len(paths) == 0 {
+		paths = DefaultPaths
+	}
 
-// GetConfig returns the singleton config instance, initializing it on first call
-func GetConfig() *viper.Viper {
-	once.Do(func() {
-		config = viper.New()
-		config.SetConfigName("config")
-		config.SetConfigType("yaml")
-		config.AddConfigPath("./")
-		config.AddConfigPath("../")    // For tests running from subdirectories
-		config.AddConfigPath("../../") // For tests running from deeper subdirectories
-		config.AutomaticEnv()
-		config.WatchConfig()
+	config := viper.New()
+	config.SetConfigName("config")
+	config.SetConfigType("yaml")
+	for _, path := range paths {
+		config.AddConfigPath(path)
+	}
+	config.AutomaticEnv()
 
-		if err := config.ReadInConfig(); err != nil {
-			if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-				// Config file not found - use defaults and environment variables
-				// This is acceptable for tests or when using env vars only
-				log.Printf("Config file not found, using defaults and environment variables: %v", err)
-			} else {
-				log.Fatalf("Error reading config file: %v", err)
-			}
+	if err := config.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
 		}
-	})
-	return config
+		// Config file not found - use defaults and environment variables
+		// This is acceptable for tests or when using env vars only
+		log.Printf("Config file not found, using defaults and environment variables: %v", err)
+		return config, nil
+	}
+	config.WatchConfig()
+	return config, nil
 }
 
-// GetString returns a string value from the config
-func GetString(key string) string {
-	return GetConfig().GetString(key)
+// DurationOrDefault returns the duration stored under key, or defaultValue when the key is not set
+func DurationOrDefault(cfg *viper.Viper, key string, defaultValue time.Duration) time.Duration {
+	if !cfg.IsSet(key) {
+		return defaultValue
+	}
+	return cfg.GetDuration(key)
 }
@@ -0,0 +1,173 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxFeedSize caps the bytes read from a feed, feeds are a few hundred kilobytes at most
+const maxFeedSize = 10 << 20
+
+// rssDateLayouts are the date formats found in RSS pubDate elements, RFC 822 in theory
+var rssDateLayouts = []string{time.RFC1123Z, time.RFC1123, "Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST", time.RFC3339}
+
+// ClientInterface defines the interface for feed client operations
+type ClientInterface interface {
+	GetFeed(ctx context.Context, feedURL string) (*Feed, error)
+}
+
+// Client fetches and parses RSS 2.0 and Atom 1.0 feeds
+type Client struct {
+	httpClient *http.Client
+	userAgent  string
+}
+
+// NewClientWithHTTPClient creates a new feed client that sends requests through httpClient
+func NewClientWithHTTPClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		userAgent:  "reddit-content-analyzer/1.0",
+	}
+}
+
+// NewClient creates a new feed client
+func NewClient() *Client {
+	return NewClientWithHTTPClient(&http.Client{Timeout: 30 * time.Second})
+}
+
+// GetFeed downloads the feed at feedURL and parses it as RSS or Atom, by its root element
+func (c *Client) GetFeed(ctx context.Context, feedURL string) (*Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses an RSS 2.0 or Atom 1.0 document
+func Parse(data []byte) (*Feed, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode feed: %w", err)
+	}
+
+	switch root {
+	case "rss":
+		var document rssDocument
+		if err := xml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("failed to decode RSS feed: %w", err)
+		}
+		return document.feed(), nil
+	case "feed":
+		var document atomDocument
+		if err := xml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("failed to decode Atom feed: %w", err)
+		}
+		return document.feed(), nil
+	default:
+		return nil, fmt.Errorf("failed to decode feed: unsupported root element <%s>", root)
+	}
+}
+
+// rootElement returns the local name of the first element of the document
+func rootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func (d *rssDocument) feed() *Feed {
+	feed := &Feed{Title: strings.TrimSpace(d.Channel.Title)}
+	for _, item := range d.Channel.Items {
+		content := item.Encoded
+		if content == "" {
+			content = item.Description
+		}
+		author := item.Creator
+		if author == "" {
+			author = item.Author
+		}
+		feed.Items = append(feed.Items, Item{
+			ID:        firstNonEmpty(item.GUID, item.Link),
+			Title:     strings.TrimSpace(item.Title),
+			Link:      strings.TrimSpace(item.Link),
+			Content:   strings.TrimSpace(content),
+			Author:    strings.TrimSpace(author),
+			Published: parseTime(item.PubDate, rssDateLayouts),
+		})
+	}
+	return feed
+}
+
+func (d *atomDocument) feed() *Feed {
+	feed := &Feed{Title: strings.TrimSpace(d.Title)}
+	for _, entry := range d.Entries {
+		link := ""
+		for _, candidate := range entry.Links {
+			if candidate.Rel == "" || candidate.Rel == "alternate" {
+				link = candidate.Href
+				break
+			}
+		}
+		feed.Items = append(feed.Items, Item{
+			ID:        firstNonEmpty(entry.ID, link),
+			Title:     strings.TrimSpace(entry.Title),
+			Link:      strings.TrimSpace(link),
+			Content:   strings.TrimSpace(firstNonEmpty(entry.Content, entry.Summary)),
+			Author:    strings.TrimSpace(entry.Author),
+			Published: parseTime(firstNonEmpty(entry.Published, entry.Updated), []string{time.RFC3339}),
+		})
+	}
+	return feed
+}
+
+func parseTime(value string, layouts []string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
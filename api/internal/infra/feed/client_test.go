@@ -0,0 +1,121 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+)
+
+// ============================================================================
+// GetFeed Tests
+// ============================================================================
+
+func TestClient_GetFeed(t *testing.T) {
+	serveFixture := func(t *testing.T, name string) *httptest.Server {
+		fixture, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "reddit-content-analyzer/1.0", r.Header.Get("User-Agent"))
+			w.Write(fixture)
+		}))
+	}
+
+	t.Run("RSS", func(t *testing.T) {
+		// Arrange
+		server := serveFixture(t, "rss.xml")
+		defer server.Close()
+
+		// Act
+		feed, err := NewClient().GetFeed(context.Background(), server.URL)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Go Weekly", feed.Title)
+		assert.Len(t, feed.Items, 2)
+		assert.Equal(t, Item{
+			ID:        "https://golangweekly.com/issues/500",
+			Title:     "Generics in practice",
+			Link:      "https://golangweekly.com/issues/500",
+			Content:   "How teams use type parameters in production.",
+			Author:    "Peter",
+			Published: time.Date(2024, time.August, 6, 10, 0, 0, 0, time.UTC),
+		}, normalizeTime(feed.Items[0]))
+		// Items without a guid are identified by their link, unparsable dates are left zero
+		assert.Equal(t, "https://golangweekly.com/issues/499", feed.Items[1].ID)
+		assert.True(t, feed.Items[1].Published.IsZero())
+	})
+
+	t.Run("Atom", func(t *testing.T) {
+		// Arrange
+		server := serveFixture(t, "atom.xml")
+		defer server.Close()
+
+		// Act
+		feed, err := NewClient().GetFeed(context.Background(), server.URL)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "The Go Blog", feed.Title)
+		assert.Len(t, feed.Items, 1)
+		assert.Equal(t, "https://go.dev/blog/range-functions", feed.Items[0].Link)
+		assert.Equal(t, "Ian Lance Taylor", feed.Items[0].Author)
+		assert.Contains(t, feed.Items[0].Content, "range over function types")
+		assert.Equal(t, 2024, feed.Items[0].Published.Year())
+	})
+
+	t.Run("NotAFeed", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("<html><body>Hello</body></html>"))
+		}))
+		defer server.Close()
+
+		// Act
+		feed, err := NewClient().GetFeed(context.Background(), server.URL)
+
+		// Assert
+		assert.Nil(t, feed)
+		assert.ErrorContains(t, err, "unsupported root element <html>")
+	})
+
+	t.Run("HTTPError", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		// Act
+		_, err := NewClient().GetFeed(context.Background(), server.URL)
+
+		// Assert
+		var apiErr *APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+
+	t.Run("LoopbackRefused", func(t *testing.T) {
+		// Arrange
+		server := serveFixture(t, "rss.xml")
+		defer server.Close()
+		client := NewClientWithHTTPClient(&http.Client{Transport: netguard.Guard{}.Transport()})
+
+		// Act
+		_, err := client.GetFeed(context.Background(), server.URL)
+
+		// Assert
+		assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+	})
+}
+
+func normalizeTime(item Item) Item {
+	item.Published = item.Published.UTC()
+	return item
+}
@@ -0,0 +1,59 @@
+package feed
+
+import "time"
+
+// Feed is an RSS or Atom feed, normalized to the fields both formats have
+type Feed struct {
+	Title string
+	Items []Item
+}
+
+type Item struct {
+	ID        string // guid of RSS items, id of Atom entries, the link when missing
+	Title     string
+	Link      string
+	Content   string // description of RSS items, content or summary of Atom entries
+	Author    string
+	Published time.Time // Zero when the feed has no parsable date
+}
+
+// rssDocument is an RSS 2.0 document
+type rssDocument struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	GUID        string `xml:"guid"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Author      string `xml:"author"`
+	Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	PubDate     string `xml:"pubDate"`
+}
+
+// atomDocument is an Atom 1.0 document
+type atomDocument struct {
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Content   string     `xml:"content"`
+	Summary   string     `xml:"summary"`
+	Author    string     `xml:"author>name"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
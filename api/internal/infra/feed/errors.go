@@ -0,0 +1,16 @@
+package feed
+
+import "fmt"
+
+// APIError is returned when a feed URL answers with a non-200 status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("feed returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("feed returned status %d: %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,109 @@
+package hackernews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ClientInterface defines the interface for Hacker News client operations
+type ClientInterface interface {
+	Search(ctx context.Context, params SearchParams) (*SearchResponse, error)
+}
+
+// SearchParams selects Hacker News items through the Algolia search API
+type SearchParams struct {
+	// Query is the full-text query, all items match when empty
+	Query string
+	// Tags filters the item type, for example story, ask_hn, show_hn or front_page
+	Tags string
+	// ByDate sorts by submission time, newest first, instead of by relevance and points
+	ByDate bool
+	// CreatedAfter keeps the items submitted after it, unless it is zero
+	CreatedAfter time.Time
+	// Limit is the maximum number of items to retrieve (default: 25, max: 100)
+	Limit int
+}
+
+// Client represents an Algolia Hacker News API client
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewClientWithHTTPClient creates a new Hacker News client that sends requests through httpClient
+func NewClientWithHTTPClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    baseURL,
+	}
+}
+
+// NewTestClient creates a new Hacker News client for testing with a custom baseURL
+func NewTestClient(baseURL string) *Client {
+	return NewClientWithHTTPClient(baseURL, &http.Client{Timeout: 30 * time.Second})
+}
+
+// NewClientFromConfig creates a new Hacker News client configured from the hackernews section of cfg
+func NewClientFromConfig(cfg *viper.Viper, httpClient *http.Client) *Client {
+	baseURL := cfg.GetString("hackernews.base_url")
+	if baseURL == "" {
+		baseURL = "https://hn.algolia.com"
+	}
+	return NewClientWithHTTPClient(baseURL, httpClient)
+}
+
+// Search returns the items matching params
+func (c *Client) Search(ctx context.Context, params SearchParams) (*SearchResponse, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	query := url.Values{
+		"query":       {params.Query},
+		"hitsPerPage": {strconv.Itoa(limit)},
+	}
+	if params.Tags != "" {
+		query.Set("tags", params.Tags)
+	}
+	if !params.CreatedAfter.IsZero() {
+		query.Set("numericFilters", fmt.Sprintf("created_at_i>%d", params.CreatedAfter.Unix()))
+	}
+	endpoint := "/api/v1/search"
+	if params.ByDate {
+		endpoint = "/api/v1/search_by_date"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var searchResponse *SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return searchResponse, nil
+}
@@ -0,0 +1,86 @@
+package hackernews
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// Search Tests
+// ============================================================================
+
+func TestClient_Search(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/search.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/search", r.URL.Path)
+			assert.Equal(t, "go generics", r.URL.Query().Get("query"))
+			assert.Equal(t, "story", r.URL.Query().Get("tags"))
+			assert.Equal(t, "10", r.URL.Query().Get("hitsPerPage"))
+			assert.Empty(t, r.URL.Query().Get("numericFilters"))
+			w.Write(fixture)
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		result, err := client.Search(context.Background(), SearchParams{Query: "go generics", Tags: "story", Limit: 10})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Hits, 2)
+		assert.Equal(t, "40000001", result.Hits[0].ObjectID)
+		assert.Equal(t, 412, result.Hits[0].Points)
+		assert.Empty(t, result.Hits[1].URL)
+		assert.Contains(t, result.Hits[1].StoryText, "generics")
+	})
+
+	t.Run("ByDateSince", func(t *testing.T) {
+		// Arrange
+		createdAfter := time.Unix(1723000000, 0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/search_by_date", r.URL.Path)
+			assert.Equal(t, "created_at_i>1723000000", r.URL.Query().Get("numericFilters"))
+			assert.Equal(t, "25", r.URL.Query().Get("hitsPerPage"))
+			w.Write(fixture)
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		_, err := client.Search(context.Background(), SearchParams{ByDate: true, CreatedAfter: createdAfter})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("HTTPError", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		result, err := client.Search(context.Background(), SearchParams{Query: "go"})
+
+		// Assert
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "status 503")
+	})
+}
@@ -0,0 +1,17 @@
+package hackernews
+
+// SearchResponse is a page of Algolia Hacker News search results
+type SearchResponse struct {
+	Hits []Hit `json:"hits"`
+}
+
+type Hit struct {
+	ObjectID    string `json:"objectID"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`        // Empty for Ask HN and other text posts
+	StoryText   string `json:"story_text"` // HTML body of text posts
+	Author      string `json:"author"`
+	Points      int    `json:"points"`
+	NumComments int    `json:"num_comments"`
+	CreatedAtI  int64  `json:"created_at_i"` // Unix time of submission
+}
@@ -0,0 +1,16 @@
+package hackernews
+
+import "fmt"
+
+// APIError is returned when the Algolia Hacker News API answers with a non-200 status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("hacker news API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("hacker news API returned status %d: %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,168 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check is a named dependency probe. Check returns nil when the dependency is usable.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// StatusError is implemented by the errors of a dependency that answered with an unexpected
+// HTTP status
+type StatusError interface {
+	error
+	HTTPStatus() int
+}
+
+// Result is the outcome of a single dependency probe. Reports are served without authentication,
+// so Error is a fixed message, never the text of the probe error.
+type Result struct {
+	Status    string    `json:"status"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the aggregated readiness of every dependency
+type Report struct {
+	Status string            `json:"status"`
+	Cached bool              `json:"cached"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready reports whether every dependency is up
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Checker runs dependency probes concurrently and caches the report for a while,
+// so frequent readiness polling does not hammer the dependencies
+type Checker struct {
+	checks   []Check
+	ttl      time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+	observe  func(hit bool)
+	failed   func(name string, err error)
+	mu       sync.Mutex
+	report   Report
+	cachedAt time.Time
+}
+
+// NewChecker creates a checker that caches results for ttl and gives each probe up to timeout
+func NewChecker(ttl, timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		ttl:     ttl,
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// ObserveCache registers fn to be called on every Check with whether the cached report was used
+func (c *Checker) ObserveCache(fn func(hit bool)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observe = fn
+}
+
+// ObserveFailure registers fn to be called with the error of every failed probe, which the report
+// does not carry
+func (c *Checker) ObserveFailure(fn func(name string, err error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failed = fn
+}
+
+// Check returns the cached report when it is fresh, otherwise it probes every dependency
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hit := !c.cachedAt.IsZero() && c.now().Sub(c.cachedAt) < c.ttl
+	if c.observe != nil {
+		c.observe(hit)
+	}
+	if hit {
+		report := c.report
+		report.Cached = true
+		return report
+	}
+
+	c.report = c.run(ctx)
+	c.cachedAt = c.now()
+	return c.report
+}
+
+func (c *Checker) run(ctx context.Context) Report {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.probe(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(c.checks)),
+	}
+	for i, check := range c.checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (c *Checker) probe(ctx context.Context, check Check) Result {
+	// The result is shared through the cache, so it must not depend on the caller going away
+	probeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.timeout)
+	defer cancel()
+
+	start := c.now()
+	err := check.Check(probeCtx)
+	result := Result{
+		Status:    StatusUp,
+		LatencyMs: c.now().Sub(start).Milliseconds(),
+		CheckedAt: start.UTC(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = describe(err)
+		if c.failed != nil {
+			c.failed(check.Name, err)
+		}
+	}
+	return result
+}
+
+// describe returns the message of a failed probe: timeout, the status the dependency answered with
+// or unreachable. The error itself may name internal hosts or hold the body of an upstream response.
+func describe(err error) string {
+	var statusErr StatusError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &statusErr):
+		return fmt.Sprintf("status %d", statusErr.HTTPStatus())
+	default:
+		return "unreachable"
+	}
+}
@@ -0,0 +1,178 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testStatusError is the error of a dependency that answered with statusCode
+type testStatusError struct {
+	statusCode int
+}
+
+func (e testStatusError) Error() string {
+	return fmt.Sprintf("status %d: upstream body", e.statusCode)
+}
+
+func (e testStatusError) HTTPStatus() int {
+	return e.statusCode
+}
+
+// ============================================================================
+// Checker Tests
+// ============================================================================
+
+func TestChecker_Check(t *testing.T) {
+	t.Run("AllUp", func(t *testing.T) {
+		// Arrange
+		checker := NewChecker(time.Minute, time.Second,
+			Check{Name: "llm", Check: func(ctx context.Context) error { return nil }},
+			Check{Name: "reddit", Check: func(ctx context.Context) error { return nil }},
+		)
+
+		// Act
+		report := checker.Check(context.Background())
+
+		// Assert
+		assert.True(t, report.Ready())
+		assert.False(t, report.Cached)
+		assert.Equal(t, StatusUp, report.Checks["llm"].Status)
+		assert.Equal(t, StatusUp, report.Checks["reddit"].Status)
+		assert.False(t, report.Checks["llm"].CheckedAt.IsZero())
+	})
+
+	t.Run("OneDown", func(t *testing.T) {
+		// Arrange
+		checker := NewChecker(time.Minute, time.Second,
+			Check{Name: "llm", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+			Check{Name: "reddit", Check: func(ctx context.Context) error { return nil }},
+		)
+
+		// Act
+		report := checker.Check(context.Background())
+
+		// Assert
+		assert.False(t, report.Ready())
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, StatusDown, report.Checks["llm"].Status)
+		assert.Equal(t, "unreachable", report.Checks["llm"].Error)
+		assert.Equal(t, StatusUp, report.Checks["reddit"].Status)
+	})
+
+	t.Run("CachesResult", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		checker := NewChecker(time.Minute, time.Second,
+			Check{Name: "llm", Check: func(ctx context.Context) error {
+				calls.Add(1)
+				return nil
+			}},
+		)
+
+		// Act
+		first := checker.Check(context.Background())
+		second := checker.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, int32(1), calls.Load())
+		assert.False(t, first.Cached)
+		assert.True(t, second.Cached)
+	})
+
+	t.Run("RefreshesAfterTTL", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		now := time.Now()
+		checker := NewChecker(time.Minute, time.Second,
+			Check{Name: "llm", Check: func(ctx context.Context) error {
+				calls.Add(1)
+				return nil
+			}},
+		)
+		checker.now = func() time.Time { return now }
+
+		// Act
+		checker.Check(context.Background())
+		now = now.Add(2 * time.Minute)
+		report := checker.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, int32(2), calls.Load())
+		assert.False(t, report.Cached)
+	})
+
+	t.Run("TimesOutSlowProbe", func(t *testing.T) {
+		// Arrange
+		checker := NewChecker(time.Minute, 20*time.Millisecond,
+			Check{Name: "reddit", Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+		)
+
+		// Act
+		report := checker.Check(context.Background())
+
+		// Assert
+		assert.False(t, report.Ready())
+		assert.Equal(t, "timeout", report.Checks["reddit"].Error)
+	})
+
+	t.Run("DescribesStatusError", func(t *testing.T) {
+		// Arrange
+		checker := NewChecker(time.Minute, time.Second,
+			Check{Name: "llm", Check: func(ctx context.Context) error {
+				return fmt.Errorf("failed to get embedding: %w", testStatusError{statusCode: 503})
+			}},
+		)
+
+		// Act
+		report := checker.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, "status 503", report.Checks["llm"].Error)
+	})
+
+	t.Run("ObservesFailures", func(t *testing.T) {
+		// Arrange
+		probeErr := errors.New(`Post "http://10.0.0.5/v1/embeddings": connection refused`)
+		var failures []error
+		checker := NewChecker(time.Minute, time.Second,
+			Check{Name: "llm", Check: func(ctx context.Context) error { return probeErr }},
+			Check{Name: "reddit", Check: func(ctx context.Context) error { return nil }},
+		)
+		checker.ObserveFailure(func(name string, err error) {
+			assert.Equal(t, "llm", name)
+			failures = append(failures, err)
+		})
+
+		// Act
+		report := checker.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, []error{probeErr}, failures)
+		assert.Equal(t, "unreachable", report.Checks["llm"].Error)
+	})
+
+	t.Run("ObservesCacheLookups", func(t *testing.T) {
+		// Arrange
+		var hits []bool
+		checker := NewChecker(time.Minute, time.Second,
+			Check{Name: "llm", Check: func(ctx context.Context) error { return nil }},
+		)
+		checker.ObserveCache(func(hit bool) { hits = append(hits, hit) })
+
+		// Act
+		checker.Check(context.Background())
+		checker.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, []bool{false, true}, hits)
+	})
+}
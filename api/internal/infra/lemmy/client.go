@@ -0,0 +1,122 @@
+package lemmy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ClientInterface defines the interface for Lemmy client operations
+type ClientInterface interface {
+	GetPosts(ctx context.Context, instance, community, sort string, limit int) (*PostsResponse, error)
+	SearchPosts(ctx context.Context, instance, community, query, sort string, limit int) (*PostsResponse, error)
+}
+
+// Client represents a client of the v3 API of Lemmy instances
+type Client struct {
+	httpClient *http.Client
+	// baseURL returns the URL of the API of an instance
+	baseURL   func(instance string) string
+	userAgent string
+}
+
+// NewClientWithHTTPClient creates a new Lemmy client that sends requests to https://{instance}
+// through httpClient
+func NewClientWithHTTPClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL: func(instance string) string {
+			return "https://" + instance
+		},
+		userAgent: "reddit-content-analyzer/1.0",
+	}
+}
+
+// NewTestClient creates a new Lemmy client for testing that sends the requests of every
+// instance to baseURL
+func NewTestClient(baseURL string) *Client {
+	client := NewClientWithHTTPClient(&http.Client{Timeout: 30 * time.Second})
+	client.baseURL = func(string) string { return baseURL }
+	return client
+}
+
+// GetPosts retrieves the posts of a community of instance, sorted by sort (Hot, New, TopWeek, ...)
+// limit specifies the maximum number of posts to retrieve (default: 25, max: 50)
+func (c *Client) GetPosts(ctx context.Context, instance, community, sort string, limit int) (*PostsResponse, error) {
+	query := url.Values{
+		"community_name": {community},
+		"limit":          {strconv.Itoa(clampLimit(limit))},
+	}
+	if sort != "" {
+		query.Set("sort", sort)
+	}
+	return c.getPosts(ctx, c.baseURL(instance)+"/api/v3/post/list?"+query.Encode())
+}
+
+// SearchPosts searches the posts of a community of instance by query terms
+// limit specifies the maximum number of posts to retrieve (default: 25, max: 50)
+func (c *Client) SearchPosts(ctx context.Context, instance, community, query, sort string, limit int) (*PostsResponse, error) {
+	params := url.Values{
+		"q":              {query},
+		"type_":          {"Posts"},
+		"community_name": {community},
+		"limit":          {strconv.Itoa(clampLimit(limit))},
+	}
+	if sort != "" {
+		params.Set("sort", sort)
+	}
+	return c.getPosts(ctx, c.baseURL(instance)+"/api/v3/search?"+params.Encode())
+}
+
+func (c *Client) getPosts(ctx context.Context, url string) (*PostsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var postsResponse *PostsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&postsResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return postsResponse, nil
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return 25
+	}
+	if limit > 50 {
+		return 50
+	}
+	return limit
+}
+
+// ParseTime parses the published time of a post, which older instances send without a zone.
+// It returns the zero time when value is not a time.
+func ParseTime(value string) time.Time {
+	if published, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return published
+	}
+	if published, err := time.Parse("2006-01-02T15:04:05.999999999", value); err == nil {
+		return published
+	}
+	return time.Time{}
+}
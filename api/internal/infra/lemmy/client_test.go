@@ -0,0 +1,118 @@
+package lemmy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+)
+
+// ============================================================================
+// Posts Tests
+// ============================================================================
+
+func TestClient_GetPosts(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/posts.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v3/post/list?community_name=golang&limit=50&sort=TopWeek", r.URL.Path+"?"+r.URL.RawQuery)
+			w.Write(fixture)
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		result, err := client.GetPosts(context.Background(), "programming.dev", "golang", "TopWeek", 80)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Posts, 2)
+		assert.Equal(t, 1201, result.Posts[0].Post.ID)
+		assert.Equal(t, "alice", result.Posts[0].Creator.Name)
+		assert.Equal(t, 51, result.Posts[1].Counts.Score)
+	})
+
+	t.Run("HTTPError", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"couldnt_find_community"}`))
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		_, err := client.GetPosts(context.Background(), "programming.dev", "nope", "", 0)
+
+		// Assert
+		assert.ErrorContains(t, err, "couldnt_find_community")
+	})
+
+	t.Run("LoopbackInstanceRefused", func(t *testing.T) {
+		// Arrange
+		client := NewClientWithHTTPClient(&http.Client{Transport: netguard.Guard{}.Transport()})
+
+		// Act
+		_, err := client.GetPosts(context.Background(), "127.0.0.1", "golang", "", 0)
+
+		// Assert
+		assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+	})
+}
+
+func TestClient_SearchPosts(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/search", r.URL.Path)
+		assert.Equal(t, "generics", r.URL.Query().Get("q"))
+		assert.Equal(t, "Posts", r.URL.Query().Get("type_"))
+		assert.Equal(t, "golang", r.URL.Query().Get("community_name"))
+		assert.Equal(t, "25", r.URL.Query().Get("limit"))
+		w.Write([]byte(`{"type_":"Posts","posts":[],"comments":[],"communities":[],"users":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClient(server.URL)
+
+	// Act
+	result, err := client.SearchPosts(context.Background(), "programming.dev", "golang", "generics", "", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.Posts)
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+	}{
+		{"UTC", "2024-08-06T10:00:00.123456Z", time.Date(2024, 8, 6, 10, 0, 0, 123456000, time.UTC)},
+		{"WithoutZone", "2024-08-13T16:20:00.5", time.Date(2024, 8, 13, 16, 20, 0, 500000000, time.UTC)},
+		{"Invalid", "yesterday", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := ParseTime(tt.value)
+
+			// Assert
+			assert.True(t, tt.expected.Equal(result), "expected %v, got %v", tt.expected, result)
+		})
+	}
+}
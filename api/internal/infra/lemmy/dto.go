@@ -0,0 +1,36 @@
+package lemmy
+
+// PostsResponse is the response of the post list and post search endpoints
+type PostsResponse struct {
+	Posts []PostView `json:"posts"`
+}
+
+type PostView struct {
+	Post      Post      `json:"post"`
+	Creator   Person    `json:"creator"`
+	Community Community `json:"community"`
+	Counts    Counts    `json:"counts"`
+}
+
+type Post struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"` // Title of the post
+	Body      string `json:"body"`
+	URL       string `json:"url"`       // Link of link posts
+	ApID      string `json:"ap_id"`     // ActivityPub ID, the canonical URL of the post
+	Published string `json:"published"` // RFC 3339, without a zone on older instances
+}
+
+type Person struct {
+	Name string `json:"name"`
+}
+
+type Community struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+type Counts struct {
+	Score    int `json:"score"`
+	Comments int `json:"comments"`
+}
@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+)
+
+// CaptionerInterface defines the interface for captioning images with a multimodal model
+type CaptionerInterface interface {
+	CaptionImage(ctx context.Context, imageURL, prompt string) (string, error)
+}
+
+// VisionRequest represents a chat completion request whose messages hold images
+type VisionRequest struct {
+	Model    string          `json:"model"`
+	Messages []VisionMessage `json:"messages"`
+}
+
+// VisionMessage represents a chat message made of text and image parts
+type VisionMessage struct {
+	Role    string        `json:"role"`
+	Content []ContentPart `json:"content"`
+}
+
+// ContentPart is a text or image part of a vision message
+type ContentPart struct {
+	Type     string    `json:"type"` // text or image_url
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+// ImageURL is the address of an image, an http(s) URL or a data URL
+type ImageURL struct {
+	URL string `json:"url"`
+}
+
+// VisionModel returns the name of the multimodal model used for captions, empty when none is configured
+func (c *Client) VisionModel() string {
+	return c.visionModel
+}
+
+// CaptionImage asks the vision model to describe the image at imageURL as prompt says
+func (c *Client) CaptionImage(ctx context.Context, imageURL, prompt string) (string, error) {
+	if c.visionModel == "" {
+		return "", fmt.Errorf("no vision model is configured")
+	}
+	log := logger.FromContext(ctx, c.logger)
+	log.Info("Captioning image", zap.String("model", c.visionModel), zap.String("image_url", imageURL))
+
+	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
+	req := VisionRequest{
+		Model: c.visionModel,
+		Messages: []VisionMessage{{
+			Role: "user",
+			Content: []ContentPart{
+				{Type: "text", Text: prompt},
+				{Type: "image_url", ImageURL: &ImageURL{URL: imageURL}},
+			},
+		}},
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		log.Error("Error calling vision API", zap.Error(err))
+		return "", fmt.Errorf("failed to call API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		log.Error("Vision API returned error", zap.Int("status", resp.StatusCode), zap.String("body", string(body)))
+		return "", &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var chatResp ChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return "", fmt.Errorf("no choices in response")
+	}
+
+	reportUsage(ctx, Usage{
+		Model:            modelOrDefault(chatResp.Model, c.visionModel),
+		PromptTokens:     chatResp.Usage.PromptTokens,
+		CompletionTokens: chatResp.Usage.CompletionTokens,
+		TotalTokens:      chatResp.Usage.TotalTokens,
+	})
+
+	caption := chatResp.Choices[0].Message.Content
+	log.Info("Caption received", zap.String("response", caption))
+	return caption, nil
+}
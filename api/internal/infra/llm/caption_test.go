@@ -0,0 +1,79 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// CaptionImage Tests
+// ============================================================================
+
+func TestClient_CaptionImage(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/chat/completions", r.URL.Path)
+
+			var req VisionRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			assert.Equal(t, "vision-model", req.Model)
+			assert.Equal(t, []VisionMessage{{
+				Role: "user",
+				Content: []ContentPart{
+					{Type: "text", Text: "Describe it"},
+					{Type: "image_url", ImageURL: &ImageURL{URL: "https://i.redd.it/chart.png"}},
+				},
+			}}, req.Messages)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"A bar chart"}}],"model":"vision-model"}`))
+		}))
+		defer server.Close()
+		client := NewClient(server.URL, "embed-model", "chat-model", server.Client(), zap.NewNop())
+		client.visionModel = "vision-model"
+
+		// Act
+		caption, err := client.CaptionImage(context.Background(), "https://i.redd.it/chart.png", "Describe it")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "A bar chart", caption)
+	})
+
+	t.Run("HTTPError", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("model does not support images"))
+		}))
+		defer server.Close()
+		client := NewClient(server.URL, "embed-model", "chat-model", server.Client(), zap.NewNop())
+		client.visionModel = "chat-model"
+
+		// Act
+		_, err := client.CaptionImage(context.Background(), "https://i.redd.it/chart.png", "Describe it")
+
+		// Assert
+		var apiErr *APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+
+	t.Run("NoVisionModel", func(t *testing.T) {
+		// Arrange
+		client := NewClient("http://127.0.0.1:0", "embed-model", "chat-model", http.DefaultClient, zap.NewNop())
+
+		// Act
+		_, err := client.CaptionImage(context.Background(), "https://i.redd.it/chart.png", "Describe it")
+
+		// Assert
+		assert.ErrorContains(t, err, "no vision model")
+	})
+}
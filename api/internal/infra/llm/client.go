@@ -7,19 +7,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"sync"
 
 	"go.uber.org/zap"
 
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
 	"github.com/firebase/genkit/go/ai"
 	"github.com/firebase/genkit/go/genkit"
-)
+	"github.com/spf13/viper"
 
-var (
-	client *Client
-	once   sync.Once
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
 )
 
 // ClientInterface defines the interface for LLM client operations
@@ -34,6 +29,7 @@ type Client struct {
 	baseURL        string
 	embeddingModel string
 	chatModel      string
+	visionModel    string
 	httpClient     *http.Client
 	logger         *zap.Logger
 }
@@ -88,42 +84,54 @@ type ChatResponse struct {
 	} `json:"usage"`
 }
 
-// GetClient returns the singleton LLM client instance, initializing it on first call
-func GetClient() *Client {
-	once.Do(func() {
-		cfg := config.GetConfig()
-		baseURL := cfg.GetString("llm.base_url")
-		embeddingModel := cfg.GetString("llm.embedding_model")
-		chatModel := cfg.GetString("llm.summarization_model")
-
-		if baseURL == "" {
-			baseURL = "http://127.0.0.1:1234/v1"
-		}
-		if embeddingModel == "" {
-			embeddingModel = "text-embedding-mxbai-embed-large-v1"
-		}
-		if chatModel == "" {
-			chatModel = "openai/gpt-oss-20b"
-		}
+// NewClientFromConfig creates a new LLM client configured from the llm section of cfg
+func NewClientFromConfig(cfg *viper.Viper, httpClient *http.Client, logger *zap.Logger) *Client {
+	baseURL := cfg.GetString("llm.base_url")
+	embeddingModel := cfg.GetString("llm.embedding_model")
+	chatModel := cfg.GetString("llm.summarization_model")
 
-		ctx := context.Background()
-		g := genkit.Init(ctx)
+	if baseURL == "" {
+		baseURL = "http://127.0.0.1:1234/v1"
+	}
+	if embeddingModel == "" {
+		embeddingModel = "text-embedding-mxbai-embed-large-v1"
+	}
+	if chatModel == "" {
+		chatModel = "openai/gpt-oss-20b"
+	}
 
-		client = &Client{
-			genkit:         g,
-			baseURL:        baseURL,
-			embeddingModel: embeddingModel,
-			chatModel:      chatModel,
-			httpClient:     &http.Client{},
-			logger:         logger.GetLogger(),
-		}
-	})
+	client := NewClient(baseURL, embeddingModel, chatModel, httpClient, logger)
+	// Captioning is off unless a multimodal model is configured
+	client.visionModel = cfg.GetString("llm.vision_model")
+	client.genkit = genkit.Init(context.Background())
 	return client
 }
 
+// NewClient creates a new LLM client for an OpenAI-compatible API that sends requests through httpClient
+func NewClient(baseURL, embeddingModel, chatModel string, httpClient *http.Client, logger *zap.Logger) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		embeddingModel: embeddingModel,
+		chatModel:      chatModel,
+		httpClient:     httpClient,
+		logger:         logger,
+	}
+}
+
+// EmbeddingModel returns the name of the model used for embeddings
+func (c *Client) EmbeddingModel() string {
+	return c.embeddingModel
+}
+
+// ChatModel returns the name of the model used for chat completions
+func (c *Client) ChatModel() string {
+	return c.chatModel
+}
+
 // GetEmbedding generates embeddings for the given text using the configured embedding model
 func (c *Client) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
-	c.logger.Info("Generating embedding", zap.String("text", text), zap.String("model", c.embeddingModel))
+	log := logger.FromContext(ctx, c.logger)
+	log.Info("Generating embedding", zap.String("text", text), zap.String("model", c.embeddingModel))
 
 	// Use OpenAI-compatible API for embeddings
 	url := fmt.Sprintf("%s/embeddings", c.baseURL)
@@ -134,13 +142,13 @@ func (c *Client) GetEmbedding(ctx context.Context, text string) ([]float32, erro
 
 	jsonData, err := json.Marshal(req)
 	if err != nil {
-		c.logger.Error("Error marshaling embedding request", zap.Error(err))
+		log.Error("Error marshaling embedding request", zap.Error(err))
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		c.logger.Error("Error creating embedding request", zap.Error(err))
+		log.Error("Error creating embedding request", zap.Error(err))
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -148,20 +156,20 @@ func (c *Client) GetEmbedding(ctx context.Context, text string) ([]float32, erro
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("Error calling embedding API", zap.Error(err))
+		log.Error("Error calling embedding API", zap.Error(err))
 		return nil, fmt.Errorf("failed to call API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("Embedding API returned error", zap.Int("status", resp.StatusCode), zap.String("body", string(body)))
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		log.Error("Embedding API returned error", zap.Int("status", resp.StatusCode), zap.String("body", string(body)))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var embeddingResp EmbeddingResponse
 	if err := json.NewDecoder(resp.Body).Decode(&embeddingResp); err != nil {
-		c.logger.Error("Error decoding embedding response", zap.Error(err))
+		log.Error("Error decoding embedding response", zap.Error(err))
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -169,13 +177,20 @@ func (c *Client) GetEmbedding(ctx context.Context, text string) ([]float32, erro
 		return nil, fmt.Errorf("no embedding data in response")
 	}
 
-	c.logger.Info("Embedding generated successfully", zap.Int("dimension", len(embeddingResp.Data[0].Embedding)))
+	reportUsage(ctx, Usage{
+		Model:        modelOrDefault(embeddingResp.Model, c.embeddingModel),
+		PromptTokens: embeddingResp.Usage.PromptTokens,
+		TotalTokens:  embeddingResp.Usage.TotalTokens,
+	})
+
+	log.Info("Embedding generated successfully", zap.Int("dimension", len(embeddingResp.Data[0].Embedding)))
 	return embeddingResp.Data[0].Embedding, nil
 }
 
 // Chat sends a chat message and returns the model's response
 func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
-	c.logger.Info("Sending chat message", zap.String("model", c.chatModel), zap.Int("message_count", len(messages)))
+	log := logger.FromContext(ctx, c.logger)
+	log.Info("Sending chat message", zap.String("model", c.chatModel), zap.Int("message_count", len(messages)))
 
 	// Use Genkit's Generate function for chat
 	// Convert messages to Genkit's format
@@ -201,13 +216,13 @@ func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
 
 	jsonData, err := json.Marshal(req)
 	if err != nil {
-		c.logger.Error("Error marshaling chat request", zap.Error(err))
+		log.Error("Error marshaling chat request", zap.Error(err))
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		c.logger.Error("Error creating chat request", zap.Error(err))
+		log.Error("Error creating chat request", zap.Error(err))
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -215,20 +230,20 @@ func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("Error calling chat API", zap.Error(err))
+		log.Error("Error calling chat API", zap.Error(err))
 		return "", fmt.Errorf("failed to call API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("Chat API returned error", zap.Int("status", resp.StatusCode), zap.String("body", string(body)))
-		return "", fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		log.Error("Chat API returned error", zap.Int("status", resp.StatusCode), zap.String("body", string(body)))
+		return "", &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var chatResp ChatResponse
 	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
-		c.logger.Error("Error decoding chat response", zap.Error(err))
+		log.Error("Error decoding chat response", zap.Error(err))
 		return "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -236,7 +251,21 @@ func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
 		return "", fmt.Errorf("no choices in response")
 	}
 
+	reportUsage(ctx, Usage{
+		Model:            modelOrDefault(chatResp.Model, c.chatModel),
+		PromptTokens:     chatResp.Usage.PromptTokens,
+		CompletionTokens: chatResp.Usage.CompletionTokens,
+		TotalTokens:      chatResp.Usage.TotalTokens,
+	})
+
 	responseText := chatResp.Choices[0].Message.Content
-	c.logger.Info("Chat response received", zap.String("response", responseText))
+	log.Info("Chat response received", zap.String("response", responseText))
 	return responseText, nil
 }
+
+func modelOrDefault(model, defaultModel string) string {
+	if model == "" {
+		return defaultModel
+	}
+	return model
+}
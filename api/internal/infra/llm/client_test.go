@@ -7,8 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 // ============================================================================
@@ -60,7 +60,7 @@ func TestClient_GetEmbedding(t *testing.T) {
 			baseURL:        server.URL,
 			embeddingModel: "text-embedding-mxbai-embed-large-v1",
 			httpClient:     &http.Client{},
-			logger:         logger.GetLogger(),
+			logger:         zap.NewNop(),
 		}
 
 		// Act
@@ -87,7 +87,7 @@ func TestClient_GetEmbedding(t *testing.T) {
 			baseURL:        server.URL,
 			embeddingModel: "text-embedding-mxbai-embed-large-v1",
 			httpClient:     &http.Client{},
-			logger:         logger.GetLogger(),
+			logger:         zap.NewNop(),
 		}
 
 		// Act
@@ -113,7 +113,7 @@ func TestClient_GetEmbedding(t *testing.T) {
 			baseURL:        server.URL,
 			embeddingModel: "text-embedding-mxbai-embed-large-v1",
 			httpClient:     &http.Client{},
-			logger:         logger.GetLogger(),
+			logger:         zap.NewNop(),
 		}
 
 		// Act
@@ -147,7 +147,7 @@ func TestClient_GetEmbedding(t *testing.T) {
 			baseURL:        server.URL,
 			embeddingModel: "text-embedding-mxbai-embed-large-v1",
 			httpClient:     &http.Client{},
-			logger:         logger.GetLogger(),
+			logger:         zap.NewNop(),
 		}
 
 		// Act
@@ -167,7 +167,7 @@ func TestClient_GetEmbedding(t *testing.T) {
 			baseURL:        "http://invalid-url-that-does-not-exist:12345",
 			embeddingModel: "text-embedding-mxbai-embed-large-v1",
 			httpClient:     &http.Client{},
-			logger:         logger.GetLogger(),
+			logger:         zap.NewNop(),
 		}
 
 		// Act
@@ -209,7 +209,7 @@ func TestClient_GetEmbedding(t *testing.T) {
 			baseURL:        server.URL,
 			embeddingModel: "text-embedding-mxbai-embed-large-v1",
 			httpClient:     &http.Client{},
-			logger:         logger.GetLogger(),
+			logger:         zap.NewNop(),
 		}
 
 		// Act
@@ -280,7 +280,7 @@ func TestClient_Chat(t *testing.T) {
 			baseURL:   server.URL,
 			chatModel: "openai/gpt-oss-20b",
 			httpClient: &http.Client{},
-			logger:    logger.GetLogger(),
+			logger:    zap.NewNop(),
 		}
 
 		messages := []Message{
@@ -337,7 +337,7 @@ func TestClient_Chat(t *testing.T) {
 			baseURL:   server.URL,
 			chatModel: "openai/gpt-oss-20b",
 			httpClient: &http.Client{},
-			logger:    logger.GetLogger(),
+			logger:    zap.NewNop(),
 		}
 
 		messages := []Message{
@@ -367,7 +367,7 @@ func TestClient_Chat(t *testing.T) {
 			baseURL:   "http://localhost:1234",
 			chatModel: "openai/gpt-oss-20b",
 			httpClient: &http.Client{},
-			logger:    logger.GetLogger(),
+			logger:    zap.NewNop(),
 		}
 
 		messages := []Message{
@@ -400,7 +400,7 @@ func TestClient_Chat(t *testing.T) {
 			baseURL:   server.URL,
 			chatModel: "openai/gpt-oss-20b",
 			httpClient: &http.Client{},
-			logger:    logger.GetLogger(),
+			logger:    zap.NewNop(),
 		}
 
 		messages := []Message{
@@ -433,7 +433,7 @@ func TestClient_Chat(t *testing.T) {
 			baseURL:   server.URL,
 			chatModel: "openai/gpt-oss-20b",
 			httpClient: &http.Client{},
-			logger:    logger.GetLogger(),
+			logger:    zap.NewNop(),
 		}
 
 		messages := []Message{
@@ -477,7 +477,7 @@ func TestClient_Chat(t *testing.T) {
 			baseURL:   server.URL,
 			chatModel: "openai/gpt-oss-20b",
 			httpClient: &http.Client{},
-			logger:    logger.GetLogger(),
+			logger:    zap.NewNop(),
 		}
 
 		messages := []Message{
@@ -504,7 +504,7 @@ func TestClient_Chat(t *testing.T) {
 			baseURL:   "http://invalid-url-that-does-not-exist:12345",
 			chatModel: "openai/gpt-oss-20b",
 			httpClient: &http.Client{},
-			logger:    logger.GetLogger(),
+			logger:    zap.NewNop(),
 		}
 
 		messages := []Message{
@@ -0,0 +1,18 @@
+package llm
+
+import "fmt"
+
+// APIError is returned when the LLM API answers with a non-200 status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// HTTPStatus returns the status code the API answered with
+func (e *APIError) HTTPStatus() int {
+	return e.StatusCode
+}
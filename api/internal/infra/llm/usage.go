@@ -0,0 +1,25 @@
+package llm
+
+import "context"
+
+// Usage is the token usage reported by the LLM API for a single call
+type Usage struct {
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+type usageCallbackKey struct{}
+
+// WithUsageCallback returns a context that makes the client report the token usage
+// of every call made with it to fn
+func WithUsageCallback(ctx context.Context, fn func(Usage)) context.Context {
+	return context.WithValue(ctx, usageCallbackKey{}, fn)
+}
+
+func reportUsage(ctx context.Context, usage Usage) {
+	if fn, ok := ctx.Value(usageCallbackKey{}).(func(Usage)); ok {
+		fn(usage)
+	}
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries logger
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// WithFields returns a copy of ctx whose logger has the additional fields. fallback is
+// used as the base logger when ctx does not carry one yet.
+func WithFields(ctx context.Context, fallback *zap.Logger, fields ...zap.Field) context.Context {
+	return NewContext(ctx, fromContext(ctx, fallback).With(fields...))
+}
+
+// FromContext returns the logger carried by ctx, or fallback when there is none,
+// annotated with the trace and span IDs of the span in ctx
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	return WithTraceContext(ctx, fromContext(ctx, fallback))
+}
+
+func fromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
+}
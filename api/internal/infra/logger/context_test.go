@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// ============================================================================
+// Context Logger Tests
+// ============================================================================
+
+func TestFromContext(t *testing.T) {
+	t.Run("FallbackWithoutLogger", func(t *testing.T) {
+		// Arrange
+		core, logs := observer.New(zap.InfoLevel)
+
+		// Act
+		FromContext(context.Background(), zap.New(core)).Info("message")
+
+		// Assert
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("CarriedLoggerWins", func(t *testing.T) {
+		// Arrange
+		fallbackCore, fallbackLogs := observer.New(zap.InfoLevel)
+		core, logs := observer.New(zap.InfoLevel)
+		ctx := NewContext(context.Background(), zap.New(core))
+
+		// Act
+		FromContext(ctx, zap.New(fallbackCore)).Info("message")
+
+		// Assert
+		assert.Equal(t, 0, fallbackLogs.Len())
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("WithFieldsAccumulates", func(t *testing.T) {
+		// Arrange
+		core, logs := observer.New(zap.InfoLevel)
+		ctx := WithFields(context.Background(), zap.New(core), zap.String("request_id", "abc"))
+
+		// Act
+		ctx = WithFields(ctx, zap.NewNop(), zap.String("subreddit", "golang"))
+		FromContext(ctx, zap.NewNop()).Info("message")
+
+		// Assert
+		assert.Equal(t, map[string]interface{}{"request_id": "abc", "subreddit": "golang"}, logs.All()[0].ContextMap())
+	})
+}
@@ -1,88 +1,175 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
-	"sync"
+	"time"
 
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-
-	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/config"
 )
 
-var (
-	logger *zap.Logger
-	once   sync.Once
-)
+// Logger is the application logger along with its runtime-adjustable level
+type Logger struct {
+	*zap.Logger
+	// Level changes the minimum level of every output while the application runs
+	Level  zap.AtomicLevel
+	closer io.Closer
+}
 
-// GetLogger returns the singleton logger instance, initializing it on first call
-func GetLogger() *zap.Logger {
-	once.Do(func() {
-		cfg := config.GetConfig()
+// Close closes the log file, if any. Log calls made afterwards to a file output fail.
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
 
-		// Get log level from config
-		levelStr := strings.ToLower(cfg.GetString("logging.level"))
-		var level zapcore.Level
-		switch levelStr {
-		case "debug":
-			level = zapcore.DebugLevel
-		case "info":
-			level = zapcore.InfoLevel
-		case "warn":
-			level = zapcore.WarnLevel
-		case "error":
-			level = zapcore.ErrorLevel
-		default:
-			level = zapcore.InfoLevel
-		}
+// New creates a logger configured from the logging section of cfg. logging.output is stdout
+// or a file path; files are rotated per the max_size, max_backups, max_age and compress
+// settings, and logging.tee_stdout also copies file output to stdout.
+func New(cfg *viper.Viper) (*Logger, error) {
+	level, err := ParseLevel(cfg.GetString("logging.level"))
+	if err != nil {
+		return nil, err
+	}
+	atomicLevel := zap.NewAtomicLevelAt(level)
 
-		// Get log format from config
-		format := strings.ToLower(cfg.GetString("logging.format"))
+	redaction, err := RedactionPolicyFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-		// Configure encoder based on format
-		var encoderConfig zapcore.EncoderConfig
-		var encoder zapcore.Encoder
+	format := strings.ToLower(cfg.GetString("logging.format"))
+	output := cfg.GetString("logging.output")
 
-		if format == "json" {
-			encoderConfig = zap.NewProductionEncoderConfig()
-			encoder = zapcore.NewJSONEncoder(encoderConfig)
-		} else {
-			encoderConfig = zap.NewDevelopmentEncoderConfig()
-			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-			encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	var cores []zapcore.Core
+	var closer io.Closer
+	if output == "stdout" || output == "" || cfg.GetBool("logging.tee_stdout") {
+		cores = append(cores, zapcore.NewCore(newEncoder(format, true), zapcore.Lock(os.Stdout), atomicLevel))
+	}
+	if output != "stdout" && output != "" {
+		rotation, err := RotationConfigFromConfig(cfg)
+		if err != nil {
+			return nil, err
 		}
+		file, err := NewRotatingFile(rotation)
+		if err != nil {
+			return nil, err
+		}
+		closer = file
+		cores = append(cores, zapcore.NewCore(newEncoder(format, false), file, atomicLevel))
+	}
 
-		// Get output from config (stdout is default)
-		output := cfg.GetString("logging.output")
-		var writeSyncer zapcore.WriteSyncer
+	core := NewRedactingCore(zapcore.NewTee(cores...), redaction)
+	return &Logger{
+		Logger: zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)),
+		Level:  atomicLevel,
+		closer: closer,
+	}, nil
+}
 
-		if output == "stdout" || output == "" {
-			writeSyncer = zapcore.AddSync(os.Stdout)
-		} else {
-			// For file output, open the file
-			file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-			if err != nil {
-				// Fallback to stdout if file can't be opened
-				writeSyncer = zapcore.AddSync(os.Stdout)
-			} else {
-				writeSyncer = zapcore.AddSync(file)
-			}
-		}
+// ParseLevel parses debug, info, warn or error. An empty level means info.
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info", "":
+		return zapcore.InfoLevel, nil
+	case "warn":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("unknown log level %q", level)
+	}
+}
 
-		// Create the core
-		core := zapcore.NewCore(encoder, writeSyncer, level)
+// newEncoder returns a JSON encoder for the json format, otherwise a console encoder that
+// colors levels only when color is set, so files do not end up with escape codes
+func newEncoder(format string, color bool) zapcore.Encoder {
+	if format == "json" {
+		return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	}
+	encoderConfig := zap.NewDevelopmentEncoderConfig()
+	if color {
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+	return zapcore.NewConsoleEncoder(encoderConfig)
+}
 
-		// Create the logger
-		logger = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
-	})
-	return logger
+// RotationConfigFromConfig reads the rotation settings of the logging.output file.
+// max_size accepts B, KB, MB and GB suffixes and max_age accepts a d (days) suffix
+// besides Go durations.
+func RotationConfigFromConfig(cfg *viper.Viper) (RotationConfig, error) {
+	maxSize, err := parseSize(cfg.GetString("logging.max_size"))
+	if err != nil {
+		return RotationConfig{}, err
+	}
+	maxAge, err := parseAge(cfg.GetString("logging.max_age"))
+	if err != nil {
+		return RotationConfig{}, err
+	}
+	return RotationConfig{
+		Filename:          cfg.GetString("logging.output"),
+		MaxSize:           maxSize,
+		MaxBackups:        cfg.GetInt("logging.max_backups"),
+		MaxAge:            maxAge,
+		Compress:          cfg.GetBool("logging.compress"),
+		CompressLevel:     cfg.GetInt("logging.compress_level"),
+		CompressExtension: cfg.GetString("logging.compress_extension"),
+	}, nil
 }
 
-// Sync flushes any buffered log entries
-func Sync() error {
-	if logger == nil {
-		return nil
+// parseSize parses sizes such as 512KB or 100MB into bytes. An empty size means no limit.
+func parseSize(raw string) (int64, error) {
+	size := strings.ToUpper(strings.TrimSpace(raw))
+	if size == "" {
+		return 0, nil
+	}
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	} {
+		if strings.HasSuffix(size, unit.suffix) {
+			size = strings.TrimSpace(strings.TrimSuffix(size, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+	value, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid log max_size %q", raw)
+	}
+	return value * multiplier, nil
+}
+
+// parseAge parses ages such as 30d or 12h. An empty age means backups are kept forever.
+func parseAge(age string) (time.Duration, error) {
+	age = strings.TrimSpace(age)
+	if age == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(age, "d"); ok {
+		value, err := strconv.Atoi(days)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("invalid log max_age %q", age)
+		}
+		return time.Duration(value) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(age)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid log max_age %q", age)
 	}
-	return logger.Sync()
+	return duration, nil
 }
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// New Tests
+// ============================================================================
+
+func TestNew(t *testing.T) {
+	t.Run("WritesToFile", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		filename := filepath.Join(t.TempDir(), "api.log")
+		cfg.Set("logging.format", "json")
+		cfg.Set("logging.output", filename)
+
+		// Act
+		log, err := New(cfg)
+		require.NoError(t, err)
+		log.Info("hello")
+		require.NoError(t, log.Close())
+
+		// Assert
+		content, err := os.ReadFile(filename)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"msg":"hello"`)
+	})
+
+	t.Run("FailsWhenFileCannotBeOpened", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		blocker := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(blocker, nil, 0600))
+		cfg.Set("logging.output", filepath.Join(blocker, "api.log"))
+
+		// Act
+		log, err := New(cfg)
+
+		// Assert
+		assert.Nil(t, log)
+		assert.Error(t, err)
+	})
+
+	t.Run("UnknownLevel", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		cfg.Set("logging.level", "verbose")
+
+		// Act
+		_, err := New(cfg)
+
+		// Assert
+		assert.EqualError(t, err, `unknown log level "verbose"`)
+	})
+
+	t.Run("LevelChangesAtRuntime", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		filename := filepath.Join(t.TempDir(), "api.log")
+		cfg.Set("logging.format", "json")
+		cfg.Set("logging.output", filename)
+		log, err := New(cfg)
+		require.NoError(t, err)
+
+		// Act
+		log.Debug("before")
+		log.Level.SetLevel(zap.DebugLevel)
+		log.Debug("after")
+		require.NoError(t, log.Close())
+
+		// Assert
+		content, _ := os.ReadFile(filename)
+		assert.NotContains(t, string(content), "before")
+		assert.Contains(t, string(content), "after")
+	})
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	RedactionOff      = "off"
+	RedactionTruncate = "truncate"
+	RedactionHash     = "hash"
+)
+
+// DefaultRedactedFields are the field keys that carry post content, prompts and API payloads
+var DefaultRedactedFields = []string{"content", "text", "prompt", "response", "body"}
+
+// RedactionPolicy decides how string fields with sensitive or large values are written
+type RedactionPolicy struct {
+	// Mode is off, truncate or hash
+	Mode string
+	// MaxLength is the number of characters kept by truncate
+	MaxLength int
+	// Fields are the keys of the fields to redact
+	Fields []string
+}
+
+// RedactionPolicyFromConfig reads the policy from logging.redaction, defaulting to truncating
+// DefaultRedactedFields to 200 characters
+func RedactionPolicyFromConfig(cfg *viper.Viper) (RedactionPolicy, error) {
+	policy := RedactionPolicy{
+		Mode:      strings.ToLower(cfg.GetString("logging.redaction.mode")),
+		MaxLength: cfg.GetInt("logging.redaction.max_length"),
+		Fields:    cfg.GetStringSlice("logging.redaction.fields"),
+	}
+	if policy.Mode == "" {
+		policy.Mode = RedactionTruncate
+	}
+	if policy.MaxLength <= 0 {
+		policy.MaxLength = 200
+	}
+	if len(policy.Fields) == 0 {
+		policy.Fields = DefaultRedactedFields
+	}
+	switch policy.Mode {
+	case RedactionOff, RedactionTruncate, RedactionHash:
+		return policy, nil
+	default:
+		return RedactionPolicy{}, fmt.Errorf("unknown log redaction mode %q", policy.Mode)
+	}
+}
+
+// redactingCore rewrites the configured string fields before handing them to the wrapped core
+type redactingCore struct {
+	zapcore.Core
+	policy RedactionPolicy
+	fields map[string]struct{}
+}
+
+// NewRedactingCore wraps core so string fields named in policy are truncated or hashed
+func NewRedactingCore(core zapcore.Core, policy RedactionPolicy) zapcore.Core {
+	if policy.Mode == RedactionOff {
+		return core
+	}
+	fields := make(map[string]struct{}, len(policy.Fields))
+	for _, field := range policy.Fields {
+		fields[field] = struct{}{}
+	}
+	return &redactingCore{
+		Core:   core,
+		policy: policy,
+		fields: fields,
+	}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{
+		Core:   c.Core.With(c.redact(fields)),
+		policy: c.policy,
+		fields: c.fields,
+	}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redact(fields))
+}
+
+func (c *redactingCore) redact(fields []zapcore.Field) []zapcore.Field {
+	redacted := fields
+	copied := false
+	for i, field := range fields {
+		if field.Type != zapcore.StringType {
+			continue
+		}
+		if _, ok := c.fields[field.Key]; !ok {
+			continue
+		}
+		value, changed := c.redactValue(field.String)
+		if !changed {
+			continue
+		}
+		// Copy on first change, the caller owns the original slice
+		if !copied {
+			redacted = append([]zapcore.Field(nil), fields...)
+			copied = true
+		}
+		redacted[i].String = value
+	}
+	return redacted
+}
+
+func (c *redactingCore) redactValue(value string) (string, bool) {
+	switch c.policy.Mode {
+	case RedactionHash:
+		if value == "" {
+			return value, false
+		}
+		sum := sha256.Sum256([]byte(value))
+		return fmt.Sprintf("sha256:%s (%d chars)", hex.EncodeToString(sum[:])[:16], utf8.RuneCountInString(value)), true
+	case RedactionTruncate:
+		length := utf8.RuneCountInString(value)
+		if length <= c.policy.MaxLength {
+			return value, false
+		}
+		return fmt.Sprintf("%s… (%d chars)", string([]rune(value)[:c.policy.MaxLength]), length), true
+	}
+	return value, false
+}
@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// ============================================================================
+// Redaction Tests
+// ============================================================================
+
+func TestNewRedactingCore(t *testing.T) {
+	newLogger := func(policy RedactionPolicy) (*zap.Logger, *observer.ObservedLogs) {
+		core, logs := observer.New(zap.DebugLevel)
+		return zap.New(NewRedactingCore(core, policy)), logs
+	}
+
+	t.Run("Truncate", func(t *testing.T) {
+		// Arrange
+		log, logs := newLogger(RedactionPolicy{Mode: RedactionTruncate, MaxLength: 5, Fields: []string{"content"}})
+
+		// Act
+		log.Info("post", zap.String("content", "a long post body"), zap.String("title", "a long title"))
+
+		// Assert
+		fields := logs.All()[0].ContextMap()
+		assert.Equal(t, "a lon… (16 chars)", fields["content"])
+		assert.Equal(t, "a long title", fields["title"])
+	})
+
+	t.Run("TruncateKeepsShortValues", func(t *testing.T) {
+		// Arrange
+		log, logs := newLogger(RedactionPolicy{Mode: RedactionTruncate, MaxLength: 50, Fields: []string{"content"}})
+
+		// Act
+		log.Info("post", zap.String("content", "short"))
+
+		// Assert
+		assert.Equal(t, "short", logs.All()[0].ContextMap()["content"])
+	})
+
+	t.Run("Hash", func(t *testing.T) {
+		// Arrange
+		log, logs := newLogger(RedactionPolicy{Mode: RedactionHash, Fields: []string{"response"}})
+
+		// Act
+		log.Info("chat", zap.String("response", "secret"))
+		log.Info("chat", zap.String("response", "secret"))
+
+		// Assert
+		first := logs.All()[0].ContextMap()["response"].(string)
+		assert.True(t, strings.HasPrefix(first, "sha256:"))
+		assert.NotContains(t, first, "secret")
+		assert.Equal(t, first, logs.All()[1].ContextMap()["response"])
+	})
+
+	t.Run("RedactsLoggerFields", func(t *testing.T) {
+		// Arrange
+		log, logs := newLogger(RedactionPolicy{Mode: RedactionTruncate, MaxLength: 3, Fields: []string{"body"}})
+
+		// Act
+		log.With(zap.String("body", "payload")).Info("error")
+
+		// Assert
+		assert.Equal(t, "pay… (7 chars)", logs.All()[0].ContextMap()["body"])
+	})
+
+	t.Run("Off", func(t *testing.T) {
+		// Arrange
+		log, logs := newLogger(RedactionPolicy{Mode: RedactionOff, MaxLength: 3, Fields: []string{"content"}})
+
+		// Act
+		log.Info("post", zap.String("content", "unchanged"))
+
+		// Assert
+		assert.Equal(t, "unchanged", logs.All()[0].ContextMap()["content"])
+	})
+}
+
+func TestRedactionPolicyFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		// Act
+		policy, err := RedactionPolicyFromConfig(viper.New())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, RedactionTruncate, policy.Mode)
+		assert.Equal(t, 200, policy.MaxLength)
+		assert.Equal(t, DefaultRedactedFields, policy.Fields)
+	})
+
+	t.Run("UnknownMode", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		cfg.Set("logging.redaction.mode", "encrypt")
+
+		// Act
+		_, err := RedactionPolicyFromConfig(cfg)
+
+		// Assert
+		assert.EqualError(t, err, `unknown log redaction mode "encrypt"`)
+	})
+}
@@ -0,0 +1,317 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	logFileMode = 0640
+	logDirMode  = 0750
+	// backupTimeFormat sorts lexically in chronological order and is safe in file names
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// RotationConfig controls when a log file is rotated and how its backups are kept
+type RotationConfig struct {
+	Filename string
+	// MaxSize is the size in bytes at which the file is rotated, 0 disables rotation
+	MaxSize int64
+	// MaxBackups is the number of rotated files to keep, 0 keeps all of them
+	MaxBackups int
+	// MaxAge is how long rotated files are kept, 0 keeps them forever
+	MaxAge time.Duration
+	// Compress gzips rotated files with CompressLevel (0 uses the gzip default) and appends
+	// CompressExtension to their name
+	Compress          bool
+	CompressLevel     int
+	CompressExtension string
+}
+
+// RotatingFile is a log file that is rotated once it reaches MaxSize. Rotated files are
+// renamed to <name>-<timestamp><ext>, or <name>-<timestamp>-<n><ext> for the later rotations of
+// the same millisecond, then compressed and pruned in the background.
+type RotatingFile struct {
+	cfg  RotationConfig
+	now  func() time.Time
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	mill sync.WaitGroup
+	// millMu serializes compression and pruning so concurrent rotations do not race on backups
+	millMu sync.Mutex
+}
+
+// NewRotatingFile opens cfg.Filename for appending, creating it and its directory when missing
+func NewRotatingFile(cfg RotationConfig) (*RotatingFile, error) {
+	if cfg.Filename == "" {
+		return nil, fmt.Errorf("log file name is empty")
+	}
+	if cfg.Compress {
+		if cfg.CompressLevel == 0 {
+			cfg.CompressLevel = gzip.DefaultCompression
+		}
+		if cfg.CompressLevel < gzip.HuffmanOnly || cfg.CompressLevel > gzip.BestCompression {
+			return nil, fmt.Errorf("invalid gzip compression level %d", cfg.CompressLevel)
+		}
+		if cfg.CompressExtension == "" {
+			cfg.CompressExtension = ".gz"
+		}
+	}
+
+	r := &RotatingFile{
+		cfg: cfg,
+		now: time.Now,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p to the file, rotating it first when p would push it past MaxSize
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.cfg.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.cfg.MaxSize {
+		// A failed rotation leaves the original file open, logging goes on there and the
+		// rotation is tried again on the next write
+		if err := r.rotate(); err != nil && r.file == nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Sync flushes the file to disk
+func (r *RotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the file and waits for pending compression and pruning to finish
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+		r.file = nil
+	}
+	r.mu.Unlock()
+	r.mill.Wait()
+	return err
+}
+
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.cfg.Filename), logDirMode); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(r.cfg.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, logFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate renames the file to a backup and opens a new one. When closing or renaming the file
+// fails, the original file is reopened for appending.
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		r.file = nil
+		return errors.Join(fmt.Errorf("failed to close log file: %w", err), r.open())
+	}
+	r.file = nil
+
+	backup := r.backupName(r.now())
+	if err := os.Rename(r.cfg.Filename, backup); err != nil {
+		return errors.Join(fmt.Errorf("failed to rename log file: %w", err), r.open())
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	r.mill.Add(1)
+	go func() {
+		defer r.mill.Done()
+		r.millMu.Lock()
+		defer r.millMu.Unlock()
+		// Failures are not reported: the log file itself is the place errors would go
+		if r.cfg.Compress {
+			_ = compressFile(backup, backup+r.cfg.CompressExtension, r.cfg.CompressLevel)
+		}
+		_ = r.prune()
+	}()
+	return nil
+}
+
+// backupName returns <dir>/<name>-<timestamp><ext> for the log file <dir>/<name><ext>. When a
+// backup of the same millisecond exists, compressed or not, a counter is appended to the
+// timestamp so it is not replaced.
+func (r *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := r.nameParts()
+	stamp := t.UTC().Format(backupTimeFormat)
+	backup := filepath.Join(dir, fmt.Sprintf("%s-%s%s", prefix, stamp, ext))
+	for n := 1; r.backupExists(backup); n++ {
+		backup = filepath.Join(dir, fmt.Sprintf("%s-%s-%d%s", prefix, stamp, n, ext))
+	}
+	return backup
+}
+
+func (r *RotatingFile) backupExists(backup string) bool {
+	for _, path := range []string{backup, backup + r.cfg.CompressExtension} {
+		if _, err := os.Lstat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RotatingFile) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(r.cfg.Filename)
+	base := filepath.Base(r.cfg.Filename)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext), ext
+}
+
+type backupFile struct {
+	path      string
+	timestamp time.Time
+	// counter orders the backups of the same timestamp
+	counter int
+}
+
+// backups lists the rotated files, newest first
+func (r *RotatingFile) backups() ([]backupFile, error) {
+	dir, prefix, ext := r.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix+"-") {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, prefix+"-")
+		if r.cfg.CompressExtension != "" {
+			stamp = strings.TrimSuffix(stamp, r.cfg.CompressExtension)
+		}
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		timestamp, counter, ok := parseBackupStamp(strings.TrimSuffix(stamp, ext))
+		if !ok {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), timestamp: timestamp, counter: counter})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].timestamp.Equal(backups[j].timestamp) {
+			return backups[i].timestamp.After(backups[j].timestamp)
+		}
+		return backups[i].counter > backups[j].counter
+	})
+	return backups, nil
+}
+
+// parseBackupStamp parses <timestamp> or <timestamp>-<n> of a backup name
+func parseBackupStamp(stamp string) (timestamp time.Time, counter int, ok bool) {
+	if timestamp, err := time.Parse(backupTimeFormat, stamp); err == nil {
+		return timestamp, 0, true
+	}
+	i := strings.LastIndex(stamp, "-")
+	if i < 0 {
+		return time.Time{}, 0, false
+	}
+	counter, err := strconv.Atoi(stamp[i+1:])
+	if err != nil || counter < 1 {
+		return time.Time{}, 0, false
+	}
+	timestamp, err = time.Parse(backupTimeFormat, stamp[:i])
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return timestamp, counter, true
+}
+
+// prune removes the backups beyond MaxBackups and those older than MaxAge
+func (r *RotatingFile) prune() error {
+	backups, err := r.backups()
+	if err != nil {
+		return err
+	}
+	cutoff := r.now().Add(-r.cfg.MaxAge)
+	for i, backup := range backups {
+		tooMany := r.cfg.MaxBackups > 0 && i >= r.cfg.MaxBackups
+		tooOld := r.cfg.MaxAge > 0 && backup.timestamp.Before(cutoff)
+		if tooMany || tooOld {
+			if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compressFile gzips src into dst and removes src once dst is complete
+func compressFile(src, dst string, level int) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, logFileMode)
+	if err != nil {
+		return err
+	}
+	gz, err := gzip.NewWriterLevel(out, level)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
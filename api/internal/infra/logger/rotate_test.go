@@ -0,0 +1,266 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listDir(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ============================================================================
+// RotatingFile Tests
+// ============================================================================
+
+func TestRotatingFile(t *testing.T) {
+	t.Run("CreatesFileWithRestrictedPermissions", func(t *testing.T) {
+		// Arrange
+		filename := filepath.Join(t.TempDir(), "logs", "api.log")
+
+		// Act
+		file, err := NewRotatingFile(RotationConfig{Filename: filename})
+		require.NoError(t, err)
+		defer file.Close()
+
+		// Assert
+		info, err := os.Stat(filename)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	})
+
+	t.Run("FailsWhenFileCannotBeOpened", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		blocker := filepath.Join(dir, "not-a-dir")
+		require.NoError(t, os.WriteFile(blocker, nil, 0600))
+
+		// Act
+		file, err := NewRotatingFile(RotationConfig{Filename: filepath.Join(blocker, "api.log")})
+
+		// Assert
+		assert.Nil(t, file)
+		assert.Error(t, err)
+	})
+
+	t.Run("RotatesAtMaxSize", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "api.log")
+		file, err := NewRotatingFile(RotationConfig{Filename: filename, MaxSize: 10})
+		require.NoError(t, err)
+		now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		file.now = func() time.Time { return now }
+
+		// Act
+		_, err = file.Write([]byte("0123456789"))
+		require.NoError(t, err)
+		_, err = file.Write([]byte("abc"))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// Assert
+		assert.Equal(t, []string{"api-2026-01-02T03-04-05.000.log", "api.log"}, listDir(t, dir))
+		current, _ := os.ReadFile(filename)
+		assert.Equal(t, "abc", string(current))
+		backup, _ := os.ReadFile(filepath.Join(dir, "api-2026-01-02T03-04-05.000.log"))
+		assert.Equal(t, "0123456789", string(backup))
+	})
+
+	t.Run("KeepsWritingWhenRenameFails", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "api.log")
+		file, err := NewRotatingFile(RotationConfig{Filename: filename, MaxSize: 10})
+		require.NoError(t, err)
+		_, err = file.Write([]byte("0123456789"))
+		require.NoError(t, err)
+		// The log file is gone from under the writer, so there is nothing to rename
+		require.NoError(t, os.Remove(filename))
+
+		// Act
+		_, rotateErr := file.Write([]byte("abc"))
+		_, laterErr := file.Write([]byte("def"))
+		require.NoError(t, file.Close())
+
+		// Assert
+		assert.NoError(t, rotateErr)
+		assert.NoError(t, laterErr)
+		assert.Equal(t, []string{"api.log"}, listDir(t, dir))
+		current, _ := os.ReadFile(filename)
+		assert.Equal(t, "abcdef", string(current))
+	})
+
+	t.Run("KeepsBackupsOfTheSameMillisecond", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		file, err := NewRotatingFile(RotationConfig{
+			Filename:          filepath.Join(dir, "api.log"),
+			MaxSize:           4,
+			MaxBackups:        2,
+			Compress:          true,
+			CompressExtension: ".gz",
+		})
+		require.NoError(t, err)
+		file.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+		// Act
+		for _, line := range []string{"one!", "two!", "three", "four"} {
+			file.Write([]byte(line))
+			file.mill.Wait()
+		}
+		require.NoError(t, file.Close())
+
+		// Assert
+		// The newest two of the three backups are kept
+		assert.Equal(t, []string{
+			"api-2026-01-02T03-04-05.000-1.log.gz",
+			"api-2026-01-02T03-04-05.000-2.log.gz",
+			"api.log",
+		}, listDir(t, dir))
+		for name, want := range map[string]string{
+			"api-2026-01-02T03-04-05.000-1.log.gz": "two!",
+			"api-2026-01-02T03-04-05.000-2.log.gz": "three",
+		} {
+			compressed, err := os.Open(filepath.Join(dir, name))
+			require.NoError(t, err)
+			reader, err := gzip.NewReader(compressed)
+			require.NoError(t, err)
+			content, _ := io.ReadAll(reader)
+			compressed.Close()
+			assert.Equal(t, want, string(content), name)
+		}
+	})
+
+	t.Run("CompressesBackups", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		file, err := NewRotatingFile(RotationConfig{
+			Filename:          filepath.Join(dir, "api.log"),
+			MaxSize:           4,
+			Compress:          true,
+			CompressLevel:     gzip.BestCompression,
+			CompressExtension: ".gzip",
+		})
+		require.NoError(t, err)
+		file.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+		// Act
+		file.Write([]byte("old!"))
+		file.Write([]byte("new"))
+		require.NoError(t, file.Close())
+
+		// Assert
+		assert.Equal(t, []string{"api-2026-01-02T03-04-05.000.log.gzip", "api.log"}, listDir(t, dir))
+		compressed, err := os.Open(filepath.Join(dir, "api-2026-01-02T03-04-05.000.log.gzip"))
+		require.NoError(t, err)
+		defer compressed.Close()
+		reader, err := gzip.NewReader(compressed)
+		require.NoError(t, err)
+		content, _ := io.ReadAll(reader)
+		assert.Equal(t, "old!", string(content))
+	})
+
+	t.Run("PrunesBackups", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		file, err := NewRotatingFile(RotationConfig{
+			Filename:   filepath.Join(dir, "api.log"),
+			MaxSize:    1,
+			MaxBackups: 2,
+			MaxAge:     48 * time.Hour,
+		})
+		require.NoError(t, err)
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		file.now = func() time.Time { return now }
+
+		// Act
+		for _, day := range []int{1, 5, 6, 7} {
+			now = time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)
+			file.Write([]byte("x"))
+			file.mill.Wait()
+		}
+		now = time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+		file.Write([]byte("x"))
+		require.NoError(t, file.Close())
+
+		// Assert
+		assert.Equal(t, []string{
+			"api-2026-01-07T00-00-00.000.log",
+			"api-2026-01-08T00-00-00.000.log",
+			"api.log",
+		}, listDir(t, dir))
+	})
+}
+
+// ============================================================================
+// Rotation Config Tests
+// ============================================================================
+
+func TestParseSize(t *testing.T) {
+	for input, expected := range map[string]int64{
+		"":      0,
+		"100":   100,
+		"512KB": 512 << 10,
+		"100MB": 100 << 20,
+		"1gb":   1 << 30,
+		"10 B":  10,
+	} {
+		t.Run(input, func(t *testing.T) {
+			// Act
+			size, err := parseSize(input)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, expected, size)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		// Act
+		_, err := parseSize("lots")
+
+		// Assert
+		assert.EqualError(t, err, `invalid log max_size "lots"`)
+	})
+}
+
+func TestParseAge(t *testing.T) {
+	for input, expected := range map[string]time.Duration{
+		"":    0,
+		"30d": 30 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+	} {
+		t.Run(input, func(t *testing.T) {
+			// Act
+			age, err := parseAge(input)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, expected, age)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		// Act
+		_, err := parseAge("a month")
+
+		// Assert
+		assert.EqualError(t, err, `invalid log max_age "a month"`)
+	})
+}
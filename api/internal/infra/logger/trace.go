@@ -0,0 +1,21 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// WithTraceContext returns logger annotated with the trace and span IDs of the span in ctx,
+// so log lines can be matched to the trace of the request that produced them
+func WithTraceContext(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return logger
+	}
+	return logger.With(
+		zap.String("trace_id", spanContext.TraceID().String()),
+		zap.String("span_id", spanContext.SpanID().String()),
+	)
+}
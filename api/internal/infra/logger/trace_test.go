@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// ============================================================================
+// WithTraceContext Tests
+// ============================================================================
+
+func TestWithTraceContext(t *testing.T) {
+	t.Run("AddsTraceFields", func(t *testing.T) {
+		// Arrange
+		core, logs := observer.New(zap.InfoLevel)
+		ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "span")
+		defer span.End()
+
+		// Act
+		WithTraceContext(ctx, zap.New(core)).Info("message")
+
+		// Assert
+		fields := logs.All()[0].ContextMap()
+		assert.Equal(t, span.SpanContext().TraceID().String(), fields["trace_id"])
+		assert.Equal(t, span.SpanContext().SpanID().String(), fields["span_id"])
+	})
+
+	t.Run("NoSpan", func(t *testing.T) {
+		// Arrange
+		core, logs := observer.New(zap.InfoLevel)
+
+		// Act
+		WithTraceContext(context.Background(), zap.New(core)).Info("message")
+
+		// Assert
+		assert.Empty(t, logs.All()[0].ContextMap())
+	})
+}
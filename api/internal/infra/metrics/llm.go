@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+)
+
+const (
+	llmOperationEmbedding = "embedding"
+	llmOperationChat      = "chat"
+	llmOperationCaption   = "caption"
+)
+
+// modelNamer is implemented by LLM clients that know which models they call
+type modelNamer interface {
+	EmbeddingModel() string
+	ChatModel() string
+}
+
+type instrumentedLLMClient struct {
+	next           llm.ClientInterface
+	metrics        *Metrics
+	embeddingModel string
+	chatModel      string
+}
+
+// InstrumentLLMClient wraps client so every call records its latency, outcome and token usage by model
+func InstrumentLLMClient(client llm.ClientInterface, metrics *Metrics) llm.ClientInterface {
+	instrumented := &instrumentedLLMClient{
+		next:           client,
+		metrics:        metrics,
+		embeddingModel: "unknown",
+		chatModel:      "unknown",
+	}
+	if namer, ok := client.(modelNamer); ok {
+		instrumented.embeddingModel = namer.EmbeddingModel()
+		instrumented.chatModel = namer.ChatModel()
+	}
+	return instrumented
+}
+
+func (c *instrumentedLLMClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	start := time.Now()
+	embedding, err := c.next.GetEmbedding(c.withUsage(ctx, llmOperationEmbedding), text)
+	c.observe(llmOperationEmbedding, c.embeddingModel, start, err)
+	return embedding, err
+}
+
+func (c *instrumentedLLMClient) Chat(ctx context.Context, messages []llm.Message) (string, error) {
+	start := time.Now()
+	response, err := c.next.Chat(c.withUsage(ctx, llmOperationChat), messages)
+	c.observe(llmOperationChat, c.chatModel, start, err)
+	return response, err
+}
+
+type instrumentedCaptioner struct {
+	next        llm.CaptionerInterface
+	metrics     *Metrics
+	visionModel string
+}
+
+// InstrumentCaptioner wraps captioner so every caption records its latency, outcome and token usage by model
+func InstrumentCaptioner(captioner llm.CaptionerInterface, model string, metrics *Metrics) llm.CaptionerInterface {
+	return &instrumentedCaptioner{
+		next:        captioner,
+		metrics:     metrics,
+		visionModel: model,
+	}
+}
+
+func (c *instrumentedCaptioner) CaptionImage(ctx context.Context, imageURL, prompt string) (string, error) {
+	start := time.Now()
+	caption, err := c.next.CaptionImage(c.metrics.withLLMUsage(ctx, llmOperationCaption), imageURL, prompt)
+	c.metrics.observeLLMCall(llmOperationCaption, c.visionModel, start, err)
+	return caption, err
+}
+
+func (c *instrumentedLLMClient) withUsage(ctx context.Context, operation string) context.Context {
+	return c.metrics.withLLMUsage(ctx, operation)
+}
+
+func (c *instrumentedLLMClient) observe(operation, model string, start time.Time, err error) {
+	c.metrics.observeLLMCall(operation, model, start, err)
+}
+
+// withLLMUsage returns ctx with a usage callback that records the tokens of the LLM call
+func (m *Metrics) withLLMUsage(ctx context.Context, operation string) context.Context {
+	return llm.WithUsageCallback(ctx, func(usage llm.Usage) {
+		m.llmTokens.WithLabelValues(operation, usage.Model, "prompt").Add(float64(usage.PromptTokens))
+		m.llmTokens.WithLabelValues(operation, usage.Model, "completion").Add(float64(usage.CompletionTokens))
+	})
+}
+
+func (m *Metrics) observeLLMCall(operation, model string, start time.Time, err error) {
+	m.llmRequestDuration.WithLabelValues(operation, model).Observe(time.Since(start).Seconds())
+	m.llmRequests.WithLabelValues(operation, model, llmStatus(err)).Inc()
+}
+
+// llmStatus returns the HTTP status code of a call, or "error" when no usable response was received
+func llmStatus(err error) string {
+	if err == nil {
+		return "200"
+	}
+	var apiErr *llm.APIError
+	if errors.As(err, &apiErr) {
+		return strconv.Itoa(apiErr.StatusCode)
+	}
+	return "error"
+}
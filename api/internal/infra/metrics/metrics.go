@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "reddit_content_analyzer"
+
+// maxSubredditLabels caps the subreddits the post counters are labeled with, the posts of the
+// subreddits seen after them are counted under otherSubreddit
+const maxSubredditLabels = 500
+
+const otherSubreddit = "other"
+
+// Metrics holds the Prometheus collectors of the application. Every instance owns its
+// registry, so several containers in the same process do not clash.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+
+	redditRequests        *prometheus.CounterVec
+	redditRequestDuration *prometheus.HistogramVec
+
+	llmRequests        *prometheus.CounterVec
+	llmRequestDuration *prometheus.HistogramVec
+	llmTokens          *prometheus.CounterVec
+
+	cacheLookups *prometheus.CounterVec
+
+	postsEvaluated *prometheus.CounterVec
+	postsRelevant  *prometheus.CounterVec
+
+	mu         sync.Mutex
+	subreddits map[string]bool
+}
+
+// New creates the application metrics along with the Go runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route and status code",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by method and route",
+			// Searches embed and summarize every post, so they run from seconds to minutes
+			Buckets: []float64{0.005, 0.05, 0.25, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}, []string{"method", "route"}),
+		redditRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reddit_requests_total",
+			Help:      "Reddit API calls, by endpoint type and status code",
+		}, []string{"endpoint", "status"}),
+		redditRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "reddit_request_duration_seconds",
+			Help:      "Reddit API call latency, by endpoint type",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		llmRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "llm_requests_total",
+			Help:      "LLM API calls, by operation, model and outcome",
+		}, []string{"operation", "model", "status"}),
+		llmRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "llm_request_duration_seconds",
+			Help:      "LLM API call latency, by operation and model",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"operation", "model"}),
+		llmTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "llm_tokens_total",
+			Help:      "Tokens reported by the LLM API, by operation, model and token type",
+		}, []string{"operation", "model", "type"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Cache lookups, by cache name and result (hit or miss)",
+		}, []string{"cache", "result"}),
+		postsEvaluated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "posts_evaluated_total",
+			Help:      "Posts scored for relevance, by subreddit, empty outside Reddit, and source kind (subreddit, user, domain, hackernews, feed or lemmy)",
+		}, []string{"subreddit", "source_kind"}),
+		postsRelevant: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "posts_relevant_total",
+			Help:      "Posts scored at or above the relevance threshold, by subreddit and source kind",
+		}, []string{"subreddit", "source_kind"}),
+		subreddits: make(map[string]bool),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.redditRequests,
+		m.redditRequestDuration,
+		m.llmRequests,
+		m.llmRequestDuration,
+		m.llmTokens,
+		m.cacheLookups,
+		m.postsEvaluated,
+		m.postsRelevant,
+	)
+	return m
+}
+
+// Registry returns the registry the metrics are registered with
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler returns the HTTP handler exposing the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveCacheLookup records a lookup in the named cache
+func (m *Metrics) ObserveCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+// subredditLabel returns the label of the subreddit, lowercased, or otherSubreddit once
+// maxSubredditLabels other subreddits have been labeled
+func (m *Metrics) subredditLabel(subreddit string) string {
+	subreddit = strings.ToLower(subreddit)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.subreddits[subreddit] {
+		if len(m.subreddits) >= maxSubredditLabels {
+			return otherSubreddit
+		}
+		m.subreddits[subreddit] = true
+	}
+	return subreddit
+}
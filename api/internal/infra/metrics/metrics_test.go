@@ -0,0 +1,222 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
+	mock_reddit "github.com/ReyOrtiz/reddit-content-analyzer/mocks/reddit"
+	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
+)
+
+// ============================================================================
+// Middleware Tests
+// ============================================================================
+
+func TestMetrics_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("LabelsByRoutePattern", func(t *testing.T) {
+		// Arrange
+		m := New()
+		router := gin.New()
+		router.Use(m.Middleware())
+		router.GET("/items/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+		// Act
+		for _, path := range []string{"/items/1", "/items/2", "/missing"} {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+		}
+
+		// Assert
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/items/:id", "204")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "unmatched", "404")))
+		assert.Equal(t, 2, testutil.CollectAndCount(m.httpRequestDuration))
+	})
+
+	t.Run("ExposesMetrics", func(t *testing.T) {
+		// Arrange
+		m := New()
+		m.ObserveCacheLookup("readiness", true)
+		m.ObserveCacheLookup("readiness", false)
+		w := httptest.NewRecorder()
+
+		// Act
+		m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `reddit_content_analyzer_cache_lookups_total{cache="readiness",result="hit"} 1`)
+		assert.Contains(t, w.Body.String(), `reddit_content_analyzer_cache_lookups_total{cache="readiness",result="miss"} 1`)
+		assert.Contains(t, w.Body.String(), "go_goroutines")
+	})
+}
+
+// ============================================================================
+// Reddit Client Tests
+// ============================================================================
+
+func TestInstrumentRedditClient(t *testing.T) {
+	t.Run("RecordsStatusByEndpoint", func(t *testing.T) {
+		// Arrange
+		m := New()
+		mockClient := mock_reddit.NewMockClientInterface(t)
+		mockClient.EXPECT().GetPosts(mock.Anything, "golang", 10, reddit.ListingOptions{}).Return(&reddit.RedditResponse{}, nil)
+		mockClient.EXPECT().SearchPosts(mock.Anything, "golang", "generics", 10, reddit.SearchOptions{}).Return(nil, &reddit.APIError{StatusCode: http.StatusTooManyRequests})
+		mockClient.EXPECT().Ping(context.Background()).Return(errors.New("connection refused"))
+		client := InstrumentRedditClient(mockClient, m)
+
+		// Act
+		_, listingErr := client.GetPosts(context.Background(), "golang", 10, reddit.ListingOptions{})
+		_, searchErr := client.SearchPosts(context.Background(), "golang", "generics", 10, reddit.SearchOptions{})
+		pingErr := client.Ping(context.Background())
+
+		// Assert
+		assert.NoError(t, listingErr)
+		assert.Error(t, searchErr)
+		assert.Error(t, pingErr)
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.redditRequests.WithLabelValues("listing", "200")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.redditRequests.WithLabelValues("search", "429")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.redditRequests.WithLabelValues("ping", "error")))
+		assert.Equal(t, 3, testutil.CollectAndCount(m.redditRequestDuration))
+	})
+}
+
+// ============================================================================
+// LLM Client Tests
+// ============================================================================
+
+func TestInstrumentLLMClient(t *testing.T) {
+	t.Run("RecordsTokensByModel", func(t *testing.T) {
+		// Arrange
+		m := New()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/embeddings":
+				w.Write([]byte(`{"data":[{"embedding":[0.1,0.2],"index":0}],"model":"embed-model","usage":{"prompt_tokens":7,"total_tokens":7}}`))
+			case "/chat/completions":
+				w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}],"model":"chat-model","usage":{"prompt_tokens":20,"completion_tokens":5,"total_tokens":25}}`))
+			}
+		}))
+		defer server.Close()
+		client := InstrumentLLMClient(llm.NewClient(server.URL, "embed-model", "chat-model", server.Client(), zap.NewNop()), m)
+
+		// Act
+		_, embeddingErr := client.GetEmbedding(context.Background(), "text")
+		_, chatErr := client.Chat(context.Background(), []llm.Message{{Role: "user", Content: "hi"}})
+
+		// Assert
+		assert.NoError(t, embeddingErr)
+		assert.NoError(t, chatErr)
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.llmRequests.WithLabelValues("embedding", "embed-model", "200")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.llmRequests.WithLabelValues("chat", "chat-model", "200")))
+		assert.Equal(t, 7.0, testutil.ToFloat64(m.llmTokens.WithLabelValues("embedding", "embed-model", "prompt")))
+		assert.Equal(t, 20.0, testutil.ToFloat64(m.llmTokens.WithLabelValues("chat", "chat-model", "prompt")))
+		assert.Equal(t, 5.0, testutil.ToFloat64(m.llmTokens.WithLabelValues("chat", "chat-model", "completion")))
+	})
+
+	t.Run("UnknownModel", func(t *testing.T) {
+		// Arrange
+		m := New()
+		mockClient := mock_llm.NewMockClientInterface(t)
+		mockClient.EXPECT().GetEmbedding(mock.Anything, "text").Return(nil, &llm.APIError{StatusCode: http.StatusInternalServerError})
+		client := InstrumentLLMClient(mockClient, m)
+
+		// Act
+		_, err := client.GetEmbedding(context.Background(), "text")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.llmRequests.WithLabelValues("embedding", "unknown", "500")))
+	})
+}
+
+func TestInstrumentCaptioner(t *testing.T) {
+	// Arrange
+	m := New()
+	mockCaptioner := mock_llm.NewMockCaptionerInterface(t)
+	mockCaptioner.EXPECT().CaptionImage(mock.Anything, "https://i.redd.it/c.png", "Describe it").Return("A chart", nil)
+	captioner := InstrumentCaptioner(mockCaptioner, "vision-model", m)
+
+	// Act
+	_, err := captioner.CaptionImage(context.Background(), "https://i.redd.it/c.png", "Describe it")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.llmRequests.WithLabelValues("caption", "vision-model", "200")))
+}
+
+// ============================================================================
+// Relevance Service Tests
+// ============================================================================
+
+func TestInstrumentRelevanceService(t *testing.T) {
+	t.Run("CountsPostsBySubreddit", func(t *testing.T) {
+		// Arrange
+		m := New()
+		ctx := context.Background()
+		request := contracts.RelevanceRequestDto{Topic: "go"}
+		mockService := mock_services.NewMockRelevanceService(t)
+		mockService.EXPECT().GetRelevantPosts(ctx, request).Return(contracts.RelevanceResponseDto{
+			Posts: []contracts.SubRedditPostDto{
+				{SubredditName: "golang", Source: "r/golang", IsRelevant: true},
+				{SubredditName: "golang", Source: "r/golang", IsRelevant: false},
+				{SubredditName: "Programming", Source: "r/programming", IsRelevant: false},
+				{SubredditName: "golang", Source: "user:gopher", IsRelevant: true},
+				{SubredditName: "lobsters", Source: "feed:https://lobste.rs/rss", IsRelevant: false},
+			},
+		}, nil)
+		service := InstrumentRelevanceService(mockService, m)
+
+		// Act
+		_, err := service.GetRelevantPosts(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.postsEvaluated.WithLabelValues("golang", "subreddit")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.postsRelevant.WithLabelValues("golang", "subreddit")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.postsEvaluated.WithLabelValues("programming", "subreddit")))
+		assert.Equal(t, 0.0, testutil.ToFloat64(m.postsRelevant.WithLabelValues("programming", "subreddit")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.postsRelevant.WithLabelValues("golang", "user")))
+		// Feeds are counted by kind, not by the name of the feed
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.postsEvaluated.WithLabelValues("", "feed")))
+	})
+
+	t.Run("CapsSubredditLabels", func(t *testing.T) {
+		// Arrange
+		m := New()
+		ctx := context.Background()
+		request := contracts.RelevanceRequestDto{Topic: "go"}
+		posts := make([]contracts.SubRedditPostDto, 0, maxSubredditLabels+2)
+		for i := range maxSubredditLabels + 2 {
+			subreddit := fmt.Sprintf("sub%d", i)
+			posts = append(posts, contracts.SubRedditPostDto{SubredditName: subreddit, Source: "r/" + subreddit})
+		}
+		mockService := mock_services.NewMockRelevanceService(t)
+		mockService.EXPECT().GetRelevantPosts(ctx, request).Return(contracts.RelevanceResponseDto{Posts: posts}, nil)
+		service := InstrumentRelevanceService(mockService, m)
+
+		// Act
+		_, err := service.GetRelevantPosts(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, maxSubredditLabels+1, testutil.CollectAndCount(m.postsEvaluated))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.postsEvaluated.WithLabelValues("sub0", "subreddit")))
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.postsEvaluated.WithLabelValues(otherSubreddit, "subreddit")))
+	})
+}
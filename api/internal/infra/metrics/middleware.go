@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware records the count and latency of every HTTP request. Requests are labelled
+// with the route pattern rather than the raw path to keep the label cardinality bounded.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		m.httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.httpRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
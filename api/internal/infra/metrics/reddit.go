@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+)
+
+const (
+	redditEndpointListing = "listing"
+	redditEndpointSearch  = "search"
+	redditEndpointPing    = "ping"
+
+	redditEndpointSubredditSearch       = "subreddit_search"
+	redditEndpointSubredditAutocomplete = "subreddit_autocomplete"
+	redditEndpointUserListing           = "user_listing"
+	redditEndpointDomainListing         = "domain_listing"
+	redditEndpointComments              = "comments"
+)
+
+type instrumentedRedditClient struct {
+	next    reddit.ClientInterface
+	metrics *Metrics
+}
+
+// InstrumentRedditClient wraps client so every call records its latency and status code
+func InstrumentRedditClient(client reddit.ClientInterface, metrics *Metrics) reddit.ClientInterface {
+	return &instrumentedRedditClient{
+		next:    client,
+		metrics: metrics,
+	}
+}
+
+func (c *instrumentedRedditClient) GetPosts(ctx context.Context, subreddit string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	start := time.Now()
+	response, err := c.next.GetPosts(ctx, subreddit, limit, options)
+	c.observe(redditEndpointListing, start, err)
+	return response, err
+}
+
+func (c *instrumentedRedditClient) SearchPosts(ctx context.Context, subreddit string, query string, limit int, options reddit.SearchOptions) (*reddit.RedditResponse, error) {
+	start := time.Now()
+	response, err := c.next.SearchPosts(ctx, subreddit, query, limit, options)
+	c.observe(redditEndpointSearch, start, err)
+	return response, err
+}
+
+func (c *instrumentedRedditClient) GetUserPosts(ctx context.Context, username string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	start := time.Now()
+	response, err := c.next.GetUserPosts(ctx, username, limit, options)
+	c.observe(redditEndpointUserListing, start, err)
+	return response, err
+}
+
+func (c *instrumentedRedditClient) GetDomainPosts(ctx context.Context, domain string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	start := time.Now()
+	response, err := c.next.GetDomainPosts(ctx, domain, limit, options)
+	c.observe(redditEndpointDomainListing, start, err)
+	return response, err
+}
+
+func (c *instrumentedRedditClient) SearchSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error) {
+	start := time.Now()
+	listing, err := c.next.SearchSubreddits(ctx, query, limit)
+	c.observe(redditEndpointSubredditSearch, start, err)
+	return listing, err
+}
+
+func (c *instrumentedRedditClient) AutocompleteSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error) {
+	start := time.Now()
+	listing, err := c.next.AutocompleteSubreddits(ctx, query, limit)
+	c.observe(redditEndpointSubredditAutocomplete, start, err)
+	return listing, err
+}
+
+func (c *instrumentedRedditClient) GetComments(ctx context.Context, postID string, limit int) (*reddit.CommentListing, error) {
+	start := time.Now()
+	comments, err := c.next.GetComments(ctx, postID, limit)
+	c.observe(redditEndpointComments, start, err)
+	return comments, err
+}
+
+func (c *instrumentedRedditClient) Ping(ctx context.Context) error {
+	start := time.Now()
+	err := c.next.Ping(ctx)
+	c.observe(redditEndpointPing, start, err)
+	return err
+}
+
+func (c *instrumentedRedditClient) observe(endpoint string, start time.Time, err error) {
+	c.metrics.redditRequestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	c.metrics.redditRequests.WithLabelValues(endpoint, redditStatus(err)).Inc()
+}
+
+// redditStatus returns the HTTP status code of a call, or "error" when no response was received
+func redditStatus(err error) string {
+	if err == nil {
+		return "200"
+	}
+	var apiErr *reddit.APIError
+	if errors.As(err, &apiErr) {
+		return strconv.Itoa(apiErr.StatusCode)
+	}
+	return "error"
+}
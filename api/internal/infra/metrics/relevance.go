@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/services"
+)
+
+type instrumentedRelevanceService struct {
+	next    services.RelevanceService
+	metrics *Metrics
+}
+
+// InstrumentRelevanceService wraps service so the posts it evaluates are counted per subreddit and source kind
+func InstrumentRelevanceService(service services.RelevanceService, metrics *Metrics) services.RelevanceService {
+	return &instrumentedRelevanceService{
+		next:    service,
+		metrics: metrics,
+	}
+}
+
+func (s *instrumentedRelevanceService) GetRelevantPosts(ctx context.Context, request contracts.RelevanceRequestDto) (contracts.RelevanceResponseDto, error) {
+	response, err := s.next.GetRelevantPosts(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	// Sources outside Reddit are counted by kind alone, their names are URLs and instances the
+	// request chooses, and the subreddits are capped
+	for _, post := range response.Posts {
+		kind := services.SourceKind(post.Source)
+		var subreddit string
+		switch kind {
+		case "subreddit", "user", "domain":
+			subreddit = s.metrics.subredditLabel(post.SubredditName)
+		}
+		s.metrics.postsEvaluated.WithLabelValues(subreddit, kind).Inc()
+		if post.IsRelevant {
+			s.metrics.postsRelevant.WithLabelValues(subreddit, kind).Inc()
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,67 @@
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned for connections to loopback, private, link-local or otherwise
+// internal addresses
+var ErrPrivateAddress = errors.New("connection to a private address is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range, 100.64.0.0/10, that net.IP does not consider private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Guard refuses connections to private addresses. The check runs on the address being dialed,
+// after DNS resolution, so it holds for every redirect and against DNS rebinding.
+type Guard struct {
+	// Allowed are the private networks that may still be dialed, meant for tests and for
+	// internal hosts an operator trusts
+	Allowed []netip.Prefix
+}
+
+// IsPrivate reports whether addr is a loopback, private, link-local, multicast, unspecified
+// or shared address, none of which a URL taken from user content should reach
+func IsPrivate(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr)
+}
+
+// Control is a net.Dialer Control function returning ErrPrivateAddress for private addresses
+// that are not allowed
+func (g Guard) Control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid dial address %q: %w", address, ErrPrivateAddress)
+	}
+	addr := addrPort.Addr().Unmap()
+	if !IsPrivate(addr) {
+		return nil
+	}
+	for _, allowed := range g.Allowed {
+		if allowed.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w", addr, ErrPrivateAddress)
+}
+
+// Transport returns an HTTP transport like http.DefaultTransport whose dialer enforces the guard.
+// It connects directly, without the proxy of the environment, since only the address of the
+// proxy could be checked.
+func (g Guard) Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.Control,
+	}).DialContext
+	return transport
+}
@@ -0,0 +1,91 @@
+package netguard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// Guard Tests
+// ============================================================================
+
+func TestIsPrivate(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fd00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"2606:4700:4700::1111", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			// Act
+			private := IsPrivate(netip.MustParseAddr(tt.address))
+
+			// Assert
+			assert.Equal(t, tt.expected, private)
+		})
+	}
+}
+
+func TestGuard_Control(t *testing.T) {
+	t.Run("RefusesPrivateAddress", func(t *testing.T) {
+		// Act
+		err := Guard{}.Control("tcp4", "169.254.169.254:80", nil)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPrivateAddress)
+	})
+
+	t.Run("AllowsPublicAddress", func(t *testing.T) {
+		// Act
+		err := Guard{}.Control("tcp4", "93.184.215.14:443", nil)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("AllowsAllowedNetwork", func(t *testing.T) {
+		// Arrange
+		guard := Guard{Allowed: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+
+		// Act
+		allowedErr := guard.Control("tcp4", "10.1.2.3:80", nil)
+		otherErr := guard.Control("tcp4", "192.168.1.1:80", nil)
+
+		// Assert
+		assert.NoError(t, allowedErr)
+		assert.ErrorIs(t, otherErr, ErrPrivateAddress)
+	})
+}
+
+func TestGuard_Transport(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("internal"))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: Guard{}.Transport()}
+
+	// Act
+	_, err := client.Get(server.URL)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+}
@@ -0,0 +1,43 @@
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// NewHTTPClient returns an http.Client that records or replays traffic according to the
+// recorder.mode and recorder.cassette_dir settings. Each named client uses its own cassette,
+// <cassette_dir>/<name>.json. Requests go through next, http.DefaultTransport when nil, unless
+// they are replayed. The returned closer saves the recorded cassette and must be called once the
+// client is no longer used.
+func NewHTTPClient(cfg *viper.Viper, name string, timeout time.Duration, next http.RoundTripper) (*http.Client, io.Closer, error) {
+	mode, err := ParseMode(cfg.GetString("recorder.mode"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if mode == ModeOff {
+		return &http.Client{Timeout: timeout, Transport: next}, nopCloser{}, nil
+	}
+
+	cassetteDir := cfg.GetString("recorder.cassette_dir")
+	if cassetteDir == "" {
+		cassetteDir = "cassettes"
+	}
+	transport, err := NewTransport(filepath.Join(cassetteDir, name+".json"), mode, next)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}, transport, nil
+}
+
+// nopCloser is the closer of clients that do not record
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
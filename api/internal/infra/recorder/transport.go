@@ -0,0 +1,209 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Mode controls whether a Transport records, replays or passes traffic through untouched
+type Mode string
+
+const (
+	ModeOff    Mode = "off"
+	ModeRecord Mode = "record"
+	ModeReplay Mode = "replay"
+)
+
+// ParseMode converts a config value into a Mode, an empty value means ModeOff
+func ParseMode(value string) (Mode, error) {
+	switch Mode(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ModeOff:
+		return ModeOff, nil
+	case ModeRecord:
+		return ModeRecord, nil
+	case ModeReplay:
+		return ModeReplay, nil
+	default:
+		return "", fmt.Errorf("unknown recorder mode %q (expected off, record or replay)", value)
+	}
+}
+
+// Transport is an http.RoundTripper that records interactions to a cassette or replays them from it.
+// Requests are matched on method, URL and body. Identical requests are replayed in recorded order,
+// and the last match is reused once they are exhausted.
+type Transport struct {
+	mode     Mode
+	path     string
+	next     http.RoundTripper
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed map[int]bool
+	// recorded is set once an interaction was recorded and not yet saved
+	recorded bool
+}
+
+// NewTransport creates a transport for the cassette at path. In record mode requests are sent
+// through next (http.DefaultTransport when nil) and appended to the existing cassette, which
+// Close saves; in replay mode the network is never used.
+func NewTransport(path string, mode Mode, next http.RoundTripper) (*Transport, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	cassette := &Cassette{Interactions: make([]Interaction, 0)}
+	if mode == ModeReplay || mode == ModeRecord {
+		loaded, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		cassette = loaded
+	}
+
+	return &Transport{
+		mode:     mode,
+		path:     path,
+		next:     next,
+		cassette: cassette,
+		replayed: make(map[int]bool),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch t.mode {
+	case ModeReplay:
+		return t.replay(req)
+	case ModeRecord:
+		return t.record(req)
+	default:
+		return t.next.RoundTrip(req)
+	}
+}
+
+// Interactions returns a copy of the interactions currently held by the transport
+func (t *Transport) Interactions() []Interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	interactions := make([]Interaction, len(t.cassette.Interactions))
+	copy(interactions, t.cassette.Interactions)
+	return interactions
+}
+
+func (t *Transport) replay(req *http.Request) (*http.Response, error) {
+	recorded, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	match := -1
+	for i, interaction := range t.cassette.Interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+		match = i
+		if !t.replayed[i] {
+			break
+		}
+	}
+	if match == -1 {
+		return nil, fmt.Errorf("recorder: no recorded interaction for %s %s in %s", recorded.Method, recorded.URL, t.path)
+	}
+	t.replayed[match] = true
+
+	return t.cassette.Interactions[match].Response.toHTTPResponse(req), nil
+}
+
+func (t *Transport) record(req *http.Request) (*http.Response, error) {
+	recorded, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: failed to read response body: %w", err)
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	response := RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     header,
+		Body:       string(body),
+	}
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: response,
+	})
+	t.recorded = true
+	t.mu.Unlock()
+
+	return response.toHTTPResponse(req), nil
+}
+
+// Close saves the cassette when interactions were recorded since it was loaded or last saved
+func (t *Transport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.recorded {
+		return nil
+	}
+	if err := t.cassette.Save(t.path); err != nil {
+		return err
+	}
+	t.recorded = false
+	return nil
+}
+
+func newRecordedRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return RecordedRequest{}, fmt.Errorf("recorder: failed to read request body: %w", err)
+	}
+	req.Body.Close()
+	// Restore the body so the request can still be sent in record mode
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = string(body)
+	return recorded, nil
+}
+
+func (r RecordedResponse) toHTTPResponse(req *http.Request) *http.Response {
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
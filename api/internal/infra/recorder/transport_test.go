@@ -0,0 +1,263 @@
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// Transport Tests
+// ============================================================================
+
+func TestTransport_RecordAndReplay(t *testing.T) {
+	t.Run("ReplaysRecordedInteractionsWithoutNetwork", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "session=secret")
+			w.Write([]byte(`{"echo":"` + string(body) + `","path":"` + r.URL.Path + `"}`))
+		}))
+		path := filepath.Join(t.TempDir(), "cassette.json")
+
+		recordTransport, err := NewTransport(path, ModeRecord, nil)
+		assert.NoError(t, err)
+		recordClient := &http.Client{Transport: recordTransport}
+
+		// Act
+		resp, err := recordClient.Post(server.URL+"/embeddings", "application/json", strings.NewReader("hello"))
+		assert.NoError(t, err)
+		recordedBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		server.Close()
+		assert.NoError(t, recordTransport.Close())
+
+		replayTransport, err := NewTransport(path, ModeReplay, nil)
+		assert.NoError(t, err)
+		replayClient := &http.Client{Transport: replayTransport}
+		resp, err = replayClient.Post(server.URL+"/embeddings", "application/json", strings.NewReader("hello"))
+
+		// Assert
+		assert.NoError(t, err)
+		replayedBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, string(recordedBody), string(replayedBody))
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Empty(t, resp.Header.Get("Set-Cookie"))
+	})
+
+	t.Run("RecordsErrorStatus", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("slow down"))
+		}))
+		defer server.Close()
+		path := filepath.Join(t.TempDir(), "cassette.json")
+		recordTransport, _ := NewTransport(path, ModeRecord, nil)
+		_, err := (&http.Client{Transport: recordTransport}).Get(server.URL + "/r/golang/.json")
+		assert.NoError(t, err)
+		assert.NoError(t, recordTransport.Close())
+
+		replayTransport, _ := NewTransport(path, ModeReplay, nil)
+
+		// Act
+		resp, err := (&http.Client{Transport: replayTransport}).Get(server.URL + "/r/golang/.json")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "slow down", string(body))
+	})
+}
+
+func TestTransport_Record(t *testing.T) {
+	t.Run("AppendsToExistingCassette", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("new"))
+		}))
+		defer server.Close()
+		path := filepath.Join(t.TempDir(), "cassette.json")
+		existing := &Cassette{
+			Interactions: []Interaction{
+				{
+					Request:  RecordedRequest{Method: "GET", URL: "https://www.reddit.com/r/golang/.json"},
+					Response: RecordedResponse{StatusCode: 200, Body: "old"},
+				},
+			},
+		}
+		assert.NoError(t, existing.Save(path))
+		transport, err := NewTransport(path, ModeRecord, nil)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = (&http.Client{Transport: transport}).Get(server.URL + "/r/rust/.json")
+		assert.NoError(t, err)
+		beforeClose, _ := LoadCassette(path)
+		closeErr := transport.Close()
+		afterClose, _ := LoadCassette(path)
+
+		// Assert
+		assert.NoError(t, closeErr)
+		// Nothing is written until the transport is closed
+		assert.Len(t, beforeClose.Interactions, 1)
+		assert.Len(t, afterClose.Interactions, 2)
+		assert.Equal(t, "old", afterClose.Interactions[0].Response.Body)
+		assert.Equal(t, "new", afterClose.Interactions[1].Response.Body)
+	})
+}
+
+func TestTransport_Replay(t *testing.T) {
+	cassette := &Cassette{
+		Interactions: []Interaction{
+			{
+				Request:  RecordedRequest{Method: "GET", URL: "https://www.reddit.com/r/golang/.json?limit=5"},
+				Response: RecordedResponse{StatusCode: 200, Body: "first"},
+			},
+			{
+				Request:  RecordedRequest{Method: "GET", URL: "https://www.reddit.com/r/golang/.json?limit=5"},
+				Response: RecordedResponse{StatusCode: 200, Body: "second"},
+			},
+			{
+				Request:  RecordedRequest{Method: "POST", URL: "http://127.0.0.1:1234/v1/embeddings", Body: `{"input":["a"]}`},
+				Response: RecordedResponse{StatusCode: 200, Body: "embedding a"},
+			},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	assert.NoError(t, cassette.Save(path))
+
+	get := func(client *http.Client, url string) string {
+		resp, err := client.Get(url)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	t.Run("IdenticalRequestsReplayInOrder", func(t *testing.T) {
+		// Arrange
+		transport, err := NewTransport(path, ModeReplay, nil)
+		assert.NoError(t, err)
+		client := &http.Client{Transport: transport}
+		url := "https://www.reddit.com/r/golang/.json?limit=5"
+
+		// Act
+		first := get(client, url)
+		second := get(client, url)
+		third := get(client, url)
+
+		// Assert
+		assert.Equal(t, "first", first)
+		assert.Equal(t, "second", second)
+		assert.Equal(t, "second", third)
+	})
+
+	t.Run("MatchesOnBody", func(t *testing.T) {
+		// Arrange
+		transport, _ := NewTransport(path, ModeReplay, nil)
+		client := &http.Client{Transport: transport}
+
+		// Act
+		_, missErr := client.Post("http://127.0.0.1:1234/v1/embeddings", "application/json", strings.NewReader(`{"input":["b"]}`))
+		resp, hitErr := client.Post("http://127.0.0.1:1234/v1/embeddings", "application/json", strings.NewReader(`{"input":["a"]}`))
+
+		// Assert
+		assert.Error(t, missErr)
+		assert.Contains(t, missErr.Error(), "no recorded interaction")
+		assert.NoError(t, hitErr)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "embedding a", string(body))
+	})
+
+	t.Run("MissingCassetteIsEmpty", func(t *testing.T) {
+		// Arrange
+		transport, err := NewTransport(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = (&http.Client{Transport: transport}).Get("https://www.reddit.com/r/golang/.json")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+// ============================================================================
+// Config Tests
+// ============================================================================
+
+func TestParseMode(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected Mode
+	}{
+		{"", ModeOff},
+		{"off", ModeOff},
+		{"Record", ModeRecord},
+		{" replay ", ModeReplay},
+	} {
+		mode, err := ParseMode(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, mode)
+	}
+
+	_, err := ParseMode("rewind")
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("OffUsesDefaultTransport", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		cfg.Set("recorder.mode", "off")
+
+		// Act
+		client, closer, err := NewHTTPClient(cfg, "reddit", 0, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, client.Transport)
+		assert.NoError(t, closer.Close())
+	})
+
+	t.Run("ReplayUsesNamedCassette", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		cassette := &Cassette{
+			Interactions: []Interaction{
+				{
+					Request:  RecordedRequest{Method: "GET", URL: "https://www.reddit.com/r/golang/.json"},
+					Response: RecordedResponse{StatusCode: 200, Body: "ok"},
+				},
+			},
+		}
+		assert.NoError(t, cassette.Save(filepath.Join(dir, "reddit.json")))
+		cfg := viper.New()
+		cfg.Set("recorder.mode", "replay")
+		cfg.Set("recorder.cassette_dir", dir)
+
+		// Act
+		client, _, err := NewHTTPClient(cfg, "reddit", 0, nil)
+		assert.NoError(t, err)
+		resp, err := client.Get("https://www.reddit.com/r/golang/.json")
+
+		// Assert
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "ok", string(body))
+	})
+}
@@ -1,18 +1,27 @@
 package reddit
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/spf13/viper"
 )
 
 // ClientInterface defines the interface for Reddit client operations
 type ClientInterface interface {
-	GetPosts(subreddit string, limit int) (*RedditResponse, error)
-	SearchPosts(subreddit string, query string, limit int) (*RedditResponse, error)
+	GetPosts(ctx context.Context, subreddit string, limit int, options ListingOptions) (*RedditResponse, error)
+	SearchPosts(ctx context.Context, subreddit string, query string, limit int, options SearchOptions) (*RedditResponse, error)
+	GetUserPosts(ctx context.Context, username string, limit int, options ListingOptions) (*RedditResponse, error)
+	GetDomainPosts(ctx context.Context, domain string, limit int, options ListingOptions) (*RedditResponse, error)
+	SearchSubreddits(ctx context.Context, query string, limit int) (*SubredditListing, error)
+	AutocompleteSubreddits(ctx context.Context, query string, limit int) (*SubredditListing, error)
+	GetComments(ctx context.Context, postID string, limit int) (*CommentListing, error)
+	Ping(ctx context.Context) error
 }
 
 // Client represents a Reddit API client
@@ -33,6 +42,16 @@ func NewClient() *Client {
 	}
 }
 
+// NewClientWithHTTPClient creates a new Reddit client that sends requests through httpClient,
+// for example one with a record/replay transport
+func NewClientWithHTTPClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    baseURL,
+		userAgent:  "reddit-content-analyzer/1.0",
+	}
+}
+
 // NewTestClient creates a new Reddit client for testing with a custom baseURL
 func NewTestClient(baseURL string) *Client {
 	return &Client{
@@ -44,19 +63,26 @@ func NewTestClient(baseURL string) *Client {
 	}
 }
 
-// GetPosts retrieves a list of posts from a given subreddit
-// limit specifies the maximum number of posts to retrieve (default: 25, max: 100)
-func (c *Client) GetPosts(subreddit string, limit int) (*RedditResponse, error) {
-	if limit <= 0 {
-		limit = 25
-	}
-	if limit > 100 {
-		limit = 100
+// NewClientFromConfig creates a new Reddit client configured from the reddit section of cfg
+func NewClientFromConfig(cfg *viper.Viper, httpClient *http.Client) *Client {
+	baseURL := cfg.GetString("reddit.base_url")
+	if baseURL == "" {
+		baseURL = "https://www.reddit.com"
 	}
+	return NewClientWithHTTPClient(baseURL, httpClient)
+}
 
-	url := fmt.Sprintf("%s/r/%s/.json?limit=%d", c.baseURL, subreddit, limit)
+// GetPosts retrieves a list of posts from a given subreddit, sorted as options say.
+// subreddit can be a multireddit such as golang+rust, or all for the posts of all subreddits.
+// limit specifies the maximum number of posts to retrieve (default: 25, max: 100)
+func (c *Client) GetPosts(ctx context.Context, subreddit string, limit int, options ListingOptions) (*RedditResponse, error) {
+	query := limitQuery(limit)
+	if options.Time != "" {
+		query.Set("t", string(options.Time))
+	}
+	url := c.baseURL + listingPath(subreddit, options.Sort) + "?" + query.Encode()
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -71,7 +97,7 @@ func (c *Client) GetPosts(subreddit string, limit int) (*RedditResponse, error)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("reddit API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 
 	}
 
@@ -82,22 +108,25 @@ func (c *Client) GetPosts(subreddit string, limit int) (*RedditResponse, error)
 	return redditResponse, nil
 }
 
-// SearchPosts searches for posts in a subreddit by query terms
+// SearchPosts searches for posts in a subreddit by query terms, sorted as options say.
+// subreddit can be a multireddit such as golang+rust, and all searches all of Reddit.
 // limit specifies the maximum number of posts to retrieve (default: 25, max: 100)
-func (c *Client) SearchPosts(subreddit string, query string, limit int) (*RedditResponse, error) {
-	if limit <= 0 {
-		limit = 25
-	}
-	if limit > 100 {
-		limit = 100
-	}
-
+func (c *Client) SearchPosts(ctx context.Context, subreddit string, query string, limit int, options SearchOptions) (*RedditResponse, error) {
 	// Reddit search endpoint with restrict_sr=true to limit search to the subreddit
 	// URL encode the query parameter
 	encodedQuery := url.QueryEscape(query)
-	url := fmt.Sprintf("%s/r/%s/search.json?q=%s&restrict_sr=true&limit=%d", c.baseURL, subreddit, encodedQuery, limit)
+	url := fmt.Sprintf("%s/r/%s/search.json?q=%s&restrict_sr=true&%s", c.baseURL, subreddit, encodedQuery, limitQuery(limit).Encode())
+	if subreddit == AllSubreddits {
+		url = fmt.Sprintf("%s/search.json?q=%s&%s", c.baseURL, encodedQuery, limitQuery(limit).Encode())
+	}
+	if options.Sort != "" {
+		url += "&sort=" + string(options.Sort)
+	}
+	if options.Time != "" {
+		url += "&t=" + string(options.Time)
+	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -112,7 +141,7 @@ func (c *Client) SearchPosts(subreddit string, query string, limit int) (*Reddit
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("reddit API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 
 	}
 
@@ -122,3 +151,151 @@ func (c *Client) SearchPosts(subreddit string, query string, limit int) (*Reddit
 	}
 	return redditResponse, nil
 }
+
+// GetUserPosts retrieves the posts a user submitted, sorted as options say. Users have no
+// rising listing, so that sort falls back to Reddit's default, new.
+// limit specifies the maximum number of posts to retrieve (default: 25, max: 100)
+func (c *Client) GetUserPosts(ctx context.Context, username string, limit int, options ListingOptions) (*RedditResponse, error) {
+	query := limitQuery(limit)
+	if options.Sort != "" && options.Sort != ListingSortRising {
+		query.Set("sort", string(options.Sort))
+	}
+	if options.Time != "" {
+		query.Set("t", string(options.Time))
+	}
+	url := fmt.Sprintf("%s/user/%s/submitted.json?%s", c.baseURL, username, query.Encode())
+
+	var redditResponse *RedditResponse
+	if err := c.getJSON(ctx, url, &redditResponse); err != nil {
+		return nil, err
+	}
+	return redditResponse, nil
+}
+
+// GetDomainPosts retrieves the posts linking to a domain, sorted as options say
+// limit specifies the maximum number of posts to retrieve (default: 25, max: 100)
+func (c *Client) GetDomainPosts(ctx context.Context, domain string, limit int, options ListingOptions) (*RedditResponse, error) {
+	query := limitQuery(limit)
+	if options.Time != "" {
+		query.Set("t", string(options.Time))
+	}
+	path := "/domain/" + domain + ".json"
+	if options.Sort != "" {
+		path = "/domain/" + domain + "/" + string(options.Sort) + ".json"
+	}
+	url := c.baseURL + path + "?" + query.Encode()
+
+	var redditResponse *RedditResponse
+	if err := c.getJSON(ctx, url, &redditResponse); err != nil {
+		return nil, err
+	}
+	return redditResponse, nil
+}
+
+// SearchSubreddits searches subreddits by name and description
+// limit specifies the maximum number of subreddits to retrieve (default: 25, max: 100)
+func (c *Client) SearchSubreddits(ctx context.Context, query string, limit int) (*SubredditListing, error) {
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	url := fmt.Sprintf("%s/subreddits/search.json?q=%s&limit=%d", c.baseURL, url.QueryEscape(query), limit)
+
+	var listing *SubredditListing
+	if err := c.getJSON(ctx, url, &listing); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// AutocompleteSubreddits returns the subreddits whose name starts like query, without NSFW subreddits.
+// It uses the v2 autocomplete endpoint, which returns full subreddit listings including descriptions.
+// limit specifies the maximum number of subreddits to retrieve (default: 10, max: 10)
+func (c *Client) AutocompleteSubreddits(ctx context.Context, query string, limit int) (*SubredditListing, error) {
+	if limit <= 0 || limit > 10 {
+		limit = 10
+	}
+
+	url := fmt.Sprintf(
+		"%s/api/subreddit_autocomplete_v2.json?query=%s&limit=%d&include_over_18=false&include_profiles=false",
+		c.baseURL, url.QueryEscape(query), limit,
+	)
+
+	var listing *SubredditListing
+	if err := c.getJSON(ctx, url, &listing); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// GetComments retrieves the top level comments of a post, best first. postID is the ID of the
+// post, without the t3_ prefix.
+// limit specifies the maximum number of comments to retrieve (default: 25, max: 100)
+func (c *Client) GetComments(ctx context.Context, postID string, limit int) (*CommentListing, error) {
+	query := limitQuery(limit)
+	query.Set("sort", "top")
+	query.Set("depth", "1")
+	url := fmt.Sprintf("%s/comments/%s.json?%s", c.baseURL, url.PathEscape(postID), query.Encode())
+
+	var listings []CommentListing
+	if err := c.getJSON(ctx, url, &listings); err != nil {
+		return nil, err
+	}
+	if len(listings) < 2 {
+		return &CommentListing{}, nil
+	}
+	return &listings[1], nil
+}
+
+// getJSON sends a GET request to url and decodes the JSON response into out
+func (c *Client) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// Ping checks that the Reddit API is reachable by fetching a single post from r/popular
+func (c *Client) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s/r/popular/.json?limit=1", c.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return &APIError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
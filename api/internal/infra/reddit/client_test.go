@@ -1,6 +1,7 @@
 package reddit
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -48,7 +49,7 @@ func TestClient_GetPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.GetPosts("technology", 5)
+		result, err := client.GetPosts(context.Background(), "technology", 5, ListingOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -57,6 +58,38 @@ func TestClient_GetPosts(t *testing.T) {
 		assert.Equal(t, "Test Post", result.Data.Children[0].Data.Title)
 	})
 
+	t.Run("Sorts", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			options  ListingOptions
+			expected string
+		}{
+			{"Default", ListingOptions{}, "/r/golang/.json?limit=5"},
+			{"New", ListingOptions{Sort: ListingSortNew}, "/r/golang/new.json?limit=5"},
+			{"TopOfTheWeek", ListingOptions{Sort: ListingSortTop, Time: TimeWindowWeek}, "/r/golang/top.json?limit=5&t=week"},
+			{"Rising", ListingOptions{Sort: ListingSortRising}, "/r/golang/rising.json?limit=5"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, tt.expected, r.URL.Path+"?"+r.URL.RawQuery)
+					w.Write([]byte(`{"data":{"children":[]}}`))
+				}))
+				defer server.Close()
+
+				client := NewTestClient(server.URL)
+
+				// Act
+				_, err := client.GetPosts(context.Background(), "golang", 5, tt.options)
+
+				// Assert
+				assert.NoError(t, err)
+			})
+		}
+	})
+
 	t.Run("LimitDefaulting", func(t *testing.T) {
 		// Arrange
 		expectedResponse := &RedditResponse{
@@ -75,7 +108,7 @@ func TestClient_GetPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.GetPosts("technology", 0)
+		result, err := client.GetPosts(context.Background(), "technology", 0, ListingOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -100,7 +133,7 @@ func TestClient_GetPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.GetPosts("technology", 200)
+		result, err := client.GetPosts(context.Background(), "technology", 200, ListingOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -118,7 +151,7 @@ func TestClient_GetPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.GetPosts("technology", 5)
+		result, err := client.GetPosts(context.Background(), "technology", 5, ListingOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -137,7 +170,7 @@ func TestClient_GetPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.GetPosts("technology", 5)
+		result, err := client.GetPosts(context.Background(), "technology", 5, ListingOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -150,7 +183,7 @@ func TestClient_GetPosts(t *testing.T) {
 		client := NewTestClient("http://invalid-url-that-does-not-exist:12345")
 
 		// Act
-		result, err := client.GetPosts("technology", 5)
+		result, err := client.GetPosts(context.Background(), "technology", 5, ListingOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -168,7 +201,7 @@ func TestClient_GetPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.GetPosts("nonexistent", 5)
+		result, err := client.GetPosts(context.Background(), "nonexistent", 5, ListingOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -218,7 +251,7 @@ func TestClient_SearchPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.SearchPosts("technology", "artificial intelligence", 5)
+		result, err := client.SearchPosts(context.Background(), "technology", "artificial intelligence", 5, SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -245,7 +278,7 @@ func TestClient_SearchPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.SearchPosts("technology", "test", 0)
+		result, err := client.SearchPosts(context.Background(), "technology", "test", 0, SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -270,13 +303,48 @@ func TestClient_SearchPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.SearchPosts("technology", "test", 150)
+		result, err := client.SearchPosts(context.Background(), "technology", "test", 150, SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 	})
 
+	t.Run("SortAndTimeWindow", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "comments", r.URL.Query().Get("sort"))
+			assert.Equal(t, "month", r.URL.Query().Get("t"))
+			w.Write([]byte(`{"data":{"children":[]}}`))
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		_, err := client.SearchPosts(context.Background(), "golang", "generics", 5, SearchOptions{Sort: SearchSortComments, Time: TimeWindowMonth})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("SiteWide", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/search.json?q=generics&limit=5", r.URL.Path+"?"+r.URL.RawQuery)
+			w.Write([]byte(`{"data":{"children":[]}}`))
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		_, err := client.SearchPosts(context.Background(), AllSubreddits, "generics", 5, SearchOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
 	t.Run("QueryEncoding", func(t *testing.T) {
 		// Arrange
 		expectedResponse := &RedditResponse{
@@ -296,7 +364,7 @@ func TestClient_SearchPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.SearchPosts("technology", "C++ & Python", 5)
+		result, err := client.SearchPosts(context.Background(), "technology", "C++ & Python", 5, SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -314,7 +382,7 @@ func TestClient_SearchPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.SearchPosts("technology", "test", 5)
+		result, err := client.SearchPosts(context.Background(), "technology", "test", 5, SearchOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -333,7 +401,7 @@ func TestClient_SearchPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.SearchPosts("technology", "test", 5)
+		result, err := client.SearchPosts(context.Background(), "technology", "test", 5, SearchOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -358,7 +426,7 @@ func TestClient_SearchPosts(t *testing.T) {
 		client := NewTestClient(server.URL)
 
 		// Act
-		result, err := client.SearchPosts("technology", "nonexistent", 5)
+		result, err := client.SearchPosts(context.Background(), "technology", "nonexistent", 5, SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -366,3 +434,232 @@ func TestClient_SearchPosts(t *testing.T) {
 		assert.Empty(t, result.Data.Children)
 	})
 }
+
+// ============================================================================
+// User and Domain Listing Tests
+// ============================================================================
+
+func TestClient_GetUserPosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ListingOptions
+		expected string
+	}{
+		{"Default", ListingOptions{}, "/user/spez/submitted.json?limit=5"},
+		{"TopOfTheYear", ListingOptions{Sort: ListingSortTop, Time: TimeWindowYear}, "/user/spez/submitted.json?limit=5&sort=top&t=year"},
+		{"RisingIsDefault", ListingOptions{Sort: ListingSortRising}, "/user/spez/submitted.json?limit=5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.expected, r.URL.Path+"?"+r.URL.RawQuery)
+				w.Write([]byte(`{"data":{"children":[{"data":{"id":"p1","subreddit":"golang","title":"Hello"}}]}}`))
+			}))
+			defer server.Close()
+
+			client := NewTestClient(server.URL)
+
+			// Act
+			result, err := client.GetUserPosts(context.Background(), "spez", 5, tt.options)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, "golang", result.Data.Children[0].Data.Subreddit)
+		})
+	}
+}
+
+func TestClient_GetDomainPosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ListingOptions
+		expected string
+	}{
+		{"Default", ListingOptions{}, "/domain/go.dev.json?limit=5"},
+		{"New", ListingOptions{Sort: ListingSortNew}, "/domain/go.dev/new.json?limit=5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.expected, r.URL.Path+"?"+r.URL.RawQuery)
+				w.Write([]byte(`{"data":{"children":[]}}`))
+			}))
+			defer server.Close()
+
+			client := NewTestClient(server.URL)
+
+			// Act
+			_, err := client.GetDomainPosts(context.Background(), "go.dev", 5, tt.options)
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_GetComments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/comments/abc123.json?depth=1&limit=5&sort=top", r.URL.Path+"?"+r.URL.RawQuery)
+			w.Write([]byte(`[
+				{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"abc123","title":"Post"}}]}},
+				{"kind":"Listing","data":{"children":[
+					{"kind":"t1","data":{"id":"c1","author":"gopher","body":"Great release","score":12}},
+					{"kind":"more","data":{"id":"c2"}}
+				]}}
+			]`))
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		comments, err := client.GetComments(context.Background(), "abc123", 5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, comments.Data.Children, 2)
+		assert.Equal(t, "t1", comments.Data.Children[0].Kind)
+		assert.Equal(t, "Great release", comments.Data.Children[0].Data.Body)
+		assert.Equal(t, 12, comments.Data.Children[0].Data.Score)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		comments, err := client.GetComments(context.Background(), "missing", 5)
+
+		// Assert
+		assert.Nil(t, comments)
+		var apiErr *APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+// ============================================================================
+// Subreddit Discovery Tests
+// ============================================================================
+
+func TestClient_SearchSubreddits(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/subreddits/search.json", r.URL.Path)
+			assert.Equal(t, "golang generics", r.URL.Query().Get("q"))
+			assert.Equal(t, "25", r.URL.Query().Get("limit"))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":{"children":[{"kind":"t5","data":{"display_name":"golang","public_description":"Go","subscribers":250000,"over18":false,"url":"/r/golang/"}}]}}`))
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		result, err := client.SearchSubreddits(context.Background(), "golang generics", 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Data.Children, 1)
+		assert.Equal(t, "t5", result.Data.Children[0].Kind)
+		assert.Equal(t, "golang", result.Data.Children[0].Data.DisplayName)
+		assert.Equal(t, 250000, result.Data.Children[0].Data.Subscribers)
+	})
+
+	t.Run("HTTPError", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		result, err := client.SearchSubreddits(context.Background(), "golang", 5)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "status 429")
+	})
+}
+
+func TestClient_AutocompleteSubreddits(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/subreddit_autocomplete_v2.json", r.URL.Path)
+			assert.Equal(t, "gola", r.URL.Query().Get("query"))
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+			assert.Equal(t, "false", r.URL.Query().Get("include_over_18"))
+			assert.Equal(t, "false", r.URL.Query().Get("include_profiles"))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":{"children":[{"kind":"t5","data":{"display_name":"golang"}}]}}`))
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		result, err := client.AutocompleteSubreddits(context.Background(), "gola", 50)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Data.Children, 1)
+		assert.Equal(t, "golang", result.Data.Children[0].Data.DisplayName)
+	})
+}
+
+// ============================================================================
+// Ping Tests
+// ============================================================================
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("Reachable", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/r/popular/.json?limit=1", r.URL.Path+"?"+r.URL.RawQuery)
+			assert.Equal(t, "reddit-content-analyzer/1.0", r.Header.Get("User-Agent"))
+			w.Write([]byte(`{"data":{"children":[]}}`))
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		err := client.Ping(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewTestClient(server.URL)
+
+		// Act
+		err := client.Ping(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+	})
+}
@@ -14,7 +14,11 @@ type RedditChild struct {
 }
 
 type RedditPostData struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`      // Fullname of the post, t3_ followed by the ID
+	Subreddit   string  `json:"subreddit"` // Display name of the subreddit the post was submitted to
 	Title       string  `json:"title"`
+	Author      string  `json:"author"`
 	Selftext    string  `json:"selftext"`
 	URL         string  `json:"url"`
 	Score       int     `json:"score"`
@@ -22,4 +26,120 @@ type RedditPostData struct {
 	CreatedUTC  float64 `json:"created_utc"`
 	Permalink   string  `json:"permalink"`
 	Stickied    bool    `json:"stickied"` // Indicates if post is pinned/community highlight
+	// CrosspostParent is the fullname of the original post of a crosspost, empty for other posts
+	CrosspostParent string `json:"crosspost_parent"`
+	// Media describes the embedded page of a link post or the video of a video post, nil for other posts
+	Media *RedditMedia `json:"media"`
+	// PostHint is the kind of post Reddit detected: self, link, image, hosted:video or rich:video
+	PostHint  string `json:"post_hint"`
+	IsVideo   bool   `json:"is_video"`
+	IsGallery bool   `json:"is_gallery"`
+	// GalleryData orders the images of a gallery, whose files are in MediaMetadata
+	GalleryData *RedditGalleryData `json:"gallery_data"`
+	// MediaMetadata holds the images of galleries and the images inline in text posts, by media ID
+	MediaMetadata map[string]RedditMediaMetadata `json:"media_metadata"`
+	Preview       *RedditPreview                 `json:"preview"`
+}
+
+// RedditMedia is the embed Reddit resolved for the URL of a link post
+type RedditMedia struct {
+	Oembed      *RedditOembed `json:"oembed"`
+	RedditVideo *RedditVideo  `json:"reddit_video"`
+}
+
+// RedditOembed is the oEmbed description of a linked page
+type RedditOembed struct {
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	Description  string `json:"description"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// RedditVideo is a video hosted by Reddit
+type RedditVideo struct {
+	FallbackURL string `json:"fallback_url"`
+	Duration    int    `json:"duration"` // Seconds
+}
+
+type RedditGalleryData struct {
+	Items []RedditGalleryItem `json:"items"`
+}
+
+type RedditGalleryItem struct {
+	MediaID     string `json:"media_id"`
+	Caption     string `json:"caption"`
+	OutboundURL string `json:"outbound_url"`
+}
+
+type RedditMediaMetadata struct {
+	Status string `json:"status"` // valid once the media is processed
+	Kind   string `json:"e"`      // Image, AnimatedImage or RedditVideo
+	Mime   string `json:"m"`
+	Source struct {
+		URL string `json:"u"`
+		Gif string `json:"gif"`
+		MP4 string `json:"mp4"`
+	} `json:"s"`
+}
+
+type RedditPreview struct {
+	Images []RedditPreviewImage `json:"images"`
+}
+
+type RedditPreviewImage struct {
+	Source struct {
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+	} `json:"source"`
+}
+
+// SubredditListing is a listing of subreddits, as returned by subreddit search and autocomplete
+type SubredditListing struct {
+	Data SubredditListingData `json:"data"`
+}
+
+type SubredditListingData struct {
+	Children []SubredditChild `json:"children"`
+}
+
+type SubredditChild struct {
+	Kind string        `json:"kind"` // t5 for subreddits, autocomplete also returns user profiles
+	Data SubredditData `json:"data"`
+}
+
+type SubredditData struct {
+	DisplayName       string  `json:"display_name"`
+	Title             string  `json:"title"`
+	PublicDescription string  `json:"public_description"`
+	Subscribers       int     `json:"subscribers"`
+	ActiveUserCount   int     `json:"active_user_count"`
+	Over18            bool    `json:"over18"`
+	SubredditType     string  `json:"subreddit_type"` // public, restricted, private, ...
+	URL               string  `json:"url"`
+	CreatedUTC        float64 `json:"created_utc"`
+}
+
+// CommentListing is the listing of the comments of a post. Reddit answers the comments endpoint
+// with two listings, the post and its comments, and this is the second one.
+type CommentListing struct {
+	Data CommentListingData `json:"data"`
+}
+
+type CommentListingData struct {
+	Children []CommentChild `json:"children"`
+}
+
+type CommentChild struct {
+	Kind string      `json:"kind"` // t1 for comments, more for the link to the rest of them
+	Data CommentData `json:"data"`
+}
+
+type CommentData struct {
+	ID         string  `json:"id"`
+	Author     string  `json:"author"`
+	Body       string  `json:"body"`
+	Score      int     `json:"score"`
+	CreatedUTC float64 `json:"created_utc"`
+	Stickied   bool    `json:"stickied"` // Pinned comments, usually by moderators or bots
 }
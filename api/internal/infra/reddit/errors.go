@@ -0,0 +1,21 @@
+package reddit
+
+import "fmt"
+
+// APIError is returned when the Reddit API answers with a non-200 status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("reddit API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("reddit API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// HTTPStatus returns the status code the API answered with
+func (e *APIError) HTTPStatus() int {
+	return e.StatusCode
+}
@@ -0,0 +1,74 @@
+package reddit
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// AllSubreddits is the subreddit of the posts of all subreddits, r/all
+const AllSubreddits = "all"
+
+// ListingSort is the order of the posts of a subreddit listing
+type ListingSort string
+
+const (
+	ListingSortHot           ListingSort = "hot"
+	ListingSortNew           ListingSort = "new"
+	ListingSortTop           ListingSort = "top"
+	ListingSortRising        ListingSort = "rising"
+	ListingSortControversial ListingSort = "controversial"
+)
+
+// SearchSort is the order of search results
+type SearchSort string
+
+const (
+	SearchSortRelevance SearchSort = "relevance"
+	SearchSortNew       SearchSort = "new"
+	SearchSortComments  SearchSort = "comments"
+)
+
+// TimeWindow restricts top and controversial listings and search results to the posts of the last hour, day, ...
+type TimeWindow string
+
+const (
+	TimeWindowHour  TimeWindow = "hour"
+	TimeWindowDay   TimeWindow = "day"
+	TimeWindowWeek  TimeWindow = "week"
+	TimeWindowMonth TimeWindow = "month"
+	TimeWindowYear  TimeWindow = "year"
+	TimeWindowAll   TimeWindow = "all"
+)
+
+// ListingOptions selects the listing of a subreddit. The zero value is Reddit's default, hot.
+type ListingOptions struct {
+	Sort ListingSort
+	// Time only applies to the top and controversial sorts, Reddit uses day when empty
+	Time TimeWindow
+}
+
+// SearchOptions orders and restricts search results. The zero value is Reddit's default,
+// relevance over all time.
+type SearchOptions struct {
+	Sort SearchSort
+	Time TimeWindow
+}
+
+// listingPath returns the path of the listing of subreddit sorted by sort
+func listingPath(subreddit string, sort ListingSort) string {
+	if sort == "" {
+		return "/r/" + subreddit + "/.json"
+	}
+	return "/r/" + subreddit + "/" + string(sort) + ".json"
+}
+
+// limitQuery returns the limit query parameter, 25 when zero or less and at most 100
+func limitQuery(limit int) url.Values {
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return url.Values{"limit": {strconv.Itoa(limit)}}
+}
@@ -0,0 +1,13 @@
+package runstore
+
+import "github.com/spf13/viper"
+
+// NewStoreFromConfig creates a store persisted to runs.path, or kept in memory when it is empty,
+// that keeps the last runs.max_runs runs
+func NewStoreFromConfig(cfg *viper.Viper) (Store, error) {
+	maxRuns := cfg.GetInt("runs.max_runs")
+	if path := cfg.GetString("runs.path"); path != "" {
+		return NewFileStore(path, maxRuns)
+	}
+	return NewMemoryStore(maxRuns), nil
+}
@@ -0,0 +1,56 @@
+package runstore
+
+import (
+	"strings"
+	"time"
+)
+
+// Run is a stored relevance search: its topics and the posts it evaluated
+type Run struct {
+	ID        string    `json:"id"`
+	Topics    []string  `json:"topics"`
+	CreatedAt time.Time `json:"created_at"`
+	Posts     []Post    `json:"posts"`
+}
+
+// Post is a post as a run evaluated it
+type Post struct {
+	ID             string    `json:"id"`
+	URL            string    `json:"url"`
+	Subreddit      string    `json:"subreddit"`
+	CreatedAt      time.Time `json:"created_at"`
+	IsRelevant     bool      `json:"is_relevant"`
+	RelevanceScore float64   `json:"relevance_score"`
+	Score          int       `json:"score"`
+	NumComments    int       `json:"num_comments"`
+	// Sentiment and Stance are empty unless the run analyzed sentiment and the post is relevant
+	Sentiment string `json:"sentiment,omitempty"`
+	Stance    string `json:"stance,omitempty"`
+}
+
+// Filter selects the runs of a topic created in a time range. Zero bounds are open.
+type Filter struct {
+	// Topic matches the runs that searched it, ignoring case
+	Topic string
+	Since time.Time
+	Until time.Time
+}
+
+// Matches reports whether run passes the filter
+func (f Filter) Matches(run Run) bool {
+	if !f.Since.IsZero() && run.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !run.CreatedAt.Before(f.Until) {
+		return false
+	}
+	if f.Topic == "" {
+		return true
+	}
+	for _, topic := range run.Topics {
+		if strings.EqualFold(strings.TrimSpace(topic), strings.TrimSpace(f.Topic)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package runstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultMaxRuns is how many runs a store keeps when its config does not say. Every run is kept
+// in memory with up to 2000 posts, a limit of 100 for 10 subreddits and 10 sources, so the default
+// bounds the store at 20 million posts, several GB at worst.
+const DefaultMaxRuns = 10000
+
+// Store saves runs and lists them back, oldest first
+type Store interface {
+	Save(ctx context.Context, run Run) error
+	List(ctx context.Context, filter Filter) ([]Run, error)
+}
+
+// memoryStore keeps the last maxRuns runs in memory
+type memoryStore struct {
+	mu      sync.RWMutex
+	runs    []Run
+	maxRuns int
+}
+
+// NewMemoryStore creates a store that keeps the last maxRuns runs in memory, DefaultMaxRuns when
+// maxRuns is not positive. Runs are lost when the process exits.
+func NewMemoryStore(maxRuns int) Store {
+	return newMemoryStore(maxRuns)
+}
+
+func newMemoryStore(maxRuns int) *memoryStore {
+	if maxRuns <= 0 {
+		maxRuns = DefaultMaxRuns
+	}
+	return &memoryStore{maxRuns: maxRuns}
+}
+
+func (s *memoryStore) Save(ctx context.Context, run Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.append(run)
+	return nil
+}
+
+// append adds run and drops the oldest runs beyond maxRuns, the caller holds the lock
+func (s *memoryStore) append(run Run) {
+	s.runs = append(s.runs, run)
+	if excess := len(s.runs) - s.maxRuns; excess > 0 {
+		s.runs = append([]Run(nil), s.runs[excess:]...)
+	}
+}
+
+func (s *memoryStore) List(ctx context.Context, filter Filter) ([]Run, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	runs := make([]Run, 0)
+	for _, run := range s.runs {
+		if filter.Matches(run) {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// fileStore appends every run as a JSON line to a file and serves them from memory. The file is
+// rewritten with the runs in memory once it holds twice maxRuns runs, so it stays bounded and a
+// restart does not parse every run ever saved.
+type fileStore struct {
+	*memoryStore
+	path string
+	// lines is how many runs the file holds
+	lines int
+}
+
+// NewFileStore creates a store persisted to the JSON lines file at path, created along with its
+// directory when missing. The last maxRuns runs of the file are loaded and served from memory,
+// and the file is compacted to them when it holds more.
+func NewFileStore(path string, maxRuns int) (Store, error) {
+	store := &fileStore{memoryStore: newMemoryStore(maxRuns), path: path}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create run store directory: %w", err)
+	}
+	if store.lines > store.maxRuns {
+		if err := store.compact(); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+func (s *fileStore) load() error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open run store: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return fmt.Errorf("failed to decode run on line %d of run store: %w", line, err)
+		}
+		s.append(run)
+		s.lines++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read run store: %w", err)
+	}
+	return nil
+}
+
+func (s *fileStore) Save(ctx context.Context, run Run) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to encode run: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open run store: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write run: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write run: %w", err)
+	}
+	s.append(run)
+	s.lines++
+	// Compacting only past twice maxRuns keeps a save from rewriting the whole file every time
+	if s.lines >= 2*s.maxRuns {
+		return s.compact()
+	}
+	return nil
+}
+
+// compact rewrites the file with the runs in memory, the caller holds the lock. The runs are
+// written to a temporary file renamed over the store, so a failure leaves the file as it was.
+func (s *fileStore) compact() error {
+	temp := s.path + ".tmp"
+	file, err := os.Create(temp)
+	if err != nil {
+		return fmt.Errorf("failed to compact run store: %w", err)
+	}
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, run := range s.runs {
+		if err = encoder.Encode(run); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp, s.path)
+	}
+	if err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("failed to compact run store: %w", err)
+	}
+	s.lines = len(s.runs)
+	return nil
+}
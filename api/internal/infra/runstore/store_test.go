@@ -0,0 +1,170 @@
+package runstore
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var day = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func testRun(id string, createdAt time.Time, topics ...string) Run {
+	return Run{
+		ID:        id,
+		Topics:    topics,
+		CreatedAt: createdAt,
+		Posts:     []Post{{ID: "p-" + id, Subreddit: "golang", CreatedAt: createdAt, IsRelevant: true, RelevanceScore: 0.8}},
+	}
+}
+
+func runIDs(runs []Run) []string {
+	ids := make([]string, 0, len(runs))
+	for _, run := range runs {
+		ids = append(ids, run.ID)
+	}
+	return ids
+}
+
+// fileRunIDs returns the IDs of the runs the JSON lines file at path holds
+func fileRunIDs(t *testing.T, path string) []string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	ids := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var run Run
+		require.NoError(t, json.Unmarshal([]byte(line), &run))
+		ids = append(ids, run.ID)
+	}
+	return ids
+}
+
+// ============================================================================
+// Store Tests
+// ============================================================================
+
+func TestMemoryStore(t *testing.T) {
+	t.Run("FiltersByTopicAndTime", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		store := NewMemoryStore(0)
+		require.NoError(t, store.Save(ctx, testRun("1", day, "Go Generics")))
+		require.NoError(t, store.Save(ctx, testRun("2", day.Add(24*time.Hour), "rust")))
+		require.NoError(t, store.Save(ctx, testRun("3", day.Add(48*time.Hour), "rust", "go generics")))
+
+		// Act
+		runs, err := store.List(ctx, Filter{Topic: " go generics", Until: day.Add(48 * time.Hour)})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, runIDs(runs))
+	})
+
+	t.Run("KeepsLastRuns", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		store := NewMemoryStore(2)
+		for _, id := range []string{"1", "2", "3"} {
+			require.NoError(t, store.Save(ctx, testRun(id, day, "go")))
+		}
+
+		// Act
+		runs, err := store.List(ctx, Filter{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "3"}, runIDs(runs))
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	t.Run("ReloadsSavedRuns", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "runs", "runs.jsonl")
+		store, err := NewFileStore(path, 2)
+		require.NoError(t, err)
+		for _, id := range []string{"1", "2", "3"} {
+			require.NoError(t, store.Save(ctx, testRun(id, day, "go")))
+		}
+
+		// Act
+		reopened, err := NewFileStore(path, 2)
+		require.NoError(t, err)
+		runs, err := reopened.List(ctx, Filter{Topic: "go"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "3"}, runIDs(runs))
+		assert.Equal(t, testRun("3", day, "go"), runs[1])
+	})
+
+	t.Run("CompactsFileOnLoad", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "runs.jsonl")
+		store, err := NewFileStore(path, 5)
+		require.NoError(t, err)
+		for _, id := range []string{"1", "2", "3"} {
+			require.NoError(t, store.Save(ctx, testRun(id, day, "go")))
+		}
+
+		// Act
+		_, err = NewFileStore(path, 2)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "3"}, fileRunIDs(t, path))
+		assert.NoFileExists(t, path+".tmp")
+	})
+
+	t.Run("CompactsFileOnSave", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "runs.jsonl")
+		store, err := NewFileStore(path, 2)
+		require.NoError(t, err)
+
+		// Act
+		for _, id := range []string{"1", "2", "3"} {
+			require.NoError(t, store.Save(ctx, testRun(id, day, "go")))
+		}
+		beforeCompaction := fileRunIDs(t, path)
+		require.NoError(t, store.Save(ctx, testRun("4", day, "go")))
+
+		// Assert
+		assert.Equal(t, []string{"1", "2", "3"}, beforeCompaction)
+		assert.Equal(t, []string{"3", "4"}, fileRunIDs(t, path))
+		runs, err := store.List(ctx, Filter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3", "4"}, runIDs(runs))
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		// Act
+		store, err := NewFileStore(filepath.Join(t.TempDir(), "runs.jsonl"), 0)
+
+		// Assert
+		assert.NoError(t, err)
+		runs, _ := store.List(context.Background(), Filter{})
+		assert.Empty(t, runs)
+	})
+
+	t.Run("CorruptFile", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "runs.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte("{\"id\":\"1\"}\nnot json\n"), 0o644))
+
+		// Act
+		_, err := NewFileStore(path, 0)
+
+		// Assert
+		assert.ErrorContains(t, err, "failed to decode run on line 2 of run store")
+	})
+}
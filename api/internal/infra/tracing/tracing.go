@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/ReyOrtiz/reddit-content-analyzer"
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Provider is a tracer provider along with the function that flushes and stops it
+type Provider struct {
+	trace.TracerProvider
+	shutdown func(ctx context.Context) error
+}
+
+// Shutdown flushes the pending spans and stops the exporter
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.shutdown(ctx)
+}
+
+// New creates a tracer provider configured from the tracing section of cfg.
+// tracing.exporter selects where spans go: none (default), stdout or otlp.
+func New(cfg *viper.Viper) (*Provider, error) {
+	exporterName := strings.ToLower(cfg.GetString("tracing.exporter"))
+	if exporterName == "" || exporterName == ExporterNone {
+		return &Provider{
+			TracerProvider: noop.NewTracerProvider(),
+			shutdown:       func(ctx context.Context) error { return nil },
+		}, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch exporterName {
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if endpoint := cfg.GetString("tracing.otlp_endpoint"); endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporterName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", exporterName, err)
+	}
+
+	serviceName := cfg.GetString("tracing.service_name")
+	if serviceName == "" {
+		serviceName = "reddit-content-analyzer"
+	}
+	sampleRatio := 1.0
+	if cfg.IsSet("tracing.sample_ratio") {
+		sampleRatio = cfg.GetFloat64("tracing.sample_ratio")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	return &Provider{
+		TracerProvider: provider,
+		shutdown:       provider.Shutdown,
+	}, nil
+}
+
+// Start starts a span named name as a child of the span in ctx. The tracer comes from the
+// parent span's provider, so code below the HTTP middleware does not need a provider injected,
+// and nothing is recorded when ctx carries no span.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(instrumentationName)
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records err on span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Propagator returns the W3C trace context and baggage propagator used for inbound and outbound requests
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
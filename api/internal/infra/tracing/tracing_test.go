@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// ============================================================================
+// Provider Tests
+// ============================================================================
+
+func TestNew(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+
+		// Act
+		provider, err := New(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		_, span := provider.Tracer("test").Start(context.Background(), "span")
+		assert.False(t, span.SpanContext().IsValid())
+		assert.NoError(t, provider.Shutdown(context.Background()))
+	})
+
+	t.Run("Stdout", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		cfg.Set("tracing.exporter", "stdout")
+
+		// Act
+		provider, err := New(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		_, span := provider.Tracer("test").Start(context.Background(), "span")
+		assert.True(t, span.SpanContext().IsValid())
+		assert.NoError(t, provider.Shutdown(context.Background()))
+	})
+
+	t.Run("UnknownExporter", func(t *testing.T) {
+		// Arrange
+		cfg := viper.New()
+		cfg.Set("tracing.exporter", "zipkin")
+
+		// Act
+		provider, err := New(cfg)
+
+		// Assert
+		assert.Nil(t, provider)
+		assert.EqualError(t, err, `unknown tracing exporter "zipkin"`)
+	})
+}
+
+// ============================================================================
+// Span Tests
+// ============================================================================
+
+func TestStart(t *testing.T) {
+	t.Run("ChildOfContextSpan", func(t *testing.T) {
+		// Arrange
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+		// Act
+		_, child := Start(ctx, "child")
+		End(child, errors.New("boom"))
+		parent.End()
+
+		// Assert
+		spans := recorder.Ended()
+		assert.Len(t, spans, 2)
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Len(t, spans[0].Events(), 1)
+	})
+
+	t.Run("NoopWithoutContextSpan", func(t *testing.T) {
+		// Act
+		_, span := Start(context.Background(), "orphan")
+		End(span, nil)
+
+		// Assert
+		assert.False(t, span.SpanContext().IsValid())
+	})
+}
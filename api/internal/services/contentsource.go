@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/feed"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/hackernews"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/lemmy"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+)
+
+// ContentItem is a post of a content source, normalized for the relevance pipeline
+type ContentItem struct {
+	ID          string
+	Title       string
+	Body        string
+	URL         string
+	Author      string
+	Score       int
+	NumComments int
+	Created     time.Time
+	// Community is where the item was posted: a subreddit, a feed title, a Lemmy community, ...
+	Community string
+	// OriginID identifies the original of the item and all its crossposts, t3_{id} of the
+	// original post on Reddit. It is empty for the sources without crossposts.
+	OriginID string
+	// LinkTitle is the title of the page a link post links to, when the source knows it
+	LinkTitle string
+	// LinkText is the readable text of the page a link post links to, when it was fetched
+	LinkText string
+	// Media is the images or video of media posts, nil for other posts
+	Media *Media
+}
+
+// ContentQuery is what a request asks of a content source
+type ContentQuery struct {
+	// Search is the full-text query, sources that cannot search list their items instead
+	Search     string
+	Limit      int
+	Method     contracts.SearchMethod
+	SearchSort contracts.SearchSort
+	TimeWindow contracts.TimeWindow
+}
+
+// ContentSource fetches the items of the targets of one kind of source, for example the
+// subreddits of Reddit or the tags of Hacker News
+type ContentSource interface {
+	Fetch(ctx context.Context, target string, query ContentQuery) ([]ContentItem, error)
+}
+
+// searchingSource is implemented by the content sources that run the Search of their queries.
+// The others list their items, so a request fetches them once whatever its search queries.
+type searchingSource interface {
+	ContentSource
+	searches()
+}
+
+// ContentSources are the sources of a request by prefix, for example hackernews:
+type ContentSources map[string]ContentSource
+
+// lookup returns the content source of a request source along with its target
+func (s ContentSources) lookup(source string) (ContentSource, string, bool) {
+	for prefix, contentSource := range s {
+		if target, found := strings.CutPrefix(source, prefix); found {
+			return contentSource, target, true
+		}
+	}
+	return nil, "", false
+}
+
+// contentQuery returns the query of the request for the search query, empty for listings
+func contentQuery(request contracts.RelevanceRequestDto, search string) ContentQuery {
+	return ContentQuery{
+		Search:     search,
+		Limit:      request.Limit,
+		Method:     request.SearchMethod,
+		SearchSort: request.SearchSort,
+		TimeWindow: request.TimeWindow,
+	}
+}
+
+// redditSubredditSource searches or lists subreddits, multireddits (a+b) and r/all
+type redditSubredditSource struct {
+	redditService RedditService
+}
+
+func (s *redditSubredditSource) searches() {}
+
+func (s *redditSubredditSource) Fetch(ctx context.Context, subreddit string, query ContentQuery) ([]ContentItem, error) {
+	var posts *reddit.RedditResponse
+	var err error
+	if query.Search != "" {
+		posts, err = s.redditService.SearchPosts(ctx, subreddit, query.Search, query.Limit, searchOptions(query))
+	} else {
+		posts, err = s.redditService.GetPosts(ctx, subreddit, query.Limit, listingOptions(query))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return MapRedditResponseToContentItems(posts), nil
+}
+
+// redditUserSource lists the posts a Reddit user submitted
+type redditUserSource struct {
+	redditService RedditService
+}
+
+func (s *redditUserSource) Fetch(ctx context.Context, username string, query ContentQuery) ([]ContentItem, error) {
+	posts, err := s.redditService.GetUserPosts(ctx, username, query.Limit, listingOptions(query))
+	if err != nil {
+		return nil, err
+	}
+	return MapRedditResponseToContentItems(posts), nil
+}
+
+// redditDomainSource lists the Reddit posts that link to a domain
+type redditDomainSource struct {
+	redditService RedditService
+}
+
+func (s *redditDomainSource) Fetch(ctx context.Context, domain string, query ContentQuery) ([]ContentItem, error) {
+	posts, err := s.redditService.GetDomainPosts(ctx, domain, query.Limit, listingOptions(query))
+	if err != nil {
+		return nil, err
+	}
+	return MapRedditResponseToContentItems(posts), nil
+}
+
+// listingOptions returns the Reddit listing of the search method of the query. Searches fall
+// back to the default listing for the sources that cannot be searched.
+func listingOptions(query ContentQuery) reddit.ListingOptions {
+	if query.Method == contracts.SearchMethodSearch {
+		return reddit.ListingOptions{}
+	}
+	options := reddit.ListingOptions{Sort: reddit.ListingSort(query.Method)}
+	if query.Method == contracts.SearchMethodLatest {
+		options.Sort = reddit.ListingSortNew
+	}
+	if options.Sort == reddit.ListingSortTop || options.Sort == reddit.ListingSortControversial {
+		options.Time = reddit.TimeWindow(query.TimeWindow)
+	}
+	return options
+}
+
+func searchOptions(query ContentQuery) reddit.SearchOptions {
+	return reddit.SearchOptions{
+		Sort: reddit.SearchSort(query.SearchSort),
+		Time: reddit.TimeWindow(query.TimeWindow),
+	}
+}
+
+// HackerNewsCommunity is the community of the items of Hacker News
+const HackerNewsCommunity = "Hacker News"
+
+type hackerNewsSource struct {
+	client hackernews.ClientInterface
+	now    func() time.Time
+}
+
+// NewHackerNewsSource creates a source of the Hacker News items of a tag: story, ask_hn,
+// show_hn, front_page, ...
+func NewHackerNewsSource(client hackernews.ClientInterface) ContentSource {
+	return &hackerNewsSource{client: client, now: time.Now}
+}
+
+func (s *hackerNewsSource) searches() {}
+
+func (s *hackerNewsSource) Fetch(ctx context.Context, tag string, query ContentQuery) ([]ContentItem, error) {
+	params := hackernews.SearchParams{
+		Query:        query.Search,
+		Tags:         tag,
+		CreatedAfter: timeWindowStart(query.TimeWindow, s.now()),
+		Limit:        query.Limit,
+	}
+	// Algolia ranks by relevance and points, which stands for the other listings and sorts
+	if query.Search != "" {
+		params.ByDate = query.SearchSort == contracts.SearchSortNew
+	} else {
+		params.ByDate = query.Method == contracts.SearchMethodNew || query.Method == contracts.SearchMethodLatest
+	}
+
+	response, err := s.client.Search(ctx, params)
+	if err != nil {
+		return nil, contentSourceError(SourceHackerNewsPrefix+tag, err)
+	}
+	items := make([]ContentItem, 0, len(response.Hits))
+	for _, hit := range response.Hits {
+		items = append(items, MapHackerNewsHitToContentItem(hit))
+	}
+	return items, nil
+}
+
+type feedSource struct {
+	client feed.ClientInterface
+}
+
+// NewFeedSource creates a source of the items of RSS and Atom feeds, by feed URL. Feeds cannot
+// be searched, their newest items are returned.
+func NewFeedSource(client feed.ClientInterface) ContentSource {
+	return &feedSource{client: client}
+}
+
+func (s *feedSource) Fetch(ctx context.Context, feedURL string, query ContentQuery) ([]ContentItem, error) {
+	result, err := s.client.GetFeed(ctx, feedURL)
+	if err != nil {
+		return nil, contentSourceError(SourceFeedPrefix+feedURL, err)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	items := make([]ContentItem, 0, min(limit, len(result.Items)))
+	for _, item := range result.Items {
+		if len(items) >= limit {
+			break
+		}
+		items = append(items, MapFeedItemToContentItem(item, result.Title))
+	}
+	return items, nil
+}
+
+type lemmySource struct {
+	client lemmy.ClientInterface
+}
+
+// NewLemmySource creates a source of the posts of Lemmy communities, by community@instance
+func NewLemmySource(client lemmy.ClientInterface) ContentSource {
+	return &lemmySource{client: client}
+}
+
+func (s *lemmySource) searches() {}
+
+func (s *lemmySource) Fetch(ctx context.Context, target string, query ContentQuery) ([]ContentItem, error) {
+	community, instance, found := splitLemmyCommunity(target)
+	if !found {
+		return nil, apperrors.Validation(nil, []apperrors.FieldError{{Field: "sources", Rule: "source", Message: "must be lemmy:{community@instance}"}})
+	}
+
+	var response *lemmy.PostsResponse
+	var err error
+	if query.Search != "" {
+		response, err = s.client.SearchPosts(ctx, instance, community, query.Search, lemmySearchSort(query), query.Limit)
+	} else {
+		response, err = s.client.GetPosts(ctx, instance, community, lemmyListingSort(query), query.Limit)
+	}
+	if err != nil {
+		return nil, contentSourceError(SourceLemmyPrefix+target, err)
+	}
+	items := make([]ContentItem, 0, len(response.Posts))
+	for _, post := range response.Posts {
+		items = append(items, MapLemmyPostToContentItem(post))
+	}
+	return items, nil
+}
+
+// splitLemmyCommunity splits community@instance
+func splitLemmyCommunity(target string) (community, instance string, found bool) {
+	community, instance, found = strings.Cut(target, "@")
+	return community, instance, found && community != "" && instance != ""
+}
+
+// lemmyListingSort returns the Lemmy sort of the listing search methods. Rising has no
+// equivalent, the active sort is the closest.
+func lemmyListingSort(query ContentQuery) string {
+	switch query.Method {
+	case contracts.SearchMethodNew, contracts.SearchMethodLatest:
+		return "New"
+	case contracts.SearchMethodRising:
+		return "Active"
+	case contracts.SearchMethodControversial:
+		return "Controversial"
+	case contracts.SearchMethodTop:
+		return lemmyTopSort(query.TimeWindow)
+	default:
+		return "Hot"
+	}
+}
+
+// lemmySearchSort returns the Lemmy sort of the search sort, Lemmy has no relevance sort
+func lemmySearchSort(query ContentQuery) string {
+	switch query.SearchSort {
+	case contracts.SearchSortNew:
+		return "New"
+	case contracts.SearchSortComments:
+		return "MostComments"
+	default:
+		if query.TimeWindow != "" {
+			return lemmyTopSort(query.TimeWindow)
+		}
+		return ""
+	}
+}
+
+// lemmyTopSort returns the top sort of the time window, the last day when empty as on Reddit
+func lemmyTopSort(window contracts.TimeWindow) string {
+	switch window {
+	case contracts.TimeWindowHour:
+		return "TopHour"
+	case contracts.TimeWindowWeek:
+		return "TopWeek"
+	case contracts.TimeWindowMonth:
+		return "TopMonth"
+	case contracts.TimeWindowYear:
+		return "TopYear"
+	case contracts.TimeWindowAll:
+		return "TopAll"
+	default:
+		return "TopDay"
+	}
+}
+
+// timeWindowStart returns when the time window started, zero for all time
+func timeWindowStart(window contracts.TimeWindow, now time.Time) time.Time {
+	switch window {
+	case contracts.TimeWindowHour:
+		return now.Add(-time.Hour)
+	case contracts.TimeWindowDay:
+		return now.AddDate(0, 0, -1)
+	case contracts.TimeWindowWeek:
+		return now.AddDate(0, 0, -7)
+	case contracts.TimeWindowMonth:
+		return now.AddDate(0, -1, 0)
+	case contracts.TimeWindowYear:
+		return now.AddDate(-1, 0, 0)
+	default:
+		return time.Time{}
+	}
+}
+
+// contentSourceError classifies a failure of a source outside Reddit. Sources answer 404, 403
+// or 410 for the targets that do not exist or are gone, and those on private addresses are
+// refused before they are reached.
+func contentSourceError(source string, err error) error {
+	if errors.Is(err, netguard.ErrPrivateAddress) {
+		return apperrors.Validation(err, []apperrors.FieldError{{Field: "sources", Rule: "source", Message: "must not point to a private address"}})
+	}
+
+	status := 0
+	var hackerNewsErr *hackernews.APIError
+	var feedErr *feed.APIError
+	var lemmyErr *lemmy.APIError
+	switch {
+	case errors.As(err, &hackerNewsErr):
+		status = hackerNewsErr.StatusCode
+	case errors.As(err, &feedErr):
+		status = feedErr.StatusCode
+	case errors.As(err, &lemmyErr):
+		status = lemmyErr.StatusCode
+	}
+
+	switch status {
+	case http.StatusNotFound, http.StatusForbidden, http.StatusGone:
+		return apperrors.Wrap(err, apperrors.CodeSourceNotFound, fmt.Sprintf("Source %s does not exist", source))
+	default:
+		return apperrors.Wrap(err, apperrors.CodeSourceUnavailable, fmt.Sprintf("Source %s could not be reached", source))
+	}
+}
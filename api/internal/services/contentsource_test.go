@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/feed"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/hackernews"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/lemmy"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/netguard"
+)
+
+// fixtureServer serves a fixture of the infra clients, e.g. hackernews/testdata/search.json, to
+// every request, after check has looked at it
+func fixtureServer(t *testing.T, fixture string, check func(r *http.Request)) *httptest.Server {
+	data, err := os.ReadFile(filepath.Join("..", "infra", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		check(r)
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// Hacker News Tests
+// ============================================================================
+
+func TestHackerNewsSource_Fetch(t *testing.T) {
+	now := time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Search", func(t *testing.T) {
+		// Arrange
+		server := fixtureServer(t, "hackernews/testdata/search.json", func(r *http.Request) {
+			assert.Equal(t, "/api/v1/search_by_date", r.URL.Path)
+			assert.Equal(t, "go generics", r.URL.Query().Get("query"))
+			assert.Equal(t, "story", r.URL.Query().Get("tags"))
+			assert.Equal(t, "created_at_i>1722643200", r.URL.Query().Get("numericFilters"))
+		})
+		source := &hackerNewsSource{client: hackernews.NewTestClient(server.URL), now: func() time.Time { return now }}
+
+		// Act
+		items, err := source.Fetch(context.Background(), "story", ContentQuery{
+			Search:     "go generics",
+			Limit:      5,
+			Method:     contracts.SearchMethodSearch,
+			SearchSort: contracts.SearchSortNew,
+			TimeWindow: contracts.TimeWindowWeek,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, ContentItem{
+			ID:          "40000001",
+			Title:       "Go 1.23 adds range-over-func iterators",
+			URL:         "https://go.dev/blog/range-functions",
+			Author:      "gopher",
+			Score:       412,
+			NumComments: 187,
+			Created:     time.Unix(1723000000, 0),
+			Community:   HackerNewsCommunity,
+		}, items[0])
+		// Text posts link to their item page
+		assert.Equal(t, "https://news.ycombinator.com/item?id=40000002", items[1].URL)
+	})
+
+	t.Run("TopListing", func(t *testing.T) {
+		// Arrange
+		server := fixtureServer(t, "hackernews/testdata/search.json", func(r *http.Request) {
+			assert.Equal(t, "/api/v1/search", r.URL.Path)
+			assert.Empty(t, r.URL.Query().Get("query"))
+			assert.Empty(t, r.URL.Query().Get("numericFilters"))
+		})
+		source := NewHackerNewsSource(hackernews.NewTestClient(server.URL))
+
+		// Act
+		_, err := source.Fetch(context.Background(), "front_page", ContentQuery{Method: contracts.SearchMethodTop})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		source := NewHackerNewsSource(hackernews.NewTestClient(server.URL))
+
+		// Act
+		_, err := source.Fetch(context.Background(), "story", ContentQuery{})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeSourceUnavailable, apperrors.CodeOf(err))
+		assert.Contains(t, err.Error(), "hackernews:story")
+	})
+}
+
+// ============================================================================
+// Feed Tests
+// ============================================================================
+
+func TestFeedSource_Fetch(t *testing.T) {
+	t.Run("Limit", func(t *testing.T) {
+		// Arrange
+		server := fixtureServer(t, "feed/testdata/rss.xml", func(r *http.Request) {})
+		source := NewFeedSource(feed.NewClientWithHTTPClient(server.Client()))
+
+		// Act
+		items, err := source.Fetch(context.Background(), server.URL+"/feed.xml", ContentQuery{Limit: 1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.True(t, items[0].Created.Equal(time.Date(2024, 8, 6, 10, 0, 0, 0, time.UTC)))
+		items[0].Created = time.Time{}
+		assert.Equal(t, ContentItem{
+			ID:        "https://golangweekly.com/issues/500",
+			Title:     "Generics in practice",
+			Body:      "How teams use type parameters in production.",
+			URL:       "https://golangweekly.com/issues/500",
+			Author:    "Peter",
+			Community: "Go Weekly",
+		}, items[0])
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+		source := NewFeedSource(feed.NewClientWithHTTPClient(server.Client()))
+
+		// Act
+		_, err := source.Fetch(context.Background(), server.URL+"/missing.xml", ContentQuery{})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeSourceNotFound, apperrors.CodeOf(err))
+	})
+
+	t.Run("PrivateAddress", func(t *testing.T) {
+		// Arrange
+		server := fixtureServer(t, "feed/testdata/rss.xml", func(r *http.Request) {
+			t.Error("the feed on loopback was requested")
+		})
+		source := NewFeedSource(feed.NewClientWithHTTPClient(&http.Client{Transport: netguard.Guard{}.Transport()}))
+
+		// Act
+		_, err := source.Fetch(context.Background(), server.URL+"/feed.xml", ContentQuery{})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeValidationFailed, apperrors.CodeOf(err))
+	})
+}
+
+// ============================================================================
+// Lemmy Tests
+// ============================================================================
+
+func TestLemmySource_Fetch(t *testing.T) {
+	t.Run("Listing", func(t *testing.T) {
+		// Arrange
+		server := fixtureServer(t, "lemmy/testdata/posts.json", func(r *http.Request) {
+			assert.Equal(t, "/api/v3/post/list", r.URL.Path)
+			assert.Equal(t, "golang", r.URL.Query().Get("community_name"))
+			assert.Equal(t, "TopWeek", r.URL.Query().Get("sort"))
+		})
+		source := NewLemmySource(lemmy.NewTestClient(server.URL))
+
+		// Act
+		items, err := source.Fetch(context.Background(), "golang@programming.dev", ContentQuery{
+			Limit:      5,
+			Method:     contracts.SearchMethodTop,
+			TimeWindow: contracts.TimeWindowWeek,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, "1201", items[0].ID)
+		// Text posts link to their post page
+		assert.Equal(t, "https://programming.dev/post/1201", items[0].URL)
+		assert.Equal(t, "https://go.dev/doc/go1.23", items[1].URL)
+		assert.Equal(t, "bob", items[1].Author)
+		assert.Equal(t, "golang", items[1].Community)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		// Arrange
+		server := fixtureServer(t, "lemmy/testdata/posts.json", func(r *http.Request) {
+			assert.Equal(t, "/api/v3/search", r.URL.Path)
+			assert.Equal(t, "generics", r.URL.Query().Get("q"))
+			assert.Equal(t, "MostComments", r.URL.Query().Get("sort"))
+		})
+		source := NewLemmySource(lemmy.NewTestClient(server.URL))
+
+		// Act
+		items, err := source.Fetch(context.Background(), "golang@programming.dev", ContentQuery{
+			Search:     "generics",
+			Method:     contracts.SearchMethodSearch,
+			SearchSort: contracts.SearchSortComments,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+
+	t.Run("InvalidTarget", func(t *testing.T) {
+		// Arrange
+		source := NewLemmySource(lemmy.NewTestClient("http://127.0.0.1:0"))
+
+		// Act
+		_, err := source.Fetch(context.Background(), "golang", ContentQuery{})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeValidationFailed, apperrors.CodeOf(err))
+	})
+}
+
+func TestLemmyListingSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    ContentQuery
+		expected string
+	}{
+		{"Hot", ContentQuery{Method: contracts.SearchMethodHot}, "Hot"},
+		{"LatestIsNew", ContentQuery{Method: contracts.SearchMethodLatest}, "New"},
+		{"RisingIsActive", ContentQuery{Method: contracts.SearchMethodRising}, "Active"},
+		{"TopDefaultsToDay", ContentQuery{Method: contracts.SearchMethodTop}, "TopDay"},
+		{"TopOfYear", ContentQuery{Method: contracts.SearchMethodTop, TimeWindow: contracts.TimeWindowYear}, "TopYear"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			sort := lemmyListingSort(tt.query)
+
+			// Assert
+			assert.Equal(t, tt.expected, sort)
+		})
+	}
+}
+
+func TestSourceKind(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"r/golang", "subreddit"},
+		{"user:spez", "user"},
+		{"domain:go.dev", "domain"},
+		{"hackernews:story", "hackernews"},
+		{"feed:https://go.dev/blog/feed.atom", "feed"},
+		{"lemmy:rust@programming.dev", "lemmy"},
+		{"", "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			// Act
+			kind := SourceKind(tt.source)
+
+			// Assert
+			assert.Equal(t, tt.expected, kind)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+)
+
+// DefaultDuplicateThreshold is the cosine similarity from which two posts are near duplicates
+const DefaultDuplicateThreshold = 0.92
+
+// duplicateGroup is a canonical post and its duplicates, by index in the posts of a request
+type duplicateGroup struct {
+	canonical  int
+	duplicates []duplicateMember
+}
+
+type duplicateMember struct {
+	index      int
+	reason     contracts.DuplicateReason
+	similarity float64
+}
+
+// singletonGroups puts every post in its own group, for requests that do not deduplicate
+func singletonGroups(count int) []duplicateGroup {
+	groups := make([]duplicateGroup, count)
+	for i := range groups {
+		groups[i].canonical = i
+	}
+	return groups
+}
+
+// groupExactDuplicates groups the items that are the same post, crossposts of the same post or
+// links to the same canonical URL. The item with the highest score of a group is its canonical
+// item, and groups are in the order their first item was fetched.
+func groupExactDuplicates(items []ContentItem) []duplicateGroup {
+	parent := make([]int, len(items))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	firstByKey := make(map[string]int)
+	link := func(i int, key string) {
+		if j, found := firstByKey[key]; found {
+			parent[find(i)] = find(j)
+			return
+		}
+		firstByKey[key] = i
+	}
+	for i, item := range items {
+		if item.OriginID != "" {
+			link(i, "origin:"+item.OriginID)
+		}
+		if canonical := canonicalURL(item.URL); canonical != "" {
+			link(i, "url:"+canonical)
+		}
+	}
+
+	membersByRoot := make(map[int][]int)
+	roots := make([]int, 0)
+	for i := range items {
+		root := find(i)
+		if _, found := membersByRoot[root]; !found {
+			roots = append(roots, root)
+		}
+		membersByRoot[root] = append(membersByRoot[root], i)
+	}
+
+	groups := make([]duplicateGroup, 0, len(roots))
+	for _, root := range roots {
+		members := membersByRoot[root]
+		canonical := members[0]
+		for _, member := range members[1:] {
+			if items[member].Score > items[canonical].Score {
+				canonical = member
+			}
+		}
+		group := duplicateGroup{canonical: canonical}
+		for _, member := range members {
+			if member != canonical {
+				group.duplicates = append(group.duplicates, duplicateMember{
+					index:  member,
+					reason: exactDuplicateReason(items[canonical], items[member]),
+				})
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// exactDuplicateReason returns why duplicate was grouped with canonical. Items linked through a
+// third item, such as a crosspost of a link to the same page, are URL duplicates.
+func exactDuplicateReason(canonical, duplicate ContentItem) contracts.DuplicateReason {
+	switch {
+	case canonical.OriginID != "" && canonical.OriginID == duplicate.OriginID && canonical.ID == duplicate.ID:
+		return contracts.DuplicateReasonSamePost
+	case canonical.OriginID != "" && canonical.OriginID == duplicate.OriginID:
+		return contracts.DuplicateReasonCrosspost
+	default:
+		return contracts.DuplicateReasonURL
+	}
+}
+
+// mergeNearDuplicates merges the groups whose canonical items have embeddings at least threshold
+// similar, by index of the item. A group is merged into the first earlier group it is similar to,
+// under the canonical item with the highest score.
+func mergeNearDuplicates(items []ContentItem, groups []duplicateGroup, embeddings [][]float32, threshold float64) []duplicateGroup {
+	merged := make([]duplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		target := -1
+		similarity := 0.0
+		for i, kept := range merged {
+			similarity = CosineSimilarity(embeddings[kept.canonical], embeddings[group.canonical])
+			if similarity >= threshold {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			merged = append(merged, group)
+			continue
+		}
+
+		kept := merged[target]
+		winner, loser := kept, group
+		if items[group.canonical].Score > items[kept.canonical].Score {
+			winner, loser = group, kept
+		}
+		winner.duplicates = append(winner.duplicates, duplicateMember{
+			index:      loser.canonical,
+			reason:     contracts.DuplicateReasonSimilar,
+			similarity: similarity,
+		})
+		winner.duplicates = append(winner.duplicates, loser.duplicates...)
+		sort.SliceStable(winner.duplicates, func(i, j int) bool {
+			return winner.duplicates[i].index < winner.duplicates[j].index
+		})
+		merged[target] = winner
+	}
+	return merged
+}
+
+// trackingParameters are the query parameters that do not change the page a URL links to
+var trackingParameters = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"ref":     true,
+	"ref_src": true,
+	"source":  true,
+}
+
+// canonicalURL returns the URL without its scheme, www. prefix, fragment, trailing slash and
+// tracking parameters, so links to the same page compare equal. It returns an empty string for
+// URLs without a host.
+func canonicalURL(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := parsed.Query()
+	for name := range query {
+		if trackingParameters[strings.ToLower(name)] || strings.HasPrefix(strings.ToLower(name), "utm_") {
+			query.Del(name)
+		}
+	}
+
+	canonical := host + strings.TrimSuffix(parsed.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		canonical += "?" + encoded
+	}
+	return canonical
+}
+
+// duplicateCommunities returns the distinct communities of the canonical item and its
+// duplicates, the one of the canonical item first
+func duplicateCommunities(items []ContentItem, group duplicateGroup) []string {
+	communities := []string{items[group.canonical].Community}
+	seen := map[string]bool{strings.ToLower(items[group.canonical].Community): true}
+	for _, duplicate := range group.duplicates {
+		community := items[duplicate.index].Community
+		if !seen[strings.ToLower(community)] {
+			seen[strings.ToLower(community)] = true
+			communities = append(communities, community)
+		}
+	}
+	return communities
+}
+
+// combinedScore returns the score of the canonical item and its duplicates
+func combinedScore(items []ContentItem, group duplicateGroup) int {
+	score := items[group.canonical].Score
+	for _, duplicate := range group.duplicates {
+		score += items[duplicate.index].Score
+	}
+	return score
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+)
+
+// ============================================================================
+// Exact Duplicates Tests
+// ============================================================================
+
+func TestGroupExactDuplicates(t *testing.T) {
+	t.Run("CrosspostsAndLinks", func(t *testing.T) {
+		// Arrange
+		items := []ContentItem{
+			MapRedditPostToContentItem(reddit.RedditChild{Data: reddit.RedditPostData{ID: "x1", Subreddit: "programming", Score: 40, CrosspostParent: "t3_a1", URL: "https://www.reddit.com/r/golang/comments/a1/"}}),
+			{ID: "hn1", URL: "https://go.dev/blog/generics?utm_source=hn", Score: 300, Community: HackerNewsCommunity},
+			MapRedditPostToContentItem(reddit.RedditChild{Data: reddit.RedditPostData{ID: "a1", Subreddit: "golang", Score: 120, URL: "https://www.reddit.com/r/golang/comments/a1/"}}),
+			MapRedditPostToContentItem(reddit.RedditChild{Data: reddit.RedditPostData{ID: "b1", Subreddit: "golang", Score: 15, URL: "http://go.dev/blog/generics/"}}),
+			MapRedditPostToContentItem(reddit.RedditChild{Data: reddit.RedditPostData{ID: "a1", Subreddit: "golang", Score: 120, URL: "https://www.reddit.com/r/golang/comments/a1/"}}),
+			{ID: "c1", URL: "https://example.com/other", Score: 1},
+		}
+
+		// Act
+		groups := groupExactDuplicates(items)
+
+		// Assert
+		assert.Equal(t, []duplicateGroup{
+			{canonical: 2, duplicates: []duplicateMember{
+				{index: 0, reason: contracts.DuplicateReasonCrosspost},
+				{index: 4, reason: contracts.DuplicateReasonSamePost},
+			}},
+			{canonical: 1, duplicates: []duplicateMember{{index: 3, reason: contracts.DuplicateReasonURL}}},
+			{canonical: 5},
+		}, groups)
+		assert.Equal(t, []string{"golang", "programming"}, duplicateCommunities(items, groups[0]))
+		assert.Equal(t, 280, combinedScore(items, groups[0]))
+	})
+
+	t.Run("ItemsWithoutURL", func(t *testing.T) {
+		// Act
+		groups := groupExactDuplicates([]ContentItem{{ID: "1"}, {ID: "2"}})
+
+		// Assert
+		assert.Equal(t, singletonGroups(2), groups)
+	})
+}
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"SchemeAndWWW", "http://www.Go.dev/blog/", "go.dev/blog"},
+		{"TrackingParameters", "https://go.dev/blog?utm_source=reddit&ref=hn&page=2#top", "go.dev/blog?page=2"},
+		{"DefaultPort", "https://go.dev:443/doc", "go.dev/doc"},
+		{"OtherPort", "https://go.dev:8443/doc", "go.dev:8443/doc"},
+		{"Relative", "/r/golang/comments/a1/", ""},
+		{"Empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			canonical := canonicalURL(tt.url)
+
+			// Assert
+			assert.Equal(t, tt.expected, canonical)
+		})
+	}
+}
+
+// ============================================================================
+// Near Duplicates Tests
+// ============================================================================
+
+func TestMergeNearDuplicates(t *testing.T) {
+	// Arrange
+	items := []ContentItem{
+		{ID: "a", Score: 10},
+		{ID: "b", Score: 50},
+		{ID: "c", Score: 5},
+		{ID: "d", Score: 1},
+	}
+	groups := []duplicateGroup{
+		{canonical: 0},
+		{canonical: 1, duplicates: []duplicateMember{{index: 3, reason: contracts.DuplicateReasonURL}}},
+		{canonical: 2},
+	}
+	embeddings := [][]float32{{1, 0}, {1, 0.1}, {0, 1}, nil}
+
+	// Act
+	merged := mergeNearDuplicates(items, groups, embeddings, 0.9)
+
+	// Assert
+	assert.Len(t, merged, 2)
+	assert.Equal(t, 1, merged[0].canonical)
+	assert.Len(t, merged[0].duplicates, 2)
+	assert.Equal(t, 0, merged[0].duplicates[0].index)
+	assert.Equal(t, contracts.DuplicateReasonSimilar, merged[0].duplicates[0].reason)
+	assert.InDelta(t, 0.995, merged[0].duplicates[0].similarity, 0.001)
+	assert.Equal(t, duplicateMember{index: 3, reason: contracts.DuplicateReasonURL}, merged[0].duplicates[1])
+	assert.Equal(t, duplicateGroup{canonical: 2}, merged[1])
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+)
+
+const (
+	// DefaultSubredditSuggestions is how many subreddits are suggested when the request does not say
+	DefaultSubredditSuggestions = 10
+
+	subredditSearchLimit       = 25
+	subredditAutocompleteLimit = 10
+	// maxAutocompleteWords caps the words of the topic that are autocompleted into subreddit names
+	maxAutocompleteWords = 3
+)
+
+// SubredditDiscoveryService suggests subreddits to search for a topic
+type SubredditDiscoveryService interface {
+	SuggestSubreddits(ctx context.Context, request contracts.SubredditSuggestionRequestDto) (contracts.SubredditSuggestionsResponseDto, error)
+}
+
+type subredditDiscoveryService struct {
+	logger        *zap.Logger
+	llmClient     llm.ClientInterface
+	redditService RedditService
+}
+
+func NewSubredditDiscoveryService(llmClient llm.ClientInterface, redditService RedditService, logger *zap.Logger) SubredditDiscoveryService {
+	return &subredditDiscoveryService{
+		logger:        logger,
+		llmClient:     llmClient,
+		redditService: redditService,
+	}
+}
+
+// SuggestSubreddits finds candidate subreddits with Reddit's subreddit search and name autocomplete,
+// then ranks them by the cosine similarity between their public description and the topic
+func (s *subredditDiscoveryService) SuggestSubreddits(ctx context.Context, request contracts.SubredditSuggestionRequestDto) (response contracts.SubredditSuggestionsResponseDto, err error) {
+	ctx, span := tracing.Start(ctx, "SubredditDiscoveryService.SuggestSubreddits", attribute.String("relevance.topic", request.Topic))
+	defer func() { tracing.End(span, err) }()
+
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Suggesting subreddits", zap.String("topic", request.Topic))
+
+	candidates, err := s.findCandidates(ctx, request.Topic)
+	if err != nil {
+		return contracts.SubredditSuggestionsResponseDto{}, errors.Wrap(err, "error finding subreddits")
+	}
+	span.SetAttributes(attribute.Int("reddit.subreddit_count", len(candidates)))
+
+	topicEmbedding, err := s.llmClient.GetEmbedding(ctx, request.Topic)
+	if err != nil {
+		return contracts.SubredditSuggestionsResponseDto{}, errors.Wrap(llmError(err), "error getting topic embedding")
+	}
+
+	suggestions := make([]contracts.SubredditSuggestionDto, 0, len(candidates))
+	for _, candidate := range candidates {
+		embedding, err := s.llmClient.GetEmbedding(ctx, SubredditEmbeddingText(candidate))
+		if err != nil {
+			return contracts.SubredditSuggestionsResponseDto{}, errors.Wrap(llmError(err), "error getting subreddit embedding")
+		}
+		suggestions = append(suggestions, MapSubredditToSuggestionDto(candidate, CosineSimilarity(embedding, topicEmbedding)))
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].RelevanceScore != suggestions[j].RelevanceScore {
+			return suggestions[i].RelevanceScore > suggestions[j].RelevanceScore
+		}
+		return suggestions[i].Subscribers > suggestions[j].Subscribers
+	})
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DefaultSubredditSuggestions
+	}
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	log.Info("Subreddits suggested", zap.Int("count", len(suggestions)))
+	return contracts.SubredditSuggestionsResponseDto{Suggestions: suggestions}, nil
+}
+
+// findCandidates returns the public, safe for work subreddits found by searching the topic and
+// autocompleting its words, without duplicates. Autocomplete only widens the search, so its
+// failures are logged and skipped.
+func (s *subredditDiscoveryService) findCandidates(ctx context.Context, topic string) ([]reddit.SubredditData, error) {
+	listings := make([]*reddit.SubredditListing, 0, 1+maxAutocompleteWords)
+
+	searched, err := s.redditService.SearchSubreddits(ctx, topic, subredditSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	listings = append(listings, searched)
+
+	for _, word := range autocompleteWords(topic) {
+		autocompleted, err := s.redditService.AutocompleteSubreddits(ctx, word, subredditAutocompleteLimit)
+		if err != nil {
+			logger.FromContext(ctx, s.logger).Warn("Error autocompleting subreddits, skipping", zap.String("query", word), zap.Error(err))
+			continue
+		}
+		listings = append(listings, autocompleted)
+	}
+
+	seen := make(map[string]bool)
+	candidates := make([]reddit.SubredditData, 0)
+	for _, listing := range listings {
+		if listing == nil {
+			continue
+		}
+		for _, child := range listing.Data.Children {
+			subreddit := child.Data
+			key := strings.ToLower(subreddit.DisplayName)
+			if child.Kind != "t5" || !suggestible(subreddit) || seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, subreddit)
+		}
+	}
+	return candidates, nil
+}
+
+// suggestible excludes NSFW subreddits, user profiles and subreddits whose posts cannot be read
+func suggestible(subreddit reddit.SubredditData) bool {
+	if subreddit.DisplayName == "" || subreddit.Over18 || strings.HasPrefix(subreddit.DisplayName, "u_") {
+		return false
+	}
+	switch subreddit.SubredditType {
+	case "", "public", "restricted", "archived":
+		return true
+	default:
+		return false
+	}
+}
+
+// autocompleteWords returns the distinct words of the topic worth autocompleting into subreddit names
+func autocompleteWords(topic string) []string {
+	words := make([]string, 0, maxAutocompleteWords)
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(topic)) {
+		word = strings.Trim(word, `"'.,;:!?()[]`)
+		if len(word) < 3 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+		if len(words) == maxAutocompleteWords {
+			break
+		}
+	}
+	return words
+}
+
+// SubredditEmbeddingText returns the text that is embedded for a subreddit
+func SubredditEmbeddingText(subreddit reddit.SubredditData) string {
+	return fmt.Sprintf("r/%s: %s. %s", subreddit.DisplayName, subreddit.Title, subreddit.PublicDescription)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
+	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
+)
+
+func subredditListing(subreddits ...reddit.SubredditData) *reddit.SubredditListing {
+	listing := &reddit.SubredditListing{}
+	for _, subreddit := range subreddits {
+		listing.Data.Children = append(listing.Data.Children, reddit.SubredditChild{Kind: "t5", Data: subreddit})
+	}
+	return listing
+}
+
+// ============================================================================
+// SubredditDiscoveryService Tests
+// ============================================================================
+
+func TestSubredditDiscoveryService_SuggestSubreddits(t *testing.T) {
+	golang := reddit.SubredditData{DisplayName: "golang", Title: "The Go Programming Language", PublicDescription: "Ask questions and post articles about Go", Subscribers: 250000, URL: "/r/golang/"}
+	programming := reddit.SubredditData{DisplayName: "programming", Title: "programming", PublicDescription: "Computer programming", Subscribers: 6000000, URL: "/r/programming/"}
+	nsfw := reddit.SubredditData{DisplayName: "gonewild", Over18: true}
+	private := reddit.SubredditData{DisplayName: "golang_private", SubredditType: "private"}
+
+	t.Run("RanksBySimilarity", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		mockRedditService := mock_services.NewMockRedditService(t)
+		service := NewSubredditDiscoveryService(mockLLMClient, mockRedditService, zap.NewNop())
+
+		mockRedditService.EXPECT().SearchSubreddits(mock.Anything, "golang generics", subredditSearchLimit).
+			Return(subredditListing(programming, nsfw, golang), nil)
+		mockRedditService.EXPECT().AutocompleteSubreddits(mock.Anything, "golang", subredditAutocompleteLimit).
+			Return(subredditListing(golang, private), nil)
+		mockRedditService.EXPECT().AutocompleteSubreddits(mock.Anything, "generics", subredditAutocompleteLimit).
+			Return(nil, errors.New("autocomplete unavailable"))
+
+		mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "golang generics").Return([]float32{1, 0}, nil)
+		mockLLMClient.EXPECT().GetEmbedding(mock.Anything, SubredditEmbeddingText(programming)).Return([]float32{1, 1}, nil)
+		mockLLMClient.EXPECT().GetEmbedding(mock.Anything, SubredditEmbeddingText(golang)).Return([]float32{1, 0.1}, nil)
+
+		// Act
+		response, err := service.SuggestSubreddits(ctx, contracts.SubredditSuggestionRequestDto{Topic: "golang generics"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Suggestions, 2)
+		assert.Equal(t, "golang", response.Suggestions[0].Name)
+		assert.Equal(t, "https://www.reddit.com/r/golang/", response.Suggestions[0].Url)
+		assert.Equal(t, "programming", response.Suggestions[1].Name)
+		assert.Greater(t, response.Suggestions[0].RelevanceScore, response.Suggestions[1].RelevanceScore)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		mockRedditService := mock_services.NewMockRedditService(t)
+		service := NewSubredditDiscoveryService(mockLLMClient, mockRedditService, zap.NewNop())
+
+		mockRedditService.EXPECT().SearchSubreddits(mock.Anything, "go", subredditSearchLimit).
+			Return(subredditListing(golang, programming), nil)
+		mockLLMClient.EXPECT().GetEmbedding(mock.Anything, mock.Anything).Return([]float32{1, 0}, nil)
+
+		// Act
+		response, err := service.SuggestSubreddits(context.Background(), contracts.SubredditSuggestionRequestDto{Topic: "go", Limit: 1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Suggestions, 1)
+		// Equal scores are ranked by subscribers
+		assert.Equal(t, "programming", response.Suggestions[0].Name)
+	})
+
+	t.Run("SearchError", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		mockRedditService := mock_services.NewMockRedditService(t)
+		service := NewSubredditDiscoveryService(mockLLMClient, mockRedditService, zap.NewNop())
+
+		mockRedditService.EXPECT().SearchSubreddits(mock.Anything, "go", subredditSearchLimit).
+			Return(nil, apperrors.New(apperrors.CodeRedditRateLimited, "Reddit rate limit reached"))
+
+		// Act
+		_, err := service.SuggestSubreddits(context.Background(), contracts.SubredditSuggestionRequestDto{Topic: "go"})
+
+		// Assert
+		assert.ErrorContains(t, err, "error finding subreddits")
+		assert.Equal(t, apperrors.CodeRedditRateLimited, apperrors.CodeOf(err))
+	})
+
+	t.Run("EmbeddingError", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		mockRedditService := mock_services.NewMockRedditService(t)
+		service := NewSubredditDiscoveryService(mockLLMClient, mockRedditService, zap.NewNop())
+
+		mockRedditService.EXPECT().SearchSubreddits(mock.Anything, "go", subredditSearchLimit).
+			Return(subredditListing(golang), nil)
+		mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "go").Return(nil, errors.New("embedding service unavailable"))
+
+		// Act
+		_, err := service.SuggestSubreddits(context.Background(), contracts.SubredditSuggestionRequestDto{Topic: "go"})
+
+		// Assert
+		assert.ErrorContains(t, err, "error getting topic embedding")
+		assert.Equal(t, apperrors.CodeLLMUnavailable, apperrors.CodeOf(err))
+	})
+}
+
+func TestAutocompleteWords(t *testing.T) {
+	// Act
+	words := autocompleteWords(`Go "Generics", go generics in rust, python or kotlin`)
+
+	// Assert
+	assert.Equal(t, []string{"generics", "rust", "python"}, words)
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/article"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+)
+
+// ContentEnricher adds content fetched from elsewhere to items, in place
+type ContentEnricher interface {
+	Enrich(ctx context.Context, items []ContentItem)
+}
+
+// ContentEnrichers runs every enricher, in order
+type ContentEnrichers []ContentEnricher
+
+func (e ContentEnrichers) Enrich(ctx context.Context, items []ContentItem) {
+	for _, enricher := range e {
+		enricher.Enrich(ctx, items)
+	}
+}
+
+const (
+	// DefaultEnricherCacheTTL is how long a fetched result, or the failure to fetch it, is reused
+	DefaultEnricherCacheTTL = time.Hour
+	// DefaultEnricherCacheSize caps the results kept in the cache, the oldest are evicted first
+	DefaultEnricherCacheSize = 1000
+	// DefaultEnricherConcurrency caps the results fetched at the same time for a request
+	DefaultEnricherConcurrency = 4
+)
+
+// EnricherOptions configures the cache and concurrency of an enricher. Zero values use the defaults.
+type EnricherOptions struct {
+	CacheTTL    time.Duration
+	CacheSize   int
+	Concurrency int
+}
+
+func (o EnricherOptions) withDefaults() EnricherOptions {
+	if o.CacheTTL <= 0 {
+		o.CacheTTL = DefaultEnricherCacheTTL
+	}
+	if o.CacheSize <= 0 {
+		o.CacheSize = DefaultEnricherCacheSize
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultEnricherConcurrency
+	}
+	return o
+}
+
+// runConcurrently calls fn with every index below count, at most limit at a time
+func runConcurrently(count, limit int, fn func(i int)) {
+	semaphore := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// LinkEnricher fetches the pages link posts link to and adds their title and readable text to
+// the posts, so link posts are not judged from their title alone
+type LinkEnricher struct {
+	client      article.ClientInterface
+	logger      *zap.Logger
+	concurrency int
+	cache       *ttlCache[*article.Article]
+	observe     func(hit bool)
+}
+
+// NewLinkEnricher creates a link enricher that fetches pages with client
+func NewLinkEnricher(client article.ClientInterface, options EnricherOptions, logger *zap.Logger) *LinkEnricher {
+	options = options.withDefaults()
+	return &LinkEnricher{
+		client:      client,
+		logger:      logger,
+		concurrency: options.Concurrency,
+		cache:       newTTLCache[*article.Article](options.CacheTTL, options.CacheSize, time.Now),
+	}
+}
+
+// ObserveCache registers fn to be called on every page lookup with whether the cache was used.
+// It must be called before the enricher is used.
+func (e *LinkEnricher) ObserveCache(fn func(hit bool)) {
+	e.observe = fn
+}
+
+// Enrich sets the link title and link text of the link posts among items. Pages that cannot be
+// fetched are logged and leave their post as it is.
+func (e *LinkEnricher) Enrich(ctx context.Context, items []ContentItem) {
+	// Posts linking to the same page share one fetch
+	linksByURL := make(map[string][]int)
+	urls := make([]string, 0)
+	for i, item := range items {
+		if linkDomain(item) == "" {
+			continue
+		}
+		key := canonicalURL(item.URL)
+		if _, found := linksByURL[key]; !found {
+			urls = append(urls, key)
+		}
+		linksByURL[key] = append(linksByURL[key], i)
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "LinkEnricher.Enrich", attribute.Int("links.count", len(urls)))
+	defer tracing.End(span, nil)
+
+	runConcurrently(len(urls), e.concurrency, func(u int) {
+		links := linksByURL[urls[u]]
+		page := e.getArticle(ctx, urls[u], items[links[0]].URL)
+		if page == nil {
+			return
+		}
+		for _, i := range links {
+			items[i].LinkText = page.Text
+			if items[i].LinkTitle == "" {
+				items[i].LinkTitle = page.Title
+			}
+		}
+	})
+}
+
+// getArticle returns the page at pageURL from the cache, by its canonical URL key, or fetches it.
+// It returns nil when the page cannot be fetched.
+func (e *LinkEnricher) getArticle(ctx context.Context, key, pageURL string) *article.Article {
+	page, hit := e.cache.get(key)
+	if e.observe != nil {
+		e.observe(hit)
+	}
+	if hit {
+		return page
+	}
+
+	page, err := e.client.GetArticle(ctx, pageURL)
+	if err != nil {
+		log := logger.FromContext(ctx, e.logger)
+		if errors.Is(err, article.ErrNotAllowed) || errors.Is(err, article.ErrDisallowedByRobots) || errors.Is(err, article.ErrNotHTML) {
+			log.Debug("Skipping linked page", zap.String("url", pageURL), zap.Error(err))
+		} else {
+			log.Warn("Error fetching linked page", zap.String("url", pageURL), zap.Error(err))
+		}
+		// A canceled request says nothing about the page, so it is not cached
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+	e.cache.set(key, page)
+	return page
+}
+
+// ttlCache keeps values, and the zero value for the lookups that failed, for a while
+type ttlCache[V any] struct {
+	ttl     time.Duration
+	size    int
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]ttlCacheEntry[V]
+	order   []string
+}
+
+type ttlCacheEntry[V any] struct {
+	value    V
+	cachedAt time.Time
+}
+
+func newTTLCache[V any](ttl time.Duration, size int, now func() time.Time) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:     ttl,
+		size:    size,
+		now:     now,
+		entries: make(map[string]ttlCacheEntry[V]),
+	}
+}
+
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, found := c.entries[key]
+	if !found || c.now().Sub(entry.cachedAt) >= c.ttl {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache[V]) set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.entries[key]; !found {
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = ttlCacheEntry[V]{value: value, cachedAt: c.now()}
+	for len(c.order) > c.size {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/article"
+)
+
+const linkedPage = `<html><head><title>Range over function types</title></head>
+<body><nav>Blog</nav><article><p>Go 1.23 lets a for loop range over iterator functions.</p></article></body></html>`
+
+// linkedPageServer serves linkedPage at /blog and 404 elsewhere, and counts the page requests
+func linkedPageServer(t *testing.T, pageRequests *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		pageRequests.Add(1)
+		if r.URL.Path != "/blog" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(linkedPage))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// LinkEnricher Tests
+// ============================================================================
+
+func TestLinkEnricher_Enrich(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("LinkPostsOnly", func(t *testing.T) {
+		// Arrange
+		var pageRequests atomic.Int32
+		server := linkedPageServer(t, &pageRequests)
+		enricher := NewLinkEnricher(article.NewTestClient(article.Options{}), EnricherOptions{}, zap.NewNop())
+		items := []ContentItem{
+			{ID: "1", Title: "Iterators", URL: server.URL + "/blog"},
+			{ID: "2", Title: "Iterators?", Body: "How do they work?", URL: server.URL + "/blog"},
+			{ID: "3", Title: "Video", URL: server.URL + "/blog", LinkTitle: "GopherCon talk"},
+		}
+
+		// Act
+		enricher.Enrich(ctx, items)
+
+		// Assert
+		assert.Equal(t, "Range over function types", items[0].LinkTitle)
+		assert.Equal(t, "Go 1.23 lets a for loop range over iterator functions.", items[0].LinkText)
+		assert.Empty(t, items[1].LinkText)
+		// Titles known from the source are kept
+		assert.Equal(t, "GopherCon talk", items[2].LinkTitle)
+		assert.NotEmpty(t, items[2].LinkText)
+		// Both link posts link to the same page
+		assert.Equal(t, int32(1), pageRequests.Load())
+	})
+
+	t.Run("CachesFailures", func(t *testing.T) {
+		// Arrange
+		var pageRequests atomic.Int32
+		server := linkedPageServer(t, &pageRequests)
+		enricher := NewLinkEnricher(article.NewTestClient(article.Options{}), EnricherOptions{}, zap.NewNop())
+		hits := make([]bool, 0)
+		enricher.ObserveCache(func(hit bool) { hits = append(hits, hit) })
+		item := ContentItem{Title: "Gone", URL: server.URL + "/missing"}
+
+		// Act
+		first := []ContentItem{item}
+		enricher.Enrich(ctx, first)
+		second := []ContentItem{item}
+		enricher.Enrich(ctx, second)
+
+		// Assert
+		assert.Equal(t, item, first[0])
+		assert.Equal(t, item, second[0])
+		assert.Equal(t, int32(1), pageRequests.Load())
+		assert.Equal(t, []bool{false, true}, hits)
+	})
+}
+
+func TestTTLCache(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC)
+	cache := newTTLCache[*article.Article](time.Hour, 2, func() time.Time { return now })
+	page := &article.Article{Title: "Page"}
+
+	// Act
+	cache.set("a", page)
+	cache.set("b", nil)
+	cache.set("c", page)
+	_, evicted := cache.get("a")
+	failure, failureCached := cache.get("b")
+	now = now.Add(time.Hour)
+	_, expired := cache.get("c")
+
+	// Assert
+	assert.False(t, evicted)
+	assert.True(t, failureCached)
+	assert.Nil(t, failure)
+	assert.False(t, expired)
+}
@@ -1,29 +1,194 @@
 package services
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/feed"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/hackernews"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/lemmy"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/runstore"
 )
 
-func MapRedditResponseToSubredditPostDto(
-	post reddit.RedditChild,
-	subredditName string,
-	relevanceScore float64,
+func MapContentItemToPostDto(
+	item ContentItem,
+	relevance PostRelevance,
 	isRelevant bool,
 	relevanceSummary string,
 ) contracts.SubRedditPostDto {
 	return contracts.SubRedditPostDto{
-		SubredditName:    subredditName,
-		Title:            post.Data.Title,
-		Content:          post.Data.Selftext,
-		Url:              post.Data.URL,
-		Score:            post.Data.Score,
-		NumComments:      post.Data.NumComments,
-		CreatedAt:        time.Unix(int64(post.Data.CreatedUTC), 0),
+		ID:               item.ID,
+		SubredditName:    item.Community,
+		Author:           item.Author,
+		Title:            item.Title,
+		Content:          item.Body,
+		Url:              item.URL,
+		Score:            item.Score,
+		NumComments:      item.NumComments,
+		CreatedAt:        item.Created,
 		IsRelevant:       isRelevant,
-		RelevanceScore:   relevanceScore,
+		RelevanceScore:   relevance.Score,
 		RelevanceSummary: relevanceSummary,
+		TopicScores:      relevance.TopicScores,
+		ExclusionScores:  relevance.ExclusionScores,
+		IsExcluded:       relevance.IsExcluded,
+		Media:            MapMediaToDto(item.Media),
+	}
+}
+
+// MapSentimentToDto maps the sentiment of a post and totals the labels of its comments
+func MapSentimentToDto(sentiment PostSentiment) *contracts.SentimentDto {
+	dto := &contracts.SentimentDto{
+		Sentiment: sentiment.Post.Sentiment,
+		Stance:    sentiment.Post.Stance,
+	}
+	if len(sentiment.Comments) > 0 {
+		dto.Comments = &contracts.SentimentBreakdownDto{}
+		for _, comment := range sentiment.Comments {
+			countSentiment(dto.Comments, comment)
+		}
+	}
+	return dto
+}
+
+// MapRelevanceResponseToRun maps the posts of a search to a run of its topics, created at createdAt
+func MapRelevanceResponseToRun(id string, topics []string, createdAt time.Time, response contracts.RelevanceResponseDto) runstore.Run {
+	posts := make([]runstore.Post, 0, len(response.Posts))
+	for _, post := range response.Posts {
+		stored := runstore.Post{
+			ID:             post.ID,
+			URL:            post.Url,
+			Subreddit:      post.SubredditName,
+			CreatedAt:      post.CreatedAt,
+			IsRelevant:     post.IsRelevant,
+			RelevanceScore: post.RelevanceScore,
+			Score:          post.Score,
+			NumComments:    post.NumComments,
+		}
+		if post.Sentiment != nil {
+			stored.Sentiment = string(post.Sentiment.Sentiment)
+			stored.Stance = string(post.Sentiment.Stance)
+		}
+		posts = append(posts, stored)
+	}
+	return runstore.Run{
+		ID:        id,
+		Topics:    topics,
+		CreatedAt: createdAt.UTC(),
+		Posts:     posts,
+	}
+}
+
+func MapRedditResponseToContentItems(posts *reddit.RedditResponse) []ContentItem {
+	items := make([]ContentItem, 0, len(posts.Data.Children))
+	for _, post := range posts.Data.Children {
+		items = append(items, MapRedditPostToContentItem(post))
+	}
+	return items
+}
+
+// MapRedditPostToContentItem maps a Reddit post, crossposts have the origin of their original post
+func MapRedditPostToContentItem(post reddit.RedditChild) ContentItem {
+	originID := post.Data.CrosspostParent
+	if originID == "" && post.Data.ID != "" {
+		originID = "t3_" + post.Data.ID
+	}
+	linkTitle := ""
+	if post.Data.Media != nil && post.Data.Media.Oembed != nil {
+		linkTitle = post.Data.Media.Oembed.Title
+	}
+	return ContentItem{
+		ID:          post.Data.ID,
+		Title:       post.Data.Title,
+		Body:        post.Data.Selftext,
+		URL:         post.Data.URL,
+		Author:      post.Data.Author,
+		Score:       post.Data.Score,
+		NumComments: post.Data.NumComments,
+		Created:     time.Unix(int64(post.Data.CreatedUTC), 0),
+		Community:   post.Data.Subreddit,
+		OriginID:    originID,
+		LinkTitle:   linkTitle,
+		Media:       MapRedditMedia(post.Data),
+	}
+}
+
+// MapHackerNewsHitToContentItem maps a Hacker News item, text posts link to their item page
+func MapHackerNewsHitToContentItem(hit hackernews.Hit) ContentItem {
+	url := hit.URL
+	if url == "" {
+		url = "https://news.ycombinator.com/item?id=" + hit.ObjectID
+	}
+	return ContentItem{
+		ID:          hit.ObjectID,
+		Title:       hit.Title,
+		Body:        hit.StoryText,
+		URL:         url,
+		Author:      hit.Author,
+		Score:       hit.Points,
+		NumComments: hit.NumComments,
+		Created:     time.Unix(hit.CreatedAtI, 0),
+		Community:   HackerNewsCommunity,
+	}
+}
+
+// MapFeedItemToContentItem maps a feed item, feeds have no score nor comment count
+func MapFeedItemToContentItem(item feed.Item, feedTitle string) ContentItem {
+	return ContentItem{
+		ID:        item.ID,
+		Title:     item.Title,
+		Body:      item.Content,
+		URL:       item.Link,
+		Author:    item.Author,
+		Created:   item.Published,
+		Community: feedTitle,
+	}
+}
+
+// MapLemmyPostToContentItem maps a Lemmy post, text posts link to their post page
+func MapLemmyPostToContentItem(post lemmy.PostView) ContentItem {
+	url := post.Post.URL
+	if url == "" {
+		url = post.Post.ApID
+	}
+	return ContentItem{
+		ID:          strconv.Itoa(post.Post.ID),
+		Title:       post.Post.Name,
+		Body:        post.Post.Body,
+		URL:         url,
+		Author:      post.Creator.Name,
+		Score:       post.Counts.Score,
+		NumComments: post.Counts.Comments,
+		Created:     lemmy.ParseTime(post.Post.Published),
+		Community:   post.Community.Name,
+	}
+}
+
+func MapDuplicateToDto(item ContentItem, source string, reason contracts.DuplicateReason, similarity float64) contracts.DuplicatePostDto {
+	return contracts.DuplicatePostDto{
+		ID:            item.ID,
+		SubredditName: item.Community,
+		Source:        source,
+		Title:         item.Title,
+		Url:           item.URL,
+		Score:         item.Score,
+		NumComments:   item.NumComments,
+		Reason:        reason,
+		Similarity:    similarity,
+	}
+}
+
+func MapSubredditToSuggestionDto(subreddit reddit.SubredditData, relevanceScore float64) contracts.SubredditSuggestionDto {
+	return contracts.SubredditSuggestionDto{
+		Name:           subreddit.DisplayName,
+		Title:          subreddit.Title,
+		Description:    subreddit.PublicDescription,
+		Url:            "https://www.reddit.com" + subreddit.URL,
+		Subscribers:    subreddit.Subscribers,
+		ActiveUsers:    subreddit.ActiveUserCount,
+		CreatedAt:      time.Unix(int64(subreddit.CreatedUTC), 0),
+		RelevanceScore: relevanceScore,
 	}
 }
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+)
+
+// Media is the images or video of a media post and the text found about them
+type Media struct {
+	Kind  contracts.MediaKind
+	Items []MediaItem
+	// Description is the description of linked media, such as the oEmbed description of a video
+	Description string
+	// PreviewURL is the image the multimodal model captions: the image itself, the first image of
+	// a gallery or the thumbnail of a video
+	PreviewURL string
+	// GeneratedCaption is the caption of the preview written by the multimodal model
+	GeneratedCaption string
+}
+
+// MediaItem is an image or video of a post
+type MediaItem struct {
+	URL string
+	// Caption is the gallery caption or alt text of the item
+	Caption string
+}
+
+// mediaLabels introduce the text about the media in the embedding text
+var mediaLabels = map[contracts.MediaKind]string{
+	contracts.MediaKindImage:   "Image",
+	contracts.MediaKindGallery: "Gallery",
+	contracts.MediaKindVideo:   "Video",
+}
+
+// mediaText returns the captions, description and generated caption of media, introduced by its
+// kind, or an empty string when nothing is known about it
+func mediaText(media *Media) string {
+	if media == nil {
+		return ""
+	}
+	texts := make([]string, 0, len(media.Items)+2)
+	for _, item := range media.Items {
+		if caption := CleanText(item.Caption); caption != "" {
+			texts = append(texts, caption)
+		}
+	}
+	for _, text := range []string{media.Description, media.GeneratedCaption} {
+		if text = CleanText(text); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	if len(texts) == 0 {
+		return ""
+	}
+	return mediaLabels[media.Kind] + ": " + strings.Join(texts, "\n")
+}
+
+// imageExtensions are the extensions of the URLs of image posts Reddit does not hint
+var imageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true}
+
+// markdownInlineImagePattern matches ![alt](target "title"), Reddit writes inline images as
+// ![img](mediaID "caption")
+var markdownInlineImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(\s*([^)\s]+)(?:\s+"([^"]*)")?\s*\)`)
+
+// MapRedditMedia detects the image, gallery and video posts of a Reddit payload. It returns nil
+// for the posts without media.
+func MapRedditMedia(data reddit.RedditPostData) *Media {
+	preview := ""
+	if data.Preview != nil && len(data.Preview.Images) > 0 {
+		preview = html.UnescapeString(data.Preview.Images[0].Source.URL)
+	}
+
+	switch {
+	case data.IsGallery && data.GalleryData != nil:
+		media := &Media{Kind: contracts.MediaKindGallery}
+		for _, item := range data.GalleryData.Items {
+			metadata, found := data.MediaMetadata[item.MediaID]
+			if !found {
+				continue
+			}
+			itemURL := mediaMetadataURL(metadata)
+			if itemURL == "" {
+				continue
+			}
+			media.Items = append(media.Items, MediaItem{URL: itemURL, Caption: item.Caption})
+			if media.PreviewURL == "" && metadata.Kind == "Image" {
+				media.PreviewURL = itemURL
+			}
+		}
+		return media
+
+	case data.IsVideo || data.PostHint == "hosted:video":
+		videoURL := data.URL
+		if data.Media != nil && data.Media.RedditVideo != nil && data.Media.RedditVideo.FallbackURL != "" {
+			videoURL = html.UnescapeString(data.Media.RedditVideo.FallbackURL)
+		}
+		return &Media{Kind: contracts.MediaKindVideo, Items: []MediaItem{{URL: videoURL}}, PreviewURL: preview}
+
+	case data.PostHint == "rich:video":
+		media := &Media{Kind: contracts.MediaKindVideo, Items: []MediaItem{{URL: data.URL}}, PreviewURL: preview}
+		if data.Media != nil && data.Media.Oembed != nil {
+			media.Description = data.Media.Oembed.Description
+			if media.PreviewURL == "" {
+				media.PreviewURL = html.UnescapeString(data.Media.Oembed.ThumbnailURL)
+			}
+		}
+		return media
+
+	case data.PostHint == "image" || isImageURL(data.URL):
+		return &Media{Kind: contracts.MediaKindImage, Items: []MediaItem{{URL: data.URL}}, PreviewURL: data.URL}
+	}
+	return inlineImages(data)
+}
+
+// inlineImages returns the images of a text post, with their alt text or caption, or nil when
+// it has none
+func inlineImages(data reddit.RedditPostData) *Media {
+	matches := markdownInlineImagePattern.FindAllStringSubmatch(data.Selftext, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	media := &Media{Kind: contracts.MediaKindImage}
+	for _, match := range matches {
+		alt, target, title := match[1], match[2], match[3]
+		itemURL := target
+		if metadata, found := data.MediaMetadata[target]; found {
+			itemURL = mediaMetadataURL(metadata)
+		}
+		caption := title
+		// Reddit names the inline images it uploads img or gif, which says nothing about them
+		if caption == "" && alt != "img" && alt != "gif" {
+			caption = alt
+		}
+		media.Items = append(media.Items, MediaItem{URL: itemURL, Caption: caption})
+		if media.PreviewURL == "" && isImageURL(itemURL) {
+			media.PreviewURL = itemURL
+		}
+	}
+	return media
+}
+
+// mediaMetadataURL returns the URL of the file of a gallery or inline image, the animation for
+// animated images
+func mediaMetadataURL(metadata reddit.RedditMediaMetadata) string {
+	for _, candidate := range []string{metadata.Source.URL, metadata.Source.Gif, metadata.Source.MP4} {
+		if candidate != "" {
+			return html.UnescapeString(candidate)
+		}
+	}
+	return ""
+}
+
+func isImageURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return imageExtensions[strings.ToLower(path.Ext(parsed.Path))]
+}
+
+// MapMediaToDto maps the media of a post to its response, nil for posts without media
+func MapMediaToDto(media *Media) *contracts.MediaDto {
+	if media == nil {
+		return nil
+	}
+	dto := &contracts.MediaDto{
+		Kind:             media.Kind,
+		Description:      media.Description,
+		GeneratedCaption: media.GeneratedCaption,
+	}
+	for _, item := range media.Items {
+		dto.Items = append(dto.Items, contracts.MediaItemDto{Url: item.URL, Caption: item.Caption})
+	}
+	return dto
+}
+
+// captionPrompt asks for a caption of the image of a post, with the title of the post for context
+const captionPrompt = `Describe this image in one or two sentences for someone who cannot see it. ` +
+	`Transcribe any text, code or chart labels that matter. The image was posted with the title %q.`
+
+// MediaCaptioner captions the preview image of media posts with a multimodal model, so image and
+// video posts are not scored from their title alone
+type MediaCaptioner struct {
+	captioner   llm.CaptionerInterface
+	logger      *zap.Logger
+	concurrency int
+	cache       *ttlCache[string]
+	observe     func(hit bool)
+}
+
+// NewMediaCaptioner creates a media captioner that captions images with captioner
+func NewMediaCaptioner(captioner llm.CaptionerInterface, options EnricherOptions, logger *zap.Logger) *MediaCaptioner {
+	options = options.withDefaults()
+	return &MediaCaptioner{
+		captioner:   captioner,
+		logger:      logger,
+		concurrency: options.Concurrency,
+		cache:       newTTLCache[string](options.CacheTTL, options.CacheSize, time.Now),
+	}
+}
+
+// ObserveCache registers fn to be called on every caption lookup with whether the cache was used.
+// It must be called before the captioner is used.
+func (c *MediaCaptioner) ObserveCache(fn func(hit bool)) {
+	c.observe = fn
+}
+
+// Enrich sets the generated caption of the media posts among items. Images that cannot be
+// captioned are logged and leave their post as it is.
+func (c *MediaCaptioner) Enrich(ctx context.Context, items []ContentItem) {
+	captioned := make([]int, 0)
+	for i, item := range items {
+		if item.Media != nil && item.Media.PreviewURL != "" && item.Media.GeneratedCaption == "" {
+			captioned = append(captioned, i)
+		}
+	}
+	if len(captioned) == 0 {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "MediaCaptioner.Enrich", attribute.Int("media.count", len(captioned)))
+	defer tracing.End(span, nil)
+
+	runConcurrently(len(captioned), c.concurrency, func(j int) {
+		item := &items[captioned[j]]
+		caption := c.caption(ctx, item.Media.PreviewURL, item.Title)
+		if caption != "" {
+			// The media may be shared with the duplicates of the item
+			media := *item.Media
+			media.GeneratedCaption = caption
+			item.Media = &media
+		}
+	})
+}
+
+// caption returns the caption of the image at imageURL from the cache, or asks the model for it.
+// It returns an empty string when the image cannot be captioned.
+func (c *MediaCaptioner) caption(ctx context.Context, imageURL, title string) string {
+	caption, hit := c.cache.get(imageURL)
+	if c.observe != nil {
+		c.observe(hit)
+	}
+	if hit {
+		return caption
+	}
+
+	caption, err := c.captioner.CaptionImage(ctx, imageURL, fmt.Sprintf(captionPrompt, title))
+	if err != nil {
+		logger.FromContext(ctx, c.logger).Warn("Error captioning image", zap.String("image_url", imageURL), zap.Error(err))
+		// A canceled request says nothing about the image, so it is not cached
+		if ctx.Err() != nil {
+			return ""
+		}
+	}
+	caption = strings.TrimSpace(caption)
+	c.cache.set(imageURL, caption)
+	return caption
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
+)
+
+// ============================================================================
+// MapRedditMedia Tests
+// ============================================================================
+
+func TestMapRedditMedia(t *testing.T) {
+	t.Run("Gallery", func(t *testing.T) {
+		// Arrange
+		data, err := os.ReadFile("testdata/media/gallery.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var post reddit.RedditPostData
+		if err := json.Unmarshal(data, &post); err != nil {
+			t.Fatal(err)
+		}
+
+		// Act
+		media := MapRedditMedia(post)
+
+		// Assert
+		assert.Equal(t, &Media{
+			Kind: contracts.MediaKindGallery,
+			Items: []MediaItem{
+				{URL: "https://preview.redd.it/abc.png?width=800&format=png", Caption: "Before: one helper per type"},
+				{URL: "https://i.redd.it/def.gif", Caption: "After: a single generic Map"},
+			},
+			PreviewURL: "https://preview.redd.it/abc.png?width=800&format=png",
+		}, media)
+	})
+
+	preview := &reddit.RedditPreview{Images: []reddit.RedditPreviewImage{{}}}
+	preview.Images[0].Source.URL = "https://external-preview.redd.it/thumb.jpg?s=1&amp;t=2"
+
+	tests := []struct {
+		name     string
+		post     reddit.RedditPostData
+		expected *Media
+	}{
+		{
+			"HostedVideo",
+			reddit.RedditPostData{
+				URL: "https://v.redd.it/v1", IsVideo: true, Preview: preview,
+				Media: &reddit.RedditMedia{RedditVideo: &reddit.RedditVideo{FallbackURL: "https://v.redd.it/v1/DASH_720.mp4?source=fallback"}},
+			},
+			&Media{
+				Kind:       contracts.MediaKindVideo,
+				Items:      []MediaItem{{URL: "https://v.redd.it/v1/DASH_720.mp4?source=fallback"}},
+				PreviewURL: "https://external-preview.redd.it/thumb.jpg?s=1&t=2",
+			},
+		},
+		{
+			"EmbeddedVideo",
+			reddit.RedditPostData{
+				URL: "https://youtube.com/watch?v=1", PostHint: "rich:video",
+				Media: &reddit.RedditMedia{Oembed: &reddit.RedditOembed{
+					Title: "GopherCon 2024", Description: "A talk on iterators", ThumbnailURL: "https://i.ytimg.com/vi/1/hqdefault.jpg",
+				}},
+			},
+			&Media{
+				Kind:        contracts.MediaKindVideo,
+				Items:       []MediaItem{{URL: "https://youtube.com/watch?v=1"}},
+				Description: "A talk on iterators",
+				PreviewURL:  "https://i.ytimg.com/vi/1/hqdefault.jpg",
+			},
+		},
+		{
+			"ImageByHint",
+			reddit.RedditPostData{URL: "https://i.redd.it/i1", PostHint: "image"},
+			&Media{Kind: contracts.MediaKindImage, Items: []MediaItem{{URL: "https://i.redd.it/i1"}}, PreviewURL: "https://i.redd.it/i1"},
+		},
+		{
+			"ImageByExtension",
+			reddit.RedditPostData{URL: "https://i.imgur.com/chart.PNG"},
+			&Media{Kind: contracts.MediaKindImage, Items: []MediaItem{{URL: "https://i.imgur.com/chart.PNG"}}, PreviewURL: "https://i.imgur.com/chart.PNG"},
+		},
+		{
+			"InlineImages",
+			reddit.RedditPostData{
+				Selftext: "Benchmarks:\n\n![img](m1 \"Allocations per op\")\n\n![flame graph of the parser](https://example.com/flame.svg)",
+				MediaMetadata: map[string]reddit.RedditMediaMetadata{
+					"m1": {Kind: "Image", Source: struct {
+						URL string `json:"u"`
+						Gif string `json:"gif"`
+						MP4 string `json:"mp4"`
+					}{URL: "https://preview.redd.it/m1.png?width=640&amp;auto=webp"}},
+				},
+			},
+			&Media{
+				Kind: contracts.MediaKindImage,
+				Items: []MediaItem{
+					{URL: "https://preview.redd.it/m1.png?width=640&auto=webp", Caption: "Allocations per op"},
+					{URL: "https://example.com/flame.svg", Caption: "flame graph of the parser"},
+				},
+				PreviewURL: "https://preview.redd.it/m1.png?width=640&auto=webp",
+			},
+		},
+		{"TextPost", reddit.RedditPostData{Selftext: "No images here", URL: "https://www.reddit.com/r/golang/comments/t1/"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			media := MapRedditMedia(tt.post)
+
+			// Assert
+			assert.Equal(t, tt.expected, media)
+		})
+	}
+}
+
+// ============================================================================
+// MediaCaptioner Tests
+// ============================================================================
+
+func TestMediaCaptioner_Enrich(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CaptionsPreviews", func(t *testing.T) {
+		// Arrange
+		mockCaptioner := mock_llm.NewMockCaptionerInterface(t)
+		mockCaptioner.EXPECT().CaptionImage(mock.Anything, "https://i.redd.it/chart.png", mock.MatchedBy(func(prompt string) bool {
+			return strings.Contains(prompt, `"Go vs Rust compile times"`)
+		})).Return(" A bar chart of compile times. ", nil).Once()
+		captioner := NewMediaCaptioner(mockCaptioner, EnricherOptions{}, zap.NewNop())
+		hits := make([]bool, 0)
+		captioner.ObserveCache(func(hit bool) { hits = append(hits, hit) })
+		shared := &Media{Kind: contracts.MediaKindImage, PreviewURL: "https://i.redd.it/chart.png"}
+		items := []ContentItem{
+			{Title: "Go vs Rust compile times", Media: shared},
+			{Title: "Text post"},
+			{Title: "Video without preview", Media: &Media{Kind: contracts.MediaKindVideo}},
+		}
+
+		// Act
+		captioner.Enrich(ctx, items)
+		again := []ContentItem{{Title: "Go vs Rust compile times", Media: shared}}
+		captioner.Enrich(ctx, again)
+
+		// Assert
+		assert.Equal(t, "A bar chart of compile times.", items[0].Media.GeneratedCaption)
+		assert.Equal(t, "A bar chart of compile times.", again[0].Media.GeneratedCaption)
+		// The media of the source item is left as it was
+		assert.Empty(t, shared.GeneratedCaption)
+		assert.Nil(t, items[1].Media)
+		assert.Empty(t, items[2].Media.GeneratedCaption)
+		assert.Equal(t, []bool{false, true}, hits)
+	})
+
+	t.Run("CaptionError", func(t *testing.T) {
+		// Arrange
+		mockCaptioner := mock_llm.NewMockCaptionerInterface(t)
+		mockCaptioner.EXPECT().CaptionImage(mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("model not loaded")).Once()
+		captioner := NewMediaCaptioner(mockCaptioner, EnricherOptions{}, zap.NewNop())
+		items := []ContentItem{{Title: "Chart", Media: &Media{Kind: contracts.MediaKindImage, PreviewURL: "https://i.redd.it/c.png"}}}
+
+		// Act
+		captioner.Enrich(ctx, items)
+		captioner.Enrich(ctx, items)
+
+		// Assert
+		assert.Empty(t, items[0].Media.GeneratedCaption)
+	})
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ChunkStrategy is how the scores of the chunks of a post are combined into the post score
+type ChunkStrategy string
+
+const (
+	// ChunkStrategyMax scores a post as its best chunk
+	ChunkStrategyMax ChunkStrategy = "max"
+	// ChunkStrategyMean scores a post as the mean of its chunks
+	ChunkStrategyMean ChunkStrategy = "mean"
+	// ChunkStrategyTitleWeighted embeds the title alone and adds its score, weighted by the title
+	// weight, to the mean of the chunks
+	ChunkStrategyTitleWeighted ChunkStrategy = "title_weighted"
+)
+
+// Valid reports whether s is a known strategy, or empty for the default one
+func (s ChunkStrategy) Valid() bool {
+	switch s {
+	case "", ChunkStrategyMax, ChunkStrategyMean, ChunkStrategyTitleWeighted:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	// DefaultChunkSize is the number of words of a chunk, which stays under the 512 tokens of
+	// common embedding models along with the title
+	DefaultChunkSize = 256
+	// DefaultChunkOverlap is the number of words consecutive chunks share
+	DefaultChunkOverlap = 32
+	// DefaultMaxChunks caps the chunks embedded per post, the rest of the text is not scored
+	DefaultMaxChunks = 8
+	// DefaultTitleWeight is the share of the title score with the title weighted strategy
+	DefaultTitleWeight = 0.3
+)
+
+// PreprocessOptions configures how posts are cleaned, chunked and scored. Zero values use the defaults.
+type PreprocessOptions struct {
+	ChunkSize    int
+	ChunkOverlap int
+	MaxChunks    int
+	Strategy     ChunkStrategy
+	TitleWeight  float64
+}
+
+func (o PreprocessOptions) withDefaults() PreprocessOptions {
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = DefaultChunkSize
+	}
+	if o.ChunkOverlap <= 0 || o.ChunkOverlap >= o.ChunkSize {
+		o.ChunkOverlap = min(DefaultChunkOverlap, o.ChunkSize/2)
+	}
+	if o.MaxChunks <= 0 {
+		o.MaxChunks = DefaultMaxChunks
+	}
+	if o.Strategy == "" {
+		o.Strategy = ChunkStrategyMax
+	}
+	if o.TitleWeight <= 0 || o.TitleWeight > 1 {
+		o.TitleWeight = DefaultTitleWeight
+	}
+	return o
+}
+
+var (
+	htmlBreakPattern = regexp.MustCompile(`(?i)<(br\s*/?|/p|/li|/div|/h[1-6]|/pre)>`)
+	htmlTagPattern   = regexp.MustCompile(`</?[A-Za-z][A-Za-z0-9]*(\s[^<>]*)?/?>`)
+
+	markdownImagePattern   = regexp.MustCompile(`!\[([^\]]*)\]\([^)\s][^)]*\)`)
+	markdownLinkPattern    = regexp.MustCompile(`\[([^\]]+)\]\([^)\s][^)]*\)`)
+	bareURLPattern         = regexp.MustCompile(`https?://\S+`)
+	markdownFencePattern   = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	markdownHeadingPattern = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s*`)
+	markdownQuotePattern   = regexp.MustCompile(`(?m)^\s*(>\s?)+`)
+	markdownListPattern    = regexp.MustCompile(`(?m)^\s*([-*+]|\d+[.)])\s+`)
+	markdownRulePattern    = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$|^\s*\|?(\s*:?-{3,}:?\s*\|)+\s*:?-*:?\s*$`)
+	markdownBoldPattern    = regexp.MustCompile(`(\*\*|__)(\S(.*?\S)?)(\*\*|__)`)
+	markdownItalicPattern  = regexp.MustCompile(`\*(\S([^*]*?\S)?)\*`)
+	markdownStrikePattern  = regexp.MustCompile(`~~(.+?)~~`)
+	markdownCodePattern    = regexp.MustCompile("`([^`]*)`")
+	redditSpoilerPattern   = regexp.MustCompile(`>!(.+?)!<`)
+)
+
+// boilerplatePatterns match the lines that say nothing about the topic of a post
+var boilerplatePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(edit|update)\s*\d*\s*:?\s*(fixed\s+)?(typos?|formatting|spelling)[.!]*$`),
+	regexp.MustCompile(`(?i)^(thanks|thank you|thx|ty|cheers)(\s+(in advance|all|everyone|guys|for reading|for any help))?[.!]*$`),
+	regexp.MustCompile(`(?i)^(sorry|apologies) (for|about) (the |my )?(formatting|format|mobile|bad english|english|long post|wall of text).*$`),
+	regexp.MustCompile(`(?i)^\[(deleted|removed)\]$`),
+	regexp.MustCompile(`(?i)^(i am a bot|this action was performed automatically).*$`),
+	regexp.MustCompile(`(?i)^(posted|sent) from my .*$`),
+}
+
+// CleanText turns the Markdown or HTML of a post into plain text for embedding. It removes tags,
+// HTML entities, Markdown markup, URLs and boilerplate lines such as "Thanks in advance", and
+// collapses whitespace.
+func CleanText(text string) string {
+	text = htmlBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.NewReplacer("\u200b", "", "\ufeff", "", "\u00a0", " ").Replace(text)
+
+	text = redditSpoilerPattern.ReplaceAllString(text, "$1")
+	text = markdownImagePattern.ReplaceAllString(text, "$1")
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+	text = bareURLPattern.ReplaceAllString(text, "")
+	text = markdownFencePattern.ReplaceAllString(text, "")
+	text = markdownRulePattern.ReplaceAllString(text, "")
+	text = markdownHeadingPattern.ReplaceAllString(text, "")
+	text = markdownQuotePattern.ReplaceAllString(text, "")
+	text = markdownListPattern.ReplaceAllString(text, "")
+	text = markdownBoldPattern.ReplaceAllString(text, "$2")
+	text = markdownItalicPattern.ReplaceAllString(text, "$1")
+	text = markdownStrikePattern.ReplaceAllString(text, "$1")
+	text = markdownCodePattern.ReplaceAllString(text, "$1")
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" || isBoilerplate(line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isBoilerplate(line string) bool {
+	for _, pattern := range boilerplatePatterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChunkWords splits text into chunks of size words, consecutive chunks sharing overlap words.
+// It returns at most maxChunks chunks, and a single empty chunk for text without words.
+func ChunkWords(text string, size, overlap, maxChunks int) []string {
+	words := strings.Fields(text)
+	if len(words) <= size {
+		return []string{strings.Join(words, " ")}
+	}
+
+	step := size - overlap
+	if step <= 0 {
+		step = size
+	}
+	chunks := make([]string, 0, min(maxChunks, len(words)/step+1))
+	for start := 0; len(chunks) < maxChunks; start += step {
+		end := min(start+size, len(words))
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return chunks
+}
+
+// nonLinkHosts are the hosts whose URLs point back to the post itself rather than to a linked page
+var nonLinkHosts = map[string]bool{
+	"reddit.com":           true,
+	"old.reddit.com":       true,
+	"np.reddit.com":        true,
+	"news.ycombinator.com": true,
+	// Images and videos hosted by Reddit, described by the media of the post instead
+	"i.redd.it":       true,
+	"v.redd.it":       true,
+	"preview.redd.it": true,
+}
+
+// linkDomain returns the domain a link post links to, or an empty string for posts with a body
+// or whose URL points back to the post
+func linkDomain(item ContentItem) string {
+	if strings.TrimSpace(item.Body) != "" {
+		return ""
+	}
+	parsed, err := url.Parse(item.URL)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host == "" || nonLinkHosts[host] {
+		return ""
+	}
+	return host
+}
+
+// summaryLinkWords caps the words of a linked page given to the summarization model
+const summaryLinkWords = 500
+
+// PostEmbeddingBody returns the cleaned body of the item. Link posts, which have no body, are
+// described by the title of the linked page, when known, its domain and its text, when fetched.
+// The captions and descriptions of the media of media posts follow.
+func PostEmbeddingBody(item ContentItem) string {
+	body := CleanText(item.Body)
+	if domain := linkDomain(item); domain != "" {
+		body = "Link to " + domain
+		if linkTitle := CleanText(item.LinkTitle); linkTitle != "" {
+			body += ": " + linkTitle
+		}
+		if linkText := CleanText(item.LinkText); linkText != "" {
+			body += "\n" + linkText
+		}
+	}
+	return joinLines(body, mediaText(item.Media))
+}
+
+// PostSummaryContent returns the content the summary of the item is based on: its body, or the
+// beginning of the linked page for link posts whose page was fetched, and the text about its media
+func PostSummaryContent(item ContentItem) string {
+	content := item.Body
+	if content == "" && item.LinkText != "" {
+		content = ChunkWords(item.LinkText, summaryLinkWords, 0, 1)[0]
+	}
+	return joinLines(content, mediaText(item.Media))
+}
+
+// joinLines joins the non-empty texts with line breaks
+func joinLines(texts ...string) string {
+	lines := make([]string, 0, len(texts))
+	for _, text := range texts {
+		if text != "" {
+			lines = append(lines, text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
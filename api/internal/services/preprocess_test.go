@@ -0,0 +1,138 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+)
+
+// ============================================================================
+// CleanText Tests
+// ============================================================================
+
+func TestCleanText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"PlainText", "Type parameters vs interfaces. When do you reach for which?", "Type parameters vs interfaces. When do you reach for which?"},
+		{"HTML", "<p>Generics &amp; iterators</p><p>in <b>Go</b>&nbsp;1.23</p>", "Generics & iterators\nin Go 1.23"},
+		{"MarkdownLinksAndImages", "See [the release notes](https://go.dev/doc/go1.23) and ![a gopher](gopher.png)", "See the release notes and a gopher"},
+		{"BareURL", "Details at https://go.dev/blog/range-functions today", "Details at today"},
+		{"HeadingsListsAndQuotes", "# Question\n> quoted\n- first\n2. second", "Question\nquoted\nfirst\nsecond"},
+		{"Emphasis", "**bold**, *italic*, ~~gone~~, `code` and >!spoiler!<", "bold, italic, gone, code and spoiler"},
+		{"CodeFence", "```go\nfunc Map[T any]()\n```", "func Map[T any]()"},
+		{"Math", "2^10 * 3 = 3072", "2^10 * 3 = 3072"},
+		{"Boilerplate", "How do I constrain T?\n\nThanks in advance!\nEdit: typo\nSorry for formatting", "How do I constrain T?"},
+		{"Whitespace", "  many \t spaces​  \n\n\n", "many spaces"},
+		{"Empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			cleaned := CleanText(tt.text)
+
+			// Assert
+			assert.Equal(t, tt.expected, cleaned)
+		})
+	}
+}
+
+// ============================================================================
+// ChunkWords Tests
+// ============================================================================
+
+func TestChunkWords(t *testing.T) {
+	words := func(from, to int) string {
+		parts := make([]string, 0, to-from)
+		for i := from; i < to; i++ {
+			parts = append(parts, string(rune('a'+i)))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		size      int
+		overlap   int
+		maxChunks int
+		expected  []string
+	}{
+		{"ShortText", words(0, 3), 4, 1, 8, []string{words(0, 3)}},
+		{"Overlap", words(0, 10), 4, 1, 8, []string{words(0, 4), words(3, 7), words(6, 10)}},
+		{"MaxChunks", words(0, 10), 4, 1, 2, []string{words(0, 4), words(3, 7)}},
+		{"NoWords", " \n ", 4, 1, 8, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			chunks := ChunkWords(tt.text, tt.size, tt.overlap, tt.maxChunks)
+
+			// Assert
+			assert.Equal(t, tt.expected, chunks)
+		})
+	}
+}
+
+// ============================================================================
+// PostEmbeddingBody Tests
+// ============================================================================
+
+func TestPostEmbeddingBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     ContentItem
+		expected string
+	}{
+		{"TextPost", ContentItem{Body: "**Generics** are here", URL: "https://www.reddit.com/r/golang/comments/a1/"}, "Generics are here"},
+		{"LinkPost", ContentItem{URL: "https://www.go.dev/blog/range-functions"}, "Link to go.dev"},
+		{"LinkPostWithTitle", ContentItem{URL: "https://youtube.com/watch?v=1", LinkTitle: "GopherCon 2024: Iterators"}, "Link to youtube.com: GopherCon 2024: Iterators"},
+		{"LinkPostWithText", ContentItem{URL: "https://go.dev/blog", LinkTitle: "Go Blog", LinkText: "Range over func\nIterators"}, "Link to go.dev: Go Blog\nRange over func\nIterators"},
+		{"PostWithoutBody", ContentItem{URL: "https://old.reddit.com/r/golang/comments/a1/"}, ""},
+		{"ImagePost", ContentItem{URL: "https://i.redd.it/chart.png", Media: &Media{
+			Kind:             contracts.MediaKindGallery,
+			Items:            []MediaItem{{Caption: "Before"}, {}, {Caption: "**After**"}},
+			GeneratedCaption: "A bar chart.",
+		}}, "Gallery: Before\nAfter\nA bar chart."},
+		{"TextPostWithImage", ContentItem{Body: "Benchmarks", Media: &Media{Kind: contracts.MediaKindImage, Items: []MediaItem{{Caption: "Allocations"}}}}, "Benchmarks\nImage: Allocations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			body := PostEmbeddingBody(tt.item)
+
+			// Assert
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
+
+func TestPostSummaryContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     ContentItem
+		expected string
+	}{
+		{"TextPost", ContentItem{Body: "Generics are here", LinkText: "ignored"}, "Generics are here"},
+		{"LinkPostWithText", ContentItem{URL: "https://go.dev/blog", LinkText: "Range over\nfunc"}, "Range over func"},
+		{"LinkPost", ContentItem{URL: "https://go.dev/blog"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			content := PostSummaryContent(tt.item)
+
+			// Assert
+			assert.Equal(t, tt.expected, content)
+		})
+	}
+}
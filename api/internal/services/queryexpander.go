@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+)
+
+const (
+	// DefaultMaxSearchQueries is how many search queries a topic is expanded into when the request does not say
+	DefaultMaxSearchQueries = 3
+	// maxSearchQueryLength is the longest query Reddit search accepts
+	maxSearchQueryLength = 512
+	// defaultSearchLimit is how many posts Reddit returns when no limit is given
+	defaultSearchLimit = 25
+)
+
+// QueryExpander rewrites topics into Reddit search queries that also match posts using other vocabulary
+type QueryExpander interface {
+	Expand(ctx context.Context, topics, exclusions []string, maxQueries int) ([]string, error)
+}
+
+type llmQueryExpander struct {
+	llmClient llm.ClientInterface
+	logger    *zap.Logger
+}
+
+// NewLLMQueryExpander creates a query expander that asks the chat model for synonyms and
+// Reddit search syntax
+func NewLLMQueryExpander(llmClient llm.ClientInterface, logger *zap.Logger) QueryExpander {
+	return &llmQueryExpander{
+		llmClient: llmClient,
+		logger:    logger,
+	}
+}
+
+// Expand returns at most maxQueries distinct queries for the topics, as answered by the chat model
+func (e *llmQueryExpander) Expand(ctx context.Context, topics, exclusions []string, maxQueries int) (queries []string, err error) {
+	ctx, span := tracing.Start(ctx, "QueryExpander.Expand", attribute.StringSlice("relevance.topics", topics))
+	defer func() { tracing.End(span, err) }()
+
+	prompt := fmt.Sprintf(`Rewrite the following topics into at most %d Reddit search queries that find posts about them, including posts that use different vocabulary. Use synonyms and related terms, quote exact phrases, and combine alternatives with OR and required terms with AND.
+Answer with a JSON array of query strings and nothing else.
+
+# Topics: %s
+`, maxQueries, quotedTopics(topics, ", "))
+	if len(exclusions) > 0 {
+		prompt += fmt.Sprintf("# Avoid posts about: %s\n", quotedTopics(exclusions, ", "))
+	}
+
+	response, err := e.llmClient.Chat(ctx, []llm.Message{
+		{
+			Role:    "user",
+			Content: prompt,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting chat response")
+	}
+
+	queries = parseSearchQueries(response, maxQueries)
+	if len(queries) == 0 {
+		return nil, errors.Errorf("no search queries in chat response %q", response)
+	}
+	span.SetAttributes(attribute.StringSlice("reddit.search_queries", queries))
+	logger.FromContext(ctx, e.logger).Info("Search queries expanded", zap.Strings("queries", queries))
+	return queries, nil
+}
+
+// parseSearchQueries reads the JSON array of queries in response, tolerating code fences and
+// text around it, and falls back to one query per line when there is no array
+func parseSearchQueries(response string, maxQueries int) []string {
+	var candidates []string
+	start, end := strings.Index(response, "["), strings.LastIndex(response, "]")
+	if start < 0 || end < start || json.Unmarshal([]byte(response[start:end+1]), &candidates) != nil {
+		candidates = nil
+		for _, line := range strings.Split(response, "\n") {
+			line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*0123456789.) "))
+			if line != "" && !strings.HasPrefix(line, "```") {
+				candidates = append(candidates, line)
+			}
+		}
+	}
+	return mergeSearchQueries(nil, candidates, maxQueries)
+}
+
+// mergeSearchQueries appends the candidates that are not in queries yet, up to maxQueries queries
+func mergeSearchQueries(queries, candidates []string, maxQueries int) []string {
+	seen := make(map[string]bool, len(queries)+len(candidates))
+	for _, query := range queries {
+		seen[strings.ToLower(query)] = true
+	}
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if len(queries) >= maxQueries {
+			break
+		}
+		if candidate == "" || len(candidate) > maxSearchQueryLength || seen[strings.ToLower(candidate)] {
+			continue
+		}
+		seen[strings.ToLower(candidate)] = true
+		queries = append(queries, candidate)
+	}
+	return queries
+}
+
+// mergeSearchResults interleaves the results of the queries by rank, so every query contributes
+// its best items, and drops the items found before. It returns at most limit items along with
+// the queries that found each of them, by post key.
+func mergeSearchResults(queries []string, results [][]ContentItem, limit int) ([]ContentItem, map[string][]string) {
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	merged := make([]ContentItem, 0, limit)
+	matchedBy := make(map[string]map[int]bool)
+
+	for rank := 0; ; rank++ {
+		exhausted := true
+		for i, result := range results {
+			if rank >= len(result) {
+				continue
+			}
+			exhausted = false
+			item := result[rank]
+			key := postKey(item)
+			if _, found := matchedBy[key]; !found {
+				if len(merged) >= limit {
+					continue
+				}
+				merged = append(merged, item)
+				matchedBy[key] = make(map[int]bool)
+			}
+			matchedBy[key][i] = true
+		}
+		if exhausted {
+			break
+		}
+	}
+
+	// List the queries of each item in the order they were run
+	matchedQueries := make(map[string][]string, len(matchedBy))
+	for key, indexes := range matchedBy {
+		for i, query := range queries {
+			if indexes[i] {
+				matchedQueries[key] = append(matchedQueries[key], query)
+			}
+		}
+	}
+	return merged, matchedQueries
+}
+
+// postKey identifies an item by its ID, or by its URL and title for sources without IDs
+func postKey(item ContentItem) string {
+	if item.ID != "" {
+		return item.ID
+	}
+	return item.URL + "\n" + item.Title
+}
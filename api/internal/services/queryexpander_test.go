@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
+)
+
+// ============================================================================
+// QueryExpander Tests
+// ============================================================================
+
+func TestLLMQueryExpander_Expand(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		expander := NewLLMQueryExpander(mockLLMClient, zap.NewNop())
+
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+			return strings.Contains(messages[0].Content, `# Topics: "go generics"`) &&
+				strings.Contains(messages[0].Content, `# Avoid posts about: "job postings"`)
+		})).Return("```json\n[\"\\\"type parameters\\\" AND golang\", \"go generics OR golang generics\"]\n```", nil)
+
+		// Act
+		queries, err := expander.Expand(ctx, []string{"go generics"}, []string{"job postings"}, 3)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`"type parameters" AND golang`, "go generics OR golang generics"}, queries)
+	})
+
+	t.Run("ChatError", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		expander := NewLLMQueryExpander(mockLLMClient, zap.NewNop())
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("", errors.New("chat service unavailable"))
+
+		// Act
+		_, err := expander.Expand(context.Background(), []string{"go generics"}, nil, 3)
+
+		// Assert
+		assert.ErrorContains(t, err, "error getting chat response")
+	})
+
+	t.Run("NoQueries", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		expander := NewLLMQueryExpander(mockLLMClient, zap.NewNop())
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("[]", nil)
+
+		// Act
+		_, err := expander.Expand(context.Background(), []string{"go generics"}, nil, 3)
+
+		// Assert
+		assert.ErrorContains(t, err, "no search queries")
+	})
+}
+
+func TestParseSearchQueries(t *testing.T) {
+	t.Run("JSONArrayWithText", func(t *testing.T) {
+		// Act
+		queries := parseSearchQueries(`Here you go: ["a", "b", "A", "c"]`, 2)
+
+		// Assert
+		assert.Equal(t, []string{"a", "b"}, queries)
+	})
+
+	t.Run("OneQueryPerLine", func(t *testing.T) {
+		// Act
+		queries := parseSearchQueries("1. golang generics\n- \"type parameters\" AND go\n\n", 3)
+
+		// Assert
+		assert.Equal(t, []string{"golang generics", `"type parameters" AND go`}, queries)
+	})
+}
+
+// ============================================================================
+// mergeSearchResults Tests
+// ============================================================================
+
+func TestMergeSearchResults(t *testing.T) {
+	listing := func(ids ...string) []ContentItem {
+		items := make([]ContentItem, 0, len(ids))
+		for _, id := range ids {
+			items = append(items, ContentItem{ID: id})
+		}
+		return items
+	}
+	ids := func(items []ContentItem) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+
+	t.Run("InterleavesAndDeduplicates", func(t *testing.T) {
+		// Act
+		merged, matched := mergeSearchResults(
+			[]string{"q1", "q2"},
+			[][]ContentItem{listing("a", "b", "c"), listing("b", "d")},
+			10,
+		)
+
+		// Assert
+		assert.Equal(t, []string{"a", "b", "d", "c"}, ids(merged))
+		assert.Equal(t, []string{"q1", "q2"}, matched["b"])
+		assert.Equal(t, []string{"q2"}, matched["d"])
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		// Act
+		merged, matched := mergeSearchResults(
+			[]string{"q1", "q2"},
+			[][]ContentItem{listing("a", "b", "c"), listing("d", "a", "e")},
+			3,
+		)
+
+		// Assert
+		assert.Equal(t, []string{"a", "d", "b"}, ids(merged))
+		assert.Equal(t, []string{"q1", "q2"}, matched["a"])
+		assert.NotContains(t, matched, "e")
+	})
+}
@@ -1,70 +1,180 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
 	"go.uber.org/zap"
 
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
 )
 
 type RedditService interface {
-	GetPosts(subreddit string, limit int) (*reddit.RedditResponse, error)
-	SearchPosts(subreddit string, query string, limit int) (*reddit.RedditResponse, error)
+	GetPosts(ctx context.Context, subreddit string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error)
+	SearchPosts(ctx context.Context, subreddit string, query string, limit int, options reddit.SearchOptions) (*reddit.RedditResponse, error)
+	GetUserPosts(ctx context.Context, username string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error)
+	GetDomainPosts(ctx context.Context, domain string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error)
+	SearchSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error)
+	AutocompleteSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error)
+	GetComments(ctx context.Context, postID string, limit int) (*reddit.CommentListing, error)
 }
 
 type redditService struct {
-	client reddit.Client
+	client reddit.ClientInterface
 	logger *zap.Logger
 }
 
-func NewRedditService() RedditService {
-	logger := logger.GetLogger()
-	client := reddit.NewClient()
+func NewRedditService(client reddit.ClientInterface, logger *zap.Logger) RedditService {
 	return &redditService{
-		client: *client,
+		client: client,
 		logger: logger,
 	}
 }
 
-func (s *redditService) GetPosts(subreddit string, limit int) (*reddit.RedditResponse, error) {
-	s.logger.Info(
+func (s *redditService) GetPosts(ctx context.Context, subreddit string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	log := logger.FromContext(ctx, s.logger)
+	log.Info(
 		"Getting Reddit posts",
 		zap.String("subreddit", subreddit),
 		zap.Int("limit", limit),
+		zap.String("sort", string(options.Sort)),
+		zap.String("time", string(options.Time)),
 	)
 
-	posts, err := s.client.GetPosts(subreddit, limit)
+	posts, err := s.client.GetPosts(ctx, subreddit, limit, options)
 	if err != nil {
-		s.logger.Error("Error getting Reddit posts", zap.Error(err))
-		return nil, err
+		log.Error("Error getting Reddit posts", zap.Error(err))
+		return nil, redditError(subreddit, err)
 	}
 
-	s.logger.Info(
-		"Reddit posts found",
-		zap.Any("posts", posts),
-		zap.Int("count", len(posts.Data.Children)),
-	)
+	log.Info("Reddit posts found", zap.Int("count", len(posts.Data.Children)))
+	log.Debug("Reddit posts payload", zap.Any("posts", posts))
 	return posts, nil
 }
 
-func (s *redditService) SearchPosts(subreddit string, query string, limit int) (*reddit.RedditResponse, error) {
-	s.logger.Info(
+func (s *redditService) SearchPosts(ctx context.Context, subreddit string, query string, limit int, options reddit.SearchOptions) (*reddit.RedditResponse, error) {
+	log := logger.FromContext(ctx, s.logger)
+	log.Info(
 		"Searching Reddit posts",
 		zap.String("subreddit", subreddit),
 		zap.String("query", query),
 		zap.Int("limit", limit),
+		zap.String("sort", string(options.Sort)),
+		zap.String("time", string(options.Time)),
 	)
 
-	posts, err := s.client.SearchPosts(subreddit, query, limit)
+	posts, err := s.client.SearchPosts(ctx, subreddit, query, limit, options)
 	if err != nil {
-		s.logger.Error("Error searching Reddit posts", zap.Error(err))
-		return nil, err
+		log.Error("Error searching Reddit posts", zap.Error(err))
+		return nil, redditError(subreddit, err)
 	}
 
-	s.logger.Info(
-		"Reddit search results found",
-		zap.Any("posts", posts),
-		zap.Int("count", len(posts.Data.Children)),
-	)
+	log.Info("Reddit search results found", zap.Int("count", len(posts.Data.Children)))
+	log.Debug("Reddit search results payload", zap.Any("posts", posts))
+	return posts, nil
+}
+
+func (s *redditService) GetUserPosts(ctx context.Context, username string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Getting Reddit user posts", zap.String("user", username), zap.Int("limit", limit), zap.String("sort", string(options.Sort)))
+
+	posts, err := s.client.GetUserPosts(ctx, username, limit, options)
+	if err != nil {
+		log.Error("Error getting Reddit user posts", zap.Error(err))
+		return nil, sourceError(fmt.Sprintf("Reddit user u/%s does not exist or is suspended", username), err)
+	}
+
+	log.Info("Reddit user posts found", zap.Int("count", len(posts.Data.Children)))
+	return posts, nil
+}
+
+func (s *redditService) GetDomainPosts(ctx context.Context, domain string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Getting Reddit domain posts", zap.String("domain", domain), zap.Int("limit", limit), zap.String("sort", string(options.Sort)))
+
+	posts, err := s.client.GetDomainPosts(ctx, domain, limit, options)
+	if err != nil {
+		log.Error("Error getting Reddit domain posts", zap.Error(err))
+		return nil, sourceError(fmt.Sprintf("Reddit has no listing for the domain %s", domain), err)
+	}
+
+	log.Info("Reddit domain posts found", zap.Int("count", len(posts.Data.Children)))
 	return posts, nil
 }
+
+func (s *redditService) SearchSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error) {
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Searching subreddits", zap.String("query", query), zap.Int("limit", limit))
+
+	listing, err := s.client.SearchSubreddits(ctx, query, limit)
+	if err != nil {
+		log.Error("Error searching subreddits", zap.Error(err))
+		return nil, redditError("", err)
+	}
+
+	log.Info("Subreddits found", zap.Int("count", len(listing.Data.Children)))
+	return listing, nil
+}
+
+func (s *redditService) AutocompleteSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error) {
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Autocompleting subreddits", zap.String("query", query), zap.Int("limit", limit))
+
+	listing, err := s.client.AutocompleteSubreddits(ctx, query, limit)
+	if err != nil {
+		log.Error("Error autocompleting subreddits", zap.Error(err))
+		return nil, redditError("", err)
+	}
+
+	log.Info("Subreddits autocompleted", zap.Int("count", len(listing.Data.Children)))
+	return listing, nil
+}
+
+func (s *redditService) GetComments(ctx context.Context, postID string, limit int) (*reddit.CommentListing, error) {
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Getting Reddit post comments", zap.String("post", postID), zap.Int("limit", limit))
+
+	comments, err := s.client.GetComments(ctx, postID, limit)
+	if err != nil {
+		log.Error("Error getting Reddit post comments", zap.Error(err))
+		return nil, sourceError(fmt.Sprintf("Reddit post %s does not exist", postID), err)
+	}
+
+	log.Info("Reddit post comments found", zap.Int("count", len(comments.Data.Children)))
+	return comments, nil
+}
+
+// redditError classifies a Reddit client failure. Reddit answers 404 for subreddits that do not
+// exist or are banned and 403 for private ones, which clients cannot tell apart either way.
+// subreddit is empty for calls that do not target a subreddit.
+func redditError(subreddit string, err error) error {
+	if subreddit == "" {
+		return classifyRedditError(err, apperrors.CodeRedditUnavailable, "Reddit could not be reached")
+	}
+	return classifyRedditError(err, apperrors.CodeSubredditNotFound, fmt.Sprintf("Subreddit r/%s does not exist or is private", subreddit))
+}
+
+// sourceError classifies a failure to list the posts of a user or domain, which Reddit answers
+// with 404 or 403 when the user does not exist or is suspended
+func sourceError(notFoundMessage string, err error) error {
+	return classifyRedditError(err, apperrors.CodeSourceNotFound, notFoundMessage)
+}
+
+func classifyRedditError(err error, notFoundCode apperrors.Code, notFoundMessage string) error {
+	var apiErr *reddit.APIError
+	if !errors.As(err, &apiErr) {
+		return apperrors.Wrap(err, apperrors.CodeRedditUnavailable, "Reddit could not be reached")
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusForbidden:
+		return apperrors.Wrap(err, notFoundCode, notFoundMessage)
+	case http.StatusTooManyRequests:
+		return apperrors.Wrap(err, apperrors.CodeRedditRateLimited, "Reddit is rate limiting requests, try again later")
+	default:
+		return apperrors.Wrap(err, apperrors.CodeRedditUnavailable, "Reddit could not be reached")
+	}
+}
@@ -1,12 +1,14 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -16,7 +18,7 @@ import (
 func newRedditServiceForTesting(baseURL string) *redditService {
 	testClient := reddit.NewTestClient(baseURL)
 	return &redditService{
-		client: *testClient,
+		client: testClient,
 		logger: zap.NewNop(),
 	}
 }
@@ -57,7 +59,7 @@ func TestRedditService_GetPosts(t *testing.T) {
 		limit := 5
 
 		// Act
-		result, err := service.GetPosts(subreddit, limit)
+		result, err := service.GetPosts(context.Background(), subreddit, limit, reddit.ListingOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -79,7 +81,7 @@ func TestRedditService_GetPosts(t *testing.T) {
 		limit := 5
 
 		// Act
-		result, err := service.GetPosts(subreddit, limit)
+		result, err := service.GetPosts(context.Background(), subreddit, limit, reddit.ListingOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -105,7 +107,7 @@ func TestRedditService_GetPosts(t *testing.T) {
 		limit := 5
 
 		// Act
-		result, err := service.GetPosts(subreddit, limit)
+		result, err := service.GetPosts(context.Background(), subreddit, limit, reddit.ListingOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -162,7 +164,7 @@ func TestRedditService_SearchPosts(t *testing.T) {
 		limit := 5
 
 		// Act
-		result, err := service.SearchPosts(subreddit, query, limit)
+		result, err := service.SearchPosts(context.Background(), subreddit, query, limit, reddit.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -186,7 +188,7 @@ func TestRedditService_SearchPosts(t *testing.T) {
 		limit := 5
 
 		// Act
-		result, err := service.SearchPosts(subreddit, query, limit)
+		result, err := service.SearchPosts(context.Background(), subreddit, query, limit, reddit.SearchOptions{})
 
 		// Assert
 		assert.Error(t, err)
@@ -213,7 +215,7 @@ func TestRedditService_SearchPosts(t *testing.T) {
 		limit := 5
 
 		// Act
-		result, err := service.SearchPosts(subreddit, query, limit)
+		result, err := service.SearchPosts(context.Background(), subreddit, query, limit, reddit.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -241,10 +243,74 @@ func TestRedditService_SearchPosts(t *testing.T) {
 		limit := 5
 
 		// Act
-		result, err := service.SearchPosts(subreddit, query, limit)
+		result, err := service.SearchPosts(context.Background(), subreddit, query, limit, reddit.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 	})
 }
+
+// ============================================================================
+// Error Classification Tests
+// ============================================================================
+
+func TestRedditService_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected apperrors.Code
+	}{
+		{"NotFound", http.StatusNotFound, apperrors.CodeSubredditNotFound},
+		{"Private", http.StatusForbidden, apperrors.CodeSubredditNotFound},
+		{"RateLimited", http.StatusTooManyRequests, apperrors.CodeRedditRateLimited},
+		{"ServerError", http.StatusInternalServerError, apperrors.CodeRedditUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			service := newRedditServiceForTesting(server.URL)
+
+			// Act
+			_, err := service.GetPosts(context.Background(), "technology", 5, reddit.ListingOptions{})
+
+			// Assert
+			assert.Equal(t, tt.expected, apperrors.CodeOf(err))
+		})
+	}
+
+	t.Run("Unreachable", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		service := newRedditServiceForTesting(server.URL)
+
+		// Act
+		_, err := service.SearchPosts(context.Background(), "technology", "golang", 5, reddit.SearchOptions{})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeRedditUnavailable, apperrors.CodeOf(err))
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		service := newRedditServiceForTesting(server.URL)
+
+		// Act
+		_, err := service.GetUserPosts(context.Background(), "nobody_here", 5, reddit.ListingOptions{})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeSourceNotFound, apperrors.CodeOf(err))
+		assert.Contains(t, err.Error(), "u/nobody_here")
+	})
+}
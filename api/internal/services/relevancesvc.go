@@ -3,14 +3,18 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
 )
 
 type RelevanceService interface {
@@ -21,126 +25,441 @@ type relevanceService struct {
 	logger        *zap.Logger
 	llmClient     llm.ClientInterface
 	redditService RedditService
+	subreddits    ContentSource
+	sources       ContentSources
+	scorer        RelevanceScorer
+	expander      QueryExpander
+	enricher      ContentEnricher
+	sentiment     SentimentAnalyzer
 }
 
-func NewRelevanceService() RelevanceService {
-	redditService := NewRedditService()
-	llmClient := llm.GetClient()
+// NewRelevanceService creates a relevance service of the subreddits, users and domains of Reddit
+// and of the sources outside Reddit, by prefix. Posts are cleaned and chunked as preprocess says, and
+// enriched by enricher before scoring unless it is nil.
+func NewRelevanceService(
+	llmClient llm.ClientInterface,
+	redditService RedditService,
+	sources ContentSources,
+	preprocess PreprocessOptions,
+	enricher ContentEnricher,
+	logger *zap.Logger,
+) RelevanceService {
 	return &relevanceService{
-		logger:        logger.GetLogger(),
+		logger:        logger,
 		llmClient:     llmClient,
 		redditService: redditService,
+		subreddits:    &redditSubredditSource{redditService: redditService},
+		sources:       redditContentSources(redditService, sources),
+		scorer:        NewEmbeddingScorerWithOptions(llmClient, preprocess),
+		expander:      NewLLMQueryExpander(llmClient, logger),
+		enricher:      enricher,
+		sentiment:     NewLLMSentimentAnalyzer(llmClient, logger),
 	}
 }
 
+// redditContentSources returns the user and domain sources of Reddit along with sources
+func redditContentSources(redditService RedditService, sources ContentSources) ContentSources {
+	all := ContentSources{
+		SourceUserPrefix:   &redditUserSource{redditService: redditService},
+		SourceDomainPrefix: &redditDomainSource{redditService: redditService},
+	}
+	for prefix, source := range sources {
+		all[prefix] = source
+	}
+	return all
+}
+
 func (s *relevanceService) GetRelevantPosts(ctx context.Context, request contracts.RelevanceRequestDto) (contracts.RelevanceResponseDto, error) {
-	s.logger.Info("Getting relevant posts", zap.Any("request", request))
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Getting relevant posts")
+	log.Debug("Relevance request payload", zap.Any("request", request))
+
+	query, err := s.newTopicQuery(ctx, request)
+	if err != nil {
+		return contracts.RelevanceResponseDto{}, err
+	}
+
+	var searchQueries []string
+	if request.SearchMethod == contracts.SearchMethodSearch {
+		searchQueries = s.searchQueries(ctx, request, query)
+	}
+
+	candidates := make([]candidatePost, 0)
+	for _, subreddit := range requestSubreddits(request) {
+		subredditPosts, matchedQueries, err := s.fetchSubredditPosts(ctx, subreddit, request, searchQueries)
+		if err != nil {
+			return contracts.RelevanceResponseDto{}, errors.Wrap(err, "error getting subreddit posts")
+		}
+		for _, item := range subredditPosts {
+			candidates = append(candidates, candidatePost{
+				item:           item,
+				source:         subredditSource(subreddit),
+				matchedQueries: matchedQueries[postKey(item)],
+				logField:       zap.String("subreddit", subreddit),
+			})
+		}
+	}
+
+	for _, source := range request.Sources {
+		sourcePosts, matchedQueries, err := s.fetchSourcePosts(ctx, source, request, searchQueries)
+		if err != nil {
+			return contracts.RelevanceResponseDto{}, errors.Wrap(err, "error getting source posts")
+		}
+		for _, item := range sourcePosts {
+			candidates = append(candidates, candidatePost{
+				item:           item,
+				source:         source,
+				matchedQueries: matchedQueries[postKey(item)],
+				logField:       zap.String("source", source),
+			})
+		}
+	}
+
+	candidates = filterCandidates(candidates, request)
+	subredditPostDtos, err := s.evaluatePosts(ctx, candidates, request, query)
+	if err != nil {
+		return contracts.RelevanceResponseDto{}, errors.Wrap(err, "error evaluating posts")
+	}
+
+	return contracts.RelevanceResponseDto{
+		Posts:                subredditPostDtos,
+		SearchQueries:        searchQueries,
+		SentimentBySubreddit: SentimentBySubreddit(subredditPostDtos),
+	}, nil
+}
+
+// searchQueries returns the Reddit search queries for the topics of the request. The topics
+// themselves are always searched, followed by the queries of the expander when the request asks
+// for them. A failing expander only narrows the search, so the request goes on without it.
+func (s *relevanceService) searchQueries(ctx context.Context, request contracts.RelevanceRequestDto, query *topicQuery) []string {
+	queries := []string{searchQuery(query.topics)}
+	if !request.ExpandQuery {
+		return queries
+	}
 
-	topicEmbedding, err := s.llmClient.GetEmbedding(ctx, request.Topic)
+	maxQueries := request.MaxSearchQueries
+	if maxQueries <= 0 {
+		maxQueries = DefaultMaxSearchQueries
+	}
+	expanded, err := s.expander.Expand(ctx, query.topics, query.exclusions, maxQueries)
 	if err != nil {
-		return contracts.RelevanceResponseDto{}, errors.Wrap(err, "error getting topic embedding")
+		logger.FromContext(ctx, s.logger).Warn("Error expanding search query, searching the topics only", zap.Error(err))
+		return queries
+	}
+	return mergeSearchQueries(queries, expanded, maxQueries)
+}
+
+// newTopicQuery embeds the topics and exclusion topics of the request
+func (s *relevanceService) newTopicQuery(ctx context.Context, request contracts.RelevanceRequestDto) (*topicQuery, error) {
+	query := &topicQuery{
+		topics:             RequestTopics(request),
+		exclusions:         distinctTopics(request.ExcludeTopics),
+		aggregation:        request.TopicAggregation,
+		relevanceThreshold: request.RelevanceThreshold,
+		exclusionThreshold: request.ExclusionThreshold,
+		exclusionPenalty:   request.ExclusionPenalty,
+	}
+	if len(query.topics) == 0 {
+		return nil, apperrors.Validation(nil, []apperrors.FieldError{{Field: "topic", Rule: "required", Message: "is required"}})
+	}
+	if query.exclusionThreshold == 0 {
+		query.exclusionThreshold = DefaultExclusionThreshold
+	}
+	if query.exclusionPenalty == 0 {
+		query.exclusionPenalty = DefaultExclusionPenalty
+	}
+
+	var err error
+	if query.topicEmbeddings, err = s.embedTopics(ctx, query.topics); err != nil {
+		return nil, errors.Wrap(err, "error getting topic embedding")
+	}
+	if query.exclusionEmbeddings, err = s.embedTopics(ctx, query.exclusions); err != nil {
+		return nil, errors.Wrap(err, "error getting exclusion topic embedding")
+	}
+	return query, nil
+}
+
+func (s *relevanceService) embedTopics(ctx context.Context, topics []string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(topics))
+	for _, topic := range topics {
+		embedding, err := s.llmClient.GetEmbedding(ctx, topic)
+		if err != nil {
+			return nil, llmError(err)
+		}
+		embeddings = append(embeddings, embedding)
 	}
+	return embeddings, nil
+}
 
-	subredditPostDtos := make([]contracts.SubRedditPostDto, 0)
-	for _, subreddit := range request.Subreddits {
-		var subredditPosts *reddit.RedditResponse
-		switch request.SearchMethod {
-		case contracts.SearchMethodSearch:
-			subredditPosts, err = s.redditService.SearchPosts(subreddit, request.Topic, request.Limit)
+// fetchSubredditPosts returns the posts of the subreddit for the search method of the request.
+// Searches also return the queries that found each post, by post key.
+func (s *relevanceService) fetchSubredditPosts(
+	ctx context.Context,
+	subreddit string,
+	request contracts.RelevanceRequestDto,
+	searchQueries []string,
+) (subredditPosts []ContentItem, matchedQueries map[string][]string, err error) {
+	ctx, span := tracing.Start(ctx, "RelevanceService.fetchSubredditPosts",
+		attribute.String("reddit.subreddit", subreddit),
+		attribute.String("reddit.search_method", string(request.SearchMethod)),
+		attribute.Int("reddit.limit", request.Limit),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	switch request.SearchMethod {
+	case contracts.SearchMethodSearch:
+		results := make([][]ContentItem, 0, len(searchQueries))
+		for _, searchQuery := range searchQueries {
+			result, err := s.subreddits.Fetch(ctx, subreddit, contentQuery(request, searchQuery))
 			if err != nil {
-				return contracts.RelevanceResponseDto{}, errors.Wrap(err, "error getting subreddit posts")
+				return nil, nil, err
 			}
-		case contracts.SearchMethodLatest:
-			subredditPosts, err = s.redditService.GetPosts(subreddit, request.Limit)
+			results = append(results, result)
+		}
+		subredditPosts, matchedQueries = mergeSearchResults(searchQueries, results, request.Limit)
+	default:
+		subredditPosts, err = s.subreddits.Fetch(ctx, subreddit, contentQuery(request, ""))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	span.SetAttributes(attribute.Int("reddit.post_count", len(subredditPosts)))
+	return subredditPosts, matchedQueries, nil
+}
+
+// fetchSourcePosts returns the items of a source of the request, for example user:{name} or
+// hackernews:{tag}. The sources that can search run every search query and their results are
+// merged as for subreddits; the others, such as Reddit users and feeds, are fetched once from
+// their listing.
+func (s *relevanceService) fetchSourcePosts(
+	ctx context.Context,
+	source string,
+	request contracts.RelevanceRequestDto,
+	searchQueries []string,
+) (sourcePosts []ContentItem, matchedQueries map[string][]string, err error) {
+	ctx, span := tracing.Start(ctx, "RelevanceService.fetchSourcePosts",
+		attribute.String("content.source", source),
+		attribute.Int("content.limit", request.Limit),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	contentSource, target, found := s.sources.lookup(source)
+	if !found {
+		return nil, nil, apperrors.Validation(nil, []apperrors.FieldError{{Field: "sources", Rule: "source", Message: SourceRuleMessage}})
+	}
+	if _, searches := contentSource.(searchingSource); searches && len(searchQueries) > 0 {
+		results := make([][]ContentItem, 0, len(searchQueries))
+		for _, searchQuery := range searchQueries {
+			result, err := contentSource.Fetch(ctx, target, contentQuery(request, searchQuery))
 			if err != nil {
-				return contracts.RelevanceResponseDto{}, errors.Wrap(err, "error getting subreddit posts")
+				return nil, nil, err
 			}
+			results = append(results, result)
 		}
+		sourcePosts, matchedQueries = mergeSearchResults(searchQueries, results, request.Limit)
+	} else if sourcePosts, err = contentSource.Fetch(ctx, target, contentQuery(request, "")); err != nil {
+		return nil, nil, err
+	}
+	span.SetAttributes(attribute.Int("content.post_count", len(sourcePosts)))
+	return sourcePosts, matchedQueries, nil
+}
 
-		evalSubredditPostDtos, err := s.evaluateSubredditPosts(
-			ctx,
-			subreddit,
-			subredditPosts,
-			request.Topic,
-			topicEmbedding,
-			request.RelevanceThreshold,
-		)
-		if err != nil {
-			return contracts.RelevanceResponseDto{}, errors.Wrap(err, "error evaluating subreddit posts")
+// requestSubreddits returns the subreddits to fetch for the scope of the request, one Reddit
+// request each. A multireddit joins the subreddits as a+b+c.
+func requestSubreddits(request contracts.RelevanceRequestDto) []string {
+	switch request.Scope {
+	case contracts.SearchScopeMultireddit:
+		return []string{strings.Join(request.Subreddits, "+")}
+	case contracts.SearchScopeAll:
+		return []string{reddit.AllSubreddits}
+	default:
+		return request.Subreddits
+	}
+}
+
+// filterCandidates drops the posts created before the CreatedAfter of the request and those with
+// fewer comments than its MinNumComments. Posts whose source does not tell when they were created are kept.
+func filterCandidates(candidates []candidatePost, request contracts.RelevanceRequestDto) []candidatePost {
+	if request.CreatedAfter.IsZero() && request.MinNumComments <= 0 {
+		return candidates
+	}
+	kept := candidates[:0]
+	for _, candidate := range candidates {
+		created := candidate.item.Created
+		if !request.CreatedAfter.IsZero() && !created.IsZero() && created.Before(request.CreatedAfter) {
+			continue
 		}
+		if candidate.item.NumComments < request.MinNumComments {
+			continue
+		}
+		kept = append(kept, candidate)
+	}
+	return kept
+}
 
-		subredditPostDtos = append(subredditPostDtos, evalSubredditPostDtos...)
+// MaxPosts returns the most posts a request can evaluate: its limit, 25 when zero, for every
+// Reddit request of its scope and every source
+func MaxPosts(request contracts.RelevanceRequestDto) int {
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
 	}
+	return limit * (len(requestSubreddits(request)) + len(request.Sources))
+}
 
-	return contracts.RelevanceResponseDto{
-		Posts: subredditPostDtos,
-	}, nil
+// candidatePost is a fetched post along with the source of the request that returned it
+type candidatePost struct {
+	item           ContentItem
+	source         string
+	matchedQueries []string
+	// logField names the subreddit or source of the post in the logs
+	logField zap.Field
 }
 
-func (s *relevanceService) evaluateSubredditPosts(
+// evaluatePosts scores the posts and summarizes their relevance. When the request deduplicates
+// posts, only the canonical post of the crossposts and links to the same page is scored, and the
+// near duplicates among the scored posts are merged before they are summarized.
+func (s *relevanceService) evaluatePosts(
 	ctx context.Context,
-	subredditName string,
-	subredditPosts *reddit.RedditResponse,
-	topic string,
-	topicEmbedding []float32,
-	relevanceThreshold float64,
+	candidates []candidatePost,
+	request contracts.RelevanceRequestDto,
+	query *topicQuery,
 ) ([]contracts.SubRedditPostDto, error) {
-	subredditPostDtos := make([]contracts.SubRedditPostDto, 0)
+	items := make([]ContentItem, len(candidates))
+	for i, candidate := range candidates {
+		items[i] = candidate.item
+	}
+	groups := singletonGroups(len(items))
+	if request.Deduplicate {
+		groups = groupExactDuplicates(items)
+	}
+	if s.enricher != nil {
+		s.enrichCanonicals(ctx, candidates, items, groups)
+	}
 
-	for _, post := range subredditPosts.Data.Children {
-		relevanceScore, err := s.getRelevanceScore(ctx, post.Data.Title, post.Data.Selftext, topicEmbedding)
+	relevances := make([]PostRelevance, len(items))
+	embeddings := make([][]float32, len(items))
+	for _, group := range groups {
+		candidate := candidates[group.canonical]
+		relevance, embedding, err := s.getRelevanceScore(logger.WithFields(ctx, s.logger, candidate.logField), candidate.item, query)
 		if err != nil {
 			return nil, errors.Wrap(err, "error getting relevance score")
 		}
-		isRelevant := relevanceScore >= relevanceThreshold
-		relevanceSummary, err := s.getRelevanceSummary(ctx, post.Data.Title, post.Data.Selftext, topic, relevanceThreshold, relevanceScore, isRelevant)
+		relevances[group.canonical] = relevance
+		embeddings[group.canonical] = embedding
+	}
+
+	if request.Deduplicate {
+		threshold := request.DuplicateThreshold
+		if threshold == 0 {
+			threshold = DefaultDuplicateThreshold
+		}
+		groups = mergeNearDuplicates(items, groups, embeddings, threshold)
+	}
+
+	postDtos := make([]contracts.SubRedditPostDto, 0, len(groups))
+	for _, group := range groups {
+		candidate := candidates[group.canonical]
+		relevance := relevances[group.canonical]
+		isRelevant := relevance.Score >= query.relevanceThreshold
+		relevanceSummary, err := s.getRelevanceSummary(
+			logger.WithFields(ctx, s.logger, candidate.logField), candidate.item, query, relevance, isRelevant,
+		)
 		if err != nil {
 			return nil, errors.Wrap(err, "error getting relevance summary")
 		}
-		postDto := MapRedditResponseToSubredditPostDto(post, subredditName, relevanceScore, isRelevant, relevanceSummary)
-		subredditPostDtos = append(subredditPostDtos, postDto)
+		postDto := MapContentItemToPostDto(candidate.item, relevance, isRelevant, relevanceSummary)
+		postDto.Source = candidate.source
+		postDto.MatchedQueries = candidate.matchedQueries
+		if request.AnalyzeSentiment && isRelevant {
+			postDto.Sentiment = s.getSentiment(logger.WithFields(ctx, s.logger, candidate.logField), candidate, query, request.SentimentComments)
+		}
+		if request.Deduplicate {
+			postDto.Subreddits = duplicateCommunities(items, group)
+			postDto.CombinedScore = combinedScore(items, group)
+			for _, duplicate := range group.duplicates {
+				postDto.Duplicates = append(postDto.Duplicates, MapDuplicateToDto(
+					items[duplicate.index], candidates[duplicate.index].source, duplicate.reason, duplicate.similarity,
+				))
+			}
+		}
+		postDtos = append(postDtos, postDto)
+	}
+	return postDtos, nil
+}
+
+// enrichCanonicals enriches the canonical post of every group, duplicates are not scored
+func (s *relevanceService) enrichCanonicals(ctx context.Context, candidates []candidatePost, items []ContentItem, groups []duplicateGroup) {
+	canonicals := make([]ContentItem, len(groups))
+	for i, group := range groups {
+		canonicals[i] = items[group.canonical]
+	}
+	s.enricher.Enrich(ctx, canonicals)
+	for i, group := range groups {
+		items[group.canonical] = canonicals[i]
+		candidates[group.canonical].item = canonicals[i]
 	}
-	return subredditPostDtos, nil
 }
 
-func (s *relevanceService) getRelevanceScore(ctx context.Context, title, content string, topicEmbedding []float32) (float64, error) {
-	s.logger.Info("Getting relevance score",
+// getRelevanceScore scores the post against the topics of the query. It also returns the
+// embedding of the post, to find near duplicates.
+func (s *relevanceService) getRelevanceScore(ctx context.Context, item ContentItem, query *topicQuery) (relevance PostRelevance, embedding []float32, err error) {
+	title := item.Title
+	ctx, span := tracing.Start(ctx, "RelevanceService.getRelevanceScore", attribute.String("reddit.post_title", title))
+	defer func() { tracing.End(span, err) }()
+
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Getting relevance score",
+		zap.String("post_id", item.ID),
 		zap.String("title", title),
-		zap.String("content", content),
 	)
+	log.Debug("Relevance score payload", zap.String("content", item.Body))
 
-	text := fmt.Sprintf("%s. %s", title, content)
-	embedding, err := s.llmClient.GetEmbedding(ctx, text)
+	scores, embedding, err := s.scorer.ScoreItem(ctx, item, query.embeddings())
 	if err != nil {
-		return 0, errors.Wrap(err, "error getting embedding")
+		return PostRelevance{}, nil, llmError(err)
 	}
-	cosineSimilarity := CosineSimilarity(embedding, topicEmbedding)
+	relevance = query.relevance(scores)
 
-	s.logger.Info(
+	span.SetAttributes(
+		attribute.Float64("relevance.score", relevance.Score),
+		attribute.Bool("relevance.is_excluded", relevance.IsExcluded),
+	)
+	log.Info(
 		"Relevance score calculated",
 		zap.String("title", title),
-		zap.Float64("cosine_similarity", cosineSimilarity),
+		zap.Float64("cosine_similarity", relevance.Score),
+		zap.Bool("excluded", relevance.IsExcluded),
 	)
-	return cosineSimilarity, nil
+	return relevance, embedding, nil
 }
 
 func (s *relevanceService) getRelevanceSummary(
 	ctx context.Context,
-	title, content, topic string,
-	relevanceThreshold float64,
-	relevanceScore float64,
+	item ContentItem,
+	query *topicQuery,
+	relevance PostRelevance,
 	isRelevant bool,
-) (string, error) {
-	s.logger.Info("Getting relevance summary",
+) (summary string, err error) {
+	title, content := item.Title, PostSummaryContent(item)
+	ctx, span := tracing.Start(ctx, "RelevanceService.getRelevanceSummary",
+		attribute.String("reddit.post_title", title),
+		attribute.Bool("relevance.is_relevant", isRelevant),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	log := logger.FromContext(ctx, s.logger)
+	log.Info("Getting relevance summary",
+		zap.String("post_id", item.ID),
 		zap.String("title", title),
-		zap.String("content", content),
-		zap.String("topic", topic),
-		zap.Float64("relevance_score", relevanceScore),
+		zap.Strings("topics", query.topics),
+		zap.Float64("relevance_score", relevance.Score),
 	)
+	log.Debug("Relevance summary payload", zap.String("content", content))
 
 	prompt := fmt.Sprintf(`Given the following title, content, and topic, generate an explanation of the relevance of the content to the topic. The explanation should be a single sentence.
 	
-	# Topic: "%s"
+	%s
 	# Relevance Threshold: %f
 	# Is Relevant: %t
 	# Relevance Score: %f
@@ -150,7 +469,7 @@ func (s *relevanceService) getRelevanceSummary(
 	# Title: "%s"
 	# Content: 
 	%s
-	`, topic, relevanceThreshold, isRelevant, relevanceScore, title, content,
+	`, topicPromptLines(query, relevance), query.relevanceThreshold, isRelevant, relevance.Score, title, content,
 	)
 
 	response, err := s.llmClient.Chat(ctx, []llm.Message{
@@ -160,8 +479,65 @@ func (s *relevanceService) getRelevanceSummary(
 		},
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "error getting chat response")
+		return "", errors.Wrap(llmError(err), "error getting chat response")
 	}
 
 	return response, nil
 }
+
+// getSentiment classifies the post and its top comments, up to commentLimit of them for Reddit
+// posts. Sentiment is optional, so failures leave the post without one and the request goes on.
+func (s *relevanceService) getSentiment(ctx context.Context, candidate candidatePost, query *topicQuery, commentLimit int) *contracts.SentimentDto {
+	log := logger.FromContext(ctx, s.logger)
+	var comments []string
+	if commentLimit > 0 && isRedditSource(candidate.source) {
+		var err error
+		if comments, err = s.postComments(ctx, candidate.item.ID, commentLimit); err != nil {
+			log.Warn("Error getting post comments, analyzing the post only", zap.Error(err))
+		}
+	}
+
+	sentiment, err := s.sentiment.Analyze(ctx, query.topics, candidate.item, comments)
+	if err != nil {
+		log.Warn("Error analyzing post sentiment", zap.String("title", candidate.item.Title), zap.Error(err))
+		return nil
+	}
+	return MapSentimentToDto(sentiment)
+}
+
+// postComments returns the cleaned text of the top comments of a Reddit post, without pinned
+// comments and the ones left empty once cleaned, such as deleted comments
+func (s *relevanceService) postComments(ctx context.Context, postID string, limit int) ([]string, error) {
+	listing, err := s.redditService.GetComments(ctx, postID, limit)
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]string, 0, len(listing.Data.Children))
+	for _, child := range listing.Data.Children {
+		if child.Kind != "t1" || child.Data.Stickied {
+			continue
+		}
+		if body := CleanText(child.Data.Body); body != "" {
+			comments = append(comments, body)
+		}
+	}
+	return comments[:min(len(comments), limit)], nil
+}
+
+// topicPromptLines describes the topics of the query in the summary prompt, mentioning the
+// exclusion topics only when the request has some
+func topicPromptLines(query *topicQuery, relevance PostRelevance) string {
+	lines := "# Topic: " + quotedTopics(query.topics, ", ")
+	if len(query.topics) > 1 {
+		lines = "# Topics: " + quotedTopics(query.topics, ", ")
+	}
+	if len(query.exclusions) > 0 {
+		lines += fmt.Sprintf("\n\t# Excluded Topics: %s\n\t# Penalized For Excluded Topic: %t", quotedTopics(query.exclusions, ", "), relevance.IsExcluded)
+	}
+	return lines
+}
+
+// llmError classifies a failure of the LLM API, whatever its status, as the model being unavailable
+func llmError(err error) error {
+	return apperrors.Wrap(err, apperrors.CodeLLMUnavailable, "The language model is unavailable, try again later")
+}
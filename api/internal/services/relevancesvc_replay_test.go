@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/recorder"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// newReplayRelevanceService wires the real Reddit and LLM clients to record/replay transports
+// reading the reddit.json and llm.json cassettes in cassetteDir
+func newReplayRelevanceService(t *testing.T, cassetteDir string) *relevanceService {
+	t.Helper()
+
+	redditTransport, err := recorder.NewTransport(filepath.Join(cassetteDir, "reddit.json"), recorder.ModeReplay, nil)
+	assert.NoError(t, err)
+	llmTransport, err := recorder.NewTransport(filepath.Join(cassetteDir, "llm.json"), recorder.ModeReplay, nil)
+	assert.NoError(t, err)
+
+	redditClient := reddit.NewClientWithHTTPClient("https://www.reddit.com", &http.Client{Transport: redditTransport})
+	llmClient := llm.NewClient(
+		"http://127.0.0.1:1234/v1",
+		"text-embedding-mxbai-embed-large-v1",
+		"openai/gpt-oss-20b",
+		&http.Client{Transport: llmTransport},
+		zap.NewNop(),
+	)
+	return newRelevanceServiceForTesting(llmClient, NewRedditService(redditClient, zap.NewNop()))
+}
+
+// ============================================================================
+// Replay Tests
+// ============================================================================
+
+func TestRelevanceService_GetRelevantPosts_Replay(t *testing.T) {
+	t.Run("SearchPipeline", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		service := newReplayRelevanceService(t, filepath.Join("testdata", "cassettes", "relevance_search"))
+
+		request := contracts.RelevanceRequestDto{
+			Topic:              "golang generics",
+			Subreddits:         []string{"golang"},
+			RelevanceThreshold: 0.6,
+			Limit:              3,
+			SearchMethod:       contracts.SearchMethodSearch,
+		}
+
+		// Act
+		first, err := service.GetRelevantPosts(ctx, request)
+		assert.NoError(t, err)
+		second, err := service.GetRelevantPosts(ctx, request)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Len(t, first.Posts, 3)
+		assert.Equal(t, first, second)
+
+		relevant := make([]string, 0)
+		for _, post := range first.Posts {
+			assert.Equal(t, "golang", post.SubredditName)
+			assert.NotEmpty(t, post.RelevanceSummary)
+			if post.IsRelevant {
+				relevant = append(relevant, post.Title)
+			}
+		}
+		assert.Equal(t, []string{"Generics in Go 1.24: what changed", "Type parameters vs interfaces"}, relevant)
+	})
+}
@@ -3,17 +3,27 @@ package services
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/article"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/hackernews"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
 	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
 	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // newRelevanceServiceForTesting creates a relevanceService with injected dependencies for testing
@@ -22,6 +32,11 @@ func newRelevanceServiceForTesting(llmClient llm.ClientInterface, redditService
 		logger:        zap.NewNop(),
 		llmClient:     llmClient,
 		redditService: redditService,
+		subreddits:    &redditSubredditSource{redditService: redditService},
+		sources:       redditContentSources(redditService, nil),
+		scorer:        NewEmbeddingScorer(llmClient),
+		expander:      NewLLMQueryExpander(llmClient, zap.NewNop()),
+		sentiment:     NewLLMSentimentAnalyzer(llmClient, zap.NewNop()),
 	}
 }
 
@@ -60,6 +75,7 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 					Children: []reddit.RedditChild{
 						{
 							Data: reddit.RedditPostData{
+								Subreddit:   subreddit,
 								Title:       "AI in Healthcare",
 								Selftext:    "Discussion about AI applications in healthcare",
 								URL:         "https://reddit.com/r/technology/ai-healthcare",
@@ -71,6 +87,7 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 						},
 						{
 							Data: reddit.RedditPostData{
+								Subreddit:   subreddit,
 								Title:       "Random Post",
 								Selftext:    "This is unrelated content",
 								URL:         "https://reddit.com/r/technology/random",
@@ -85,12 +102,12 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			}
 
 			mockLLMClient.EXPECT().GetEmbedding(ctx, topic).Return(topicEmbedding, nil)
-			mockRedditService.EXPECT().SearchPosts(subreddit, topic, limit).Return(redditResponse, nil)
-			mockLLMClient.EXPECT().GetEmbedding(ctx, "AI in Healthcare. Discussion about AI applications in healthcare").
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, subreddit, topic, limit, reddit.SearchOptions{}).Return(redditResponse, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "AI in Healthcare. Discussion about AI applications in healthcare").
 				Return(post1Embedding, nil)
-			mockLLMClient.EXPECT().GetEmbedding(ctx, "Random Post. This is unrelated content").
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Random Post. This is unrelated content").
 				Return(post2Embedding, nil)
-			mockLLMClient.EXPECT().Chat(ctx, mock.MatchedBy(func(messages []llm.Message) bool {
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
 				return len(messages) == 1 && messages[0].Role == "user"
 			})).Return("This post is highly relevant to artificial intelligence", nil).Times(2)
 
@@ -116,6 +133,140 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			assert.NotEmpty(t, post2.RelevanceSummary)
 		})
 
+		t.Run("MultipleTopicsWithExclusion", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go generics",
+				Topics:             []string{"go iterators"},
+				ExcludeTopics:      []string{"job postings"},
+				Subreddits:         []string{"golang"},
+				RelevanceThreshold: 0.6,
+				Limit:              5,
+				SearchMethod:       contracts.SearchMethodSearch,
+			}
+
+			redditResponse := &reddit.RedditResponse{
+				Data: reddit.RedditData{
+					Children: []reddit.RedditChild{
+						{Data: reddit.RedditPostData{Title: "Iterators", Selftext: "range over func"}},
+						{Data: reddit.RedditPostData{Title: "Hiring", Selftext: "Go generics developer wanted"}},
+					},
+				},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0, 0}, nil)
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go iterators").Return([]float32{0, 1, 0}, nil)
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "job postings").Return([]float32{0, 0, 1}, nil)
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "golang", `"go generics" OR "go iterators"`, 5, reddit.SearchOptions{}).Return(redditResponse, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Iterators. range over func").Return([]float32{0, 1, 0}, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Hiring. Go generics developer wanted").Return([]float32{1, 0, 1}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return strings.Contains(messages[0].Content, `# Topics: "go generics", "go iterators"`) &&
+					strings.Contains(messages[0].Content, `# Excluded Topics: "job postings"`)
+			})).Return("summary", nil).Times(2)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 2)
+
+			iterators := result.Posts[0]
+			assert.True(t, iterators.IsRelevant)
+			assert.False(t, iterators.IsExcluded)
+			assert.InDelta(t, 1.0, iterators.RelevanceScore, 0.0001)
+			assert.Len(t, iterators.TopicScores, 2)
+			assert.InDelta(t, 1.0, iterators.TopicScores[1].Score, 0.0001)
+
+			hiring := result.Posts[1]
+			assert.True(t, hiring.IsExcluded)
+			assert.False(t, hiring.IsRelevant)
+			assert.InDelta(t, 0.7071*DefaultExclusionPenalty, hiring.RelevanceScore, 0.0001)
+			assert.Equal(t, "job postings", hiring.ExclusionScores[0].Topic)
+		})
+
+		t.Run("ExpandedQuery", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go generics",
+				Subreddits:         []string{"golang"},
+				RelevanceThreshold: 0.5,
+				Limit:              5,
+				SearchMethod:       contracts.SearchMethodSearch,
+				ExpandQuery:        true,
+				MaxSearchQueries:   2,
+			}
+
+			generics := reddit.RedditChild{Data: reddit.RedditPostData{ID: "a1", Title: "Generics", Selftext: "in Go"}}
+			typeParams := reddit.RedditChild{Data: reddit.RedditPostData{ID: "a2", Title: "Type parameters", Selftext: "in Go"}}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return strings.Contains(messages[0].Content, "Reddit search queries")
+			})).Return(`["\"type parameters\" AND golang", "go generics"]`, nil).Once()
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "golang", "go generics", 5, reddit.SearchOptions{}).
+				Return(&reddit.RedditResponse{Data: reddit.RedditData{Children: []reddit.RedditChild{generics}}}, nil)
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "golang", `"type parameters" AND golang`, 5, reddit.SearchOptions{}).
+				Return(&reddit.RedditResponse{Data: reddit.RedditData{Children: []reddit.RedditChild{typeParams, generics}}}, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Generics. in Go").Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Type parameters. in Go").Return([]float32{1, 1}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return strings.Contains(messages[0].Content, "Reddit Post:")
+			})).Return("summary", nil).Times(2)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"go generics", `"type parameters" AND golang`}, result.SearchQueries)
+			assert.Len(t, result.Posts, 2)
+			assert.Equal(t, "a1", result.Posts[0].ID)
+			assert.Equal(t, []string{"go generics", `"type parameters" AND golang`}, result.Posts[0].MatchedQueries)
+			assert.Equal(t, "a2", result.Posts[1].ID)
+			assert.Equal(t, []string{`"type parameters" AND golang`}, result.Posts[1].MatchedQueries)
+		})
+
+		t.Run("ExpansionFailureSearchesTopic", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:        "go generics",
+				Subreddits:   []string{"golang"},
+				Limit:        5,
+				SearchMethod: contracts.SearchMethodSearch,
+				ExpandQuery:  true,
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("", errors.New("chat service unavailable")).Once()
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "golang", "go generics", 5, reddit.SearchOptions{}).
+				Return(&reddit.RedditResponse{}, nil)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"go generics"}, result.SearchQueries)
+			assert.Empty(t, result.Posts)
+		})
+
 		t.Run("LatestMethod", func(t *testing.T) {
 			// Arrange
 			ctx := context.Background()
@@ -144,6 +295,7 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 					Children: []reddit.RedditChild{
 						{
 							Data: reddit.RedditPostData{
+								Subreddit:   subreddit,
 								Title:       "New ML Paper",
 								Selftext:    "Latest research in machine learning",
 								URL:         "https://reddit.com/r/MachineLearning/new-paper",
@@ -158,10 +310,10 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			}
 
 			mockLLMClient.EXPECT().GetEmbedding(ctx, topic).Return(topicEmbedding, nil)
-			mockRedditService.EXPECT().GetPosts(subreddit, limit).Return(redditResponse, nil)
-			mockLLMClient.EXPECT().GetEmbedding(ctx, "New ML Paper. Latest research in machine learning").
+			mockRedditService.EXPECT().GetPosts(mock.Anything, subreddit, limit, reddit.ListingOptions{Sort: reddit.ListingSortNew}).Return(redditResponse, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "New ML Paper. Latest research in machine learning").
 				Return(postEmbedding, nil)
-			mockLLMClient.EXPECT().Chat(ctx, mock.Anything).Return("This post discusses machine learning research", nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("This post discusses machine learning research", nil)
 
 			// Act
 			result, err := service.GetRelevantPosts(ctx, request)
@@ -173,6 +325,58 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			assert.Equal(t, "New ML Paper", result.Posts[0].Title)
 		})
 
+		t.Run("CreatedAfterAndMinNumComments", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			createdAfter := time.Now().Add(-24 * time.Hour)
+			request := contracts.RelevanceRequestDto{
+				Topic:              "golang",
+				Subreddits:         []string{"golang"},
+				RelevanceThreshold: 0.5,
+				Limit:              3,
+				CreatedAfter:       createdAfter,
+				MinNumComments:     5,
+				SearchMethod:       contracts.SearchMethodLatest,
+			}
+			post := func(id, title string, created time.Time, comments int) reddit.RedditChild {
+				return reddit.RedditChild{Data: reddit.RedditPostData{
+					ID:          id,
+					Subreddit:   "golang",
+					Title:       title,
+					Selftext:    "About Go",
+					NumComments: comments,
+					CreatedUTC:  float64(created.Unix()),
+					Permalink:   "/r/golang/comments/" + id,
+				}}
+			}
+			redditResponse := &reddit.RedditResponse{
+				Data: reddit.RedditData{
+					Children: []reddit.RedditChild{
+						post("a", "Recent and discussed", time.Now(), 10),
+						post("b", "Too old", createdAfter.Add(-time.Hour), 10),
+						post("c", "Too quiet", time.Now(), 4),
+					},
+				},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "golang").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetPosts(mock.Anything, "golang", 3, reddit.ListingOptions{Sort: reddit.ListingSortNew}).Return(redditResponse, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Recent and discussed. About Go").Return([]float32{1, 0}, nil).Once()
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 1)
+			assert.Equal(t, "Recent and discussed", result.Posts[0].Title)
+		})
+
 		t.Run("MultipleSubreddits", func(t *testing.T) {
 			// Arrange
 			ctx := context.Background()
@@ -202,6 +406,7 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 						Children: []reddit.RedditChild{
 							{
 								Data: reddit.RedditPostData{
+									Subreddit:   subreddit,
 									Title:       "Post in " + subreddit,
 									Selftext:    "Content about " + topic,
 									URL:         "https://reddit.com/r/" + subreddit + "/post",
@@ -215,11 +420,11 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 					},
 				}
 
-				mockRedditService.EXPECT().SearchPosts(subreddit, topic, limit).Return(redditResponse, nil)
-				mockLLMClient.EXPECT().GetEmbedding(ctx, mock.MatchedBy(func(text string) bool {
+				mockRedditService.EXPECT().SearchPosts(mock.Anything, subreddit, topic, limit, reddit.SearchOptions{}).Return(redditResponse, nil)
+				mockLLMClient.EXPECT().GetEmbedding(mock.Anything, mock.MatchedBy(func(text string) bool {
 					return len(text) > 0
 				})).Return(postEmbedding, nil)
-				mockLLMClient.EXPECT().Chat(ctx, mock.Anything).Return("Relevant post about programming", nil)
+				mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant post about programming", nil)
 			}
 
 			mockLLMClient.EXPECT().GetEmbedding(ctx, topic).Return(topicEmbedding, nil)
@@ -232,6 +437,453 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			assert.Len(t, result.Posts, 2)
 			assert.Equal(t, subreddits[0], result.Posts[0].SubredditName)
 			assert.Equal(t, subreddits[1], result.Posts[1].SubredditName)
+			assert.Equal(t, "r/golang", result.Posts[1].Source)
+		})
+
+		t.Run("Multireddit", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:        "go generics",
+				Subreddits:   []string{"golang", "programming"},
+				Limit:        2,
+				SearchMethod: contracts.SearchMethodSearch,
+				Scope:        contracts.SearchScopeMultireddit,
+			}
+			redditResponse := &reddit.RedditResponse{
+				Data: reddit.RedditData{
+					Children: []reddit.RedditChild{
+						{Data: reddit.RedditPostData{ID: "a1", Subreddit: "programming", Title: "Generics", Selftext: "in Go"}},
+						{Data: reddit.RedditPostData{ID: "a2", Subreddit: "golang", Title: "Type parameters", Selftext: "in Go"}},
+					},
+				},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "golang+programming", "go generics", 2, reddit.SearchOptions{}).
+				Return(redditResponse, nil).Once()
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, mock.Anything).Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 2)
+			assert.Equal(t, "programming", result.Posts[0].SubredditName)
+			assert.Equal(t, "golang", result.Posts[1].SubredditName)
+		})
+
+		t.Run("SiteWide", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:        "go generics",
+				Limit:        1,
+				SearchMethod: contracts.SearchMethodSearch,
+				Scope:        contracts.SearchScopeAll,
+			}
+			redditResponse := &reddit.RedditResponse{
+				Data: reddit.RedditData{
+					Children: []reddit.RedditChild{
+						{Data: reddit.RedditPostData{ID: "a1", Subreddit: "learnprogramming", Title: "Generics", Selftext: "in Go"}},
+					},
+				},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, reddit.AllSubreddits, "go generics", 1, reddit.SearchOptions{}).
+				Return(redditResponse, nil).Once()
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, mock.Anything).Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 1)
+			assert.Equal(t, "learnprogramming", result.Posts[0].SubredditName)
+		})
+
+		t.Run("Sources", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:        "go generics",
+				Sources:      []string{"user:gopher", "domain:go.dev"},
+				Limit:        1,
+				SearchMethod: contracts.SearchMethodTop,
+				TimeWindow:   contracts.TimeWindowWeek,
+			}
+			listing := func(id, subreddit string) *reddit.RedditResponse {
+				return &reddit.RedditResponse{
+					Data: reddit.RedditData{
+						Children: []reddit.RedditChild{
+							{Data: reddit.RedditPostData{ID: id, Subreddit: subreddit, Title: "Generics", Selftext: "in Go"}},
+						},
+					},
+				}
+			}
+			options := reddit.ListingOptions{Sort: reddit.ListingSortTop, Time: reddit.TimeWindowWeek}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetUserPosts(mock.Anything, "gopher", 1, options).Return(listing("a1", "golang"), nil)
+			mockRedditService.EXPECT().GetDomainPosts(mock.Anything, "go.dev", 1, options).Return(listing("a2", "programming"), nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, mock.Anything).Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 2)
+			assert.Equal(t, "user:gopher", result.Posts[0].Source)
+			assert.Equal(t, "golang", result.Posts[0].SubredditName)
+			assert.Equal(t, "domain:go.dev", result.Posts[1].Source)
+			assert.Equal(t, "programming", result.Posts[1].SubredditName)
+		})
+
+		t.Run("SourcesOutsideReddit", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mock_services.NewMockRedditService(t))
+			server := fixtureServer(t, "hackernews/testdata/search.json", func(r *http.Request) {
+				assert.Equal(t, "go generics", r.URL.Query().Get("query"))
+			})
+			service.sources[SourceHackerNewsPrefix] = NewHackerNewsSource(hackernews.NewTestClient(server.URL))
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go generics",
+				Sources:            []string{"hackernews:story"},
+				RelevanceThreshold: 0.5,
+				SearchMethod:       contracts.SearchMethodSearch,
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, mock.Anything).Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 2)
+			assert.Equal(t, "hackernews:story", result.Posts[0].Source)
+			assert.Equal(t, HackerNewsCommunity, result.Posts[0].SubredditName)
+			assert.Equal(t, "gopher", result.Posts[0].Author)
+			assert.True(t, result.Posts[0].IsRelevant)
+		})
+
+		t.Run("ExpandedQueryOnSources", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mock_services.NewMockRedditService(t))
+			var queries []string
+			server := fixtureServer(t, "hackernews/testdata/search.json", func(r *http.Request) {
+				queries = append(queries, r.URL.Query().Get("query"))
+			})
+			service.sources[SourceHackerNewsPrefix] = NewHackerNewsSource(hackernews.NewTestClient(server.URL))
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go generics",
+				Sources:            []string{"hackernews:story"},
+				RelevanceThreshold: 0.5,
+				SearchMethod:       contracts.SearchMethodSearch,
+				ExpandQuery:        true,
+				MaxSearchQueries:   2,
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return strings.Contains(messages[0].Content, "Reddit search queries")
+			})).Return(`["type parameters", "go generics"]`, nil).Once()
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, mock.Anything).Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"go generics", "type parameters"}, queries)
+			// Both queries return the same items, they are merged
+			assert.Len(t, result.Posts, 2)
+			assert.Equal(t, []string{"go generics", "type parameters"}, result.Posts[0].MatchedQueries)
+		})
+
+		t.Run("Deduplicate", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go generics",
+				Subreddits:         []string{"golang", "programming"},
+				RelevanceThreshold: 0.5,
+				Limit:              5,
+				SearchMethod:       contracts.SearchMethodNew,
+				Deduplicate:        true,
+			}
+			listing := func(posts ...reddit.RedditPostData) *reddit.RedditResponse {
+				response := &reddit.RedditResponse{}
+				for _, post := range posts {
+					response.Data.Children = append(response.Data.Children, reddit.RedditChild{Data: post})
+				}
+				return response
+			}
+			options := reddit.ListingOptions{Sort: reddit.ListingSortNew}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetPosts(mock.Anything, "golang", 5, options).Return(listing(
+				reddit.RedditPostData{ID: "a1", Subreddit: "golang", Title: "Generics are here", Score: 120},
+			), nil)
+			mockRedditService.EXPECT().GetPosts(mock.Anything, "programming", 5, options).Return(listing(
+				reddit.RedditPostData{ID: "x1", Subreddit: "programming", Title: "Generics are here", Score: 40, CrosspostParent: "t3_a1"},
+				reddit.RedditPostData{ID: "p2", Subreddit: "programming", Title: "Go finally has generics", Score: 30},
+			), nil)
+			// The crosspost is not embedded, the near duplicate is
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Generics are here. ").Return([]float32{1, 0}, nil).Once()
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Go finally has generics. ").Return([]float32{1, 0.05}, nil).Once()
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil).Once()
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 1)
+			post := result.Posts[0]
+			assert.Equal(t, "a1", post.ID)
+			assert.Equal(t, []string{"golang", "programming"}, post.Subreddits)
+			assert.Equal(t, 190, post.CombinedScore)
+			assert.Len(t, post.Duplicates, 2)
+			assert.Equal(t, "x1", post.Duplicates[0].ID)
+			assert.Equal(t, "r/programming", post.Duplicates[0].Source)
+			assert.Equal(t, contracts.DuplicateReasonCrosspost, post.Duplicates[0].Reason)
+			assert.Equal(t, "p2", post.Duplicates[1].ID)
+			assert.Equal(t, contracts.DuplicateReasonSimilar, post.Duplicates[1].Reason)
+			assert.Greater(t, post.Duplicates[1].Similarity, DefaultDuplicateThreshold)
+		})
+
+		t.Run("LinkPosts", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+			var pageRequests atomic.Int32
+			server := linkedPageServer(t, &pageRequests)
+			service.enricher = NewLinkEnricher(article.NewTestClient(article.Options{}), EnricherOptions{}, zap.NewNop())
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go iterators",
+				Subreddits:         []string{"golang"},
+				RelevanceThreshold: 0.5,
+				Limit:              5,
+				SearchMethod:       contracts.SearchMethodNew,
+			}
+			options := reddit.ListingOptions{Sort: reddit.ListingSortNew}
+			response := &reddit.RedditResponse{}
+			response.Data.Children = []reddit.RedditChild{
+				{Data: reddit.RedditPostData{ID: "a1", Subreddit: "golang", Title: "Go 1.23 is out", URL: server.URL + "/blog"}},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go iterators").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetPosts(mock.Anything, "golang", 5, options).Return(response, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything,
+				"Go 1.23 is out. Link to 127.0.0.1: Range over function types Go 1.23 lets a for loop range over iterator functions.",
+			).Return([]float32{1, 0}, nil).Once()
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return strings.Contains(messages[0].Content, "Go 1.23 lets a for loop range over iterator functions.")
+			})).Return("Relevant", nil).Once()
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 1)
+			assert.True(t, result.Posts[0].IsRelevant)
+			assert.Equal(t, int32(1), pageRequests.Load())
+		})
+
+		t.Run("MediaPosts", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			mockCaptioner := mock_llm.NewMockCaptionerInterface(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+			service.enricher = NewMediaCaptioner(mockCaptioner, EnricherOptions{}, zap.NewNop())
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go performance",
+				Subreddits:         []string{"golang"},
+				RelevanceThreshold: 0.5,
+				Limit:              5,
+				SearchMethod:       contracts.SearchMethodNew,
+			}
+			options := reddit.ListingOptions{Sort: reddit.ListingSortNew}
+			response := &reddit.RedditResponse{}
+			response.Data.Children = []reddit.RedditChild{
+				{Data: reddit.RedditPostData{ID: "i1", Subreddit: "golang", Title: "Compile times", URL: "https://i.redd.it/chart.png", PostHint: "image"}},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go performance").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetPosts(mock.Anything, "golang", 5, options).Return(response, nil)
+			mockCaptioner.EXPECT().CaptionImage(mock.Anything, "https://i.redd.it/chart.png", mock.Anything).Return("A bar chart of Go build times.", nil).Once()
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Compile times. Image: A bar chart of Go build times.").Return([]float32{1, 0}, nil).Once()
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil).Once()
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 1)
+			assert.Equal(t, &contracts.MediaDto{
+				Kind:             contracts.MediaKindImage,
+				Items:            []contracts.MediaItemDto{{Url: "https://i.redd.it/chart.png"}},
+				GeneratedCaption: "A bar chart of Go build times.",
+			}, result.Posts[0].Media)
+		})
+
+		t.Run("Sentiment", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go generics",
+				Subreddits:         []string{"golang"},
+				RelevanceThreshold: 0.5,
+				Limit:              5,
+				SearchMethod:       contracts.SearchMethodNew,
+				AnalyzeSentiment:   true,
+				SentimentComments:  2,
+			}
+			options := reddit.ListingOptions{Sort: reddit.ListingSortNew}
+			response := &reddit.RedditResponse{}
+			response.Data.Children = []reddit.RedditChild{
+				{Data: reddit.RedditPostData{ID: "p1", Subreddit: "golang", Title: "Generics are great", Selftext: "Love them"}},
+				{Data: reddit.RedditPostData{ID: "p2", Subreddit: "golang", Title: "Hiring", Selftext: "Job"}},
+			}
+			comments := &reddit.CommentListing{}
+			comments.Data.Children = []reddit.CommentChild{
+				{Kind: "t1", Data: reddit.CommentData{Body: "Please read the rules", Stickied: true}},
+				{Kind: "t1", Data: reddit.CommentData{Body: "Agreed, **finally**"}},
+				{Kind: "t1", Data: reddit.CommentData{Body: "[deleted]"}},
+				{Kind: "t1", Data: reddit.CommentData{Body: "They make code harder to read"}},
+				{Kind: "more"},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetPosts(mock.Anything, "golang", 5, options).Return(response, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Generics are great. Love them").Return([]float32{1, 0}, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Hiring. Job").Return([]float32{0, 1}, nil)
+			mockRedditService.EXPECT().GetComments(mock.Anything, "p1", 2).Return(comments, nil).Once()
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return !strings.Contains(messages[0].Content, "Classify the sentiment")
+			})).Return("Summary", nil).Times(2)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return strings.Contains(messages[0].Content, "Classify the sentiment") &&
+					strings.Contains(messages[0].Content, "1. Agreed, finally\n2. They make code harder to read\n")
+			})).Return(`{"post": {"sentiment": "Positive", "stance": "favor"}, "comments": [{"sentiment": "positive", "stance": "favor"}, {"sentiment": "negative", "stance": "against"}]}`, nil).Once()
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 2)
+			assert.Equal(t, &contracts.SentimentDto{
+				Sentiment: contracts.SentimentPositive,
+				Stance:    contracts.StanceFavor,
+				Comments: &contracts.SentimentBreakdownDto{
+					Total:      2,
+					Sentiments: contracts.SentimentCountsDto{Positive: 1, Negative: 1},
+					Stances:    contracts.StanceCountsDto{Favor: 1, Against: 1},
+				},
+			}, result.Posts[0].Sentiment)
+			assert.Nil(t, result.Posts[1].Sentiment)
+			assert.Equal(t, []contracts.SubredditSentimentDto{{
+				SubredditName: "golang",
+				Posts: contracts.SentimentBreakdownDto{
+					Total:      1,
+					Sentiments: contracts.SentimentCountsDto{Positive: 1},
+					Stances:    contracts.StanceCountsDto{Favor: 1},
+				},
+				Comments: contracts.SentimentBreakdownDto{
+					Total:      2,
+					Sentiments: contracts.SentimentCountsDto{Positive: 1, Negative: 1},
+					Stances:    contracts.StanceCountsDto{Favor: 1, Against: 1},
+				},
+			}}, result.SentimentBySubreddit)
+		})
+
+		t.Run("SentimentFailureKeepsPost", func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			mockLLMClient := mock_llm.NewMockClientInterface(t)
+			mockRedditService := mock_services.NewMockRedditService(t)
+			service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+			request := contracts.RelevanceRequestDto{
+				Topic:              "go generics",
+				Subreddits:         []string{"golang"},
+				RelevanceThreshold: 0.5,
+				Limit:              5,
+				SearchMethod:       contracts.SearchMethodNew,
+				AnalyzeSentiment:   true,
+				SentimentComments:  5,
+			}
+			options := reddit.ListingOptions{Sort: reddit.ListingSortNew}
+			response := &reddit.RedditResponse{}
+			response.Data.Children = []reddit.RedditChild{
+				{Data: reddit.RedditPostData{ID: "p1", Subreddit: "golang", Title: "Generics are great", Selftext: "Love them"}},
+			}
+
+			mockLLMClient.EXPECT().GetEmbedding(ctx, "go generics").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetPosts(mock.Anything, "golang", 5, options).Return(response, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Generics are great. Love them").Return([]float32{1, 0}, nil)
+			mockRedditService.EXPECT().GetComments(mock.Anything, "p1", 5).Return(nil, errors.New("reddit unavailable"))
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return !strings.Contains(messages[0].Content, "Classify the sentiment")
+			})).Return("Summary", nil).Once()
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+				return strings.Contains(messages[0].Content, "Classify the sentiment") && !strings.Contains(messages[0].Content, "Comments:")
+			})).Return("I cannot tell", nil).Once()
+
+			// Act
+			result, err := service.GetRelevantPosts(ctx, request)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 1)
+			assert.Nil(t, result.Posts[0].Sentiment)
+			assert.Nil(t, result.SentimentBySubreddit)
 		})
 
 		t.Run("EmptySubreddits", func(t *testing.T) {
@@ -286,6 +938,7 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			// Assert
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), "error getting topic embedding")
+			assert.Equal(t, apperrors.CodeLLMUnavailable, apperrors.CodeOf(err))
 			assert.Empty(t, result.Posts)
 		})
 
@@ -308,7 +961,7 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			expectedError := errors.New("Reddit API error")
 
 			mockLLMClient.EXPECT().GetEmbedding(ctx, "test topic").Return(topicEmbedding, nil)
-			mockRedditService.EXPECT().SearchPosts("test", "test topic", 5).Return(nil, expectedError)
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "test", "test topic", 5, reddit.SearchOptions{}).Return(nil, expectedError)
 
 			// Act
 			result, err := service.GetRelevantPosts(ctx, request)
@@ -356,8 +1009,8 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			}
 
 			mockLLMClient.EXPECT().GetEmbedding(ctx, "test topic").Return(topicEmbedding, nil)
-			mockRedditService.EXPECT().SearchPosts("test", "test topic", 5).Return(redditResponse, nil)
-			mockLLMClient.EXPECT().GetEmbedding(ctx, "Test Post. Test content").Return(nil, expectedError)
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "test", "test topic", 5, reddit.SearchOptions{}).Return(redditResponse, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Test Post. Test content").Return(nil, expectedError)
 
 			// Act
 			result, err := service.GetRelevantPosts(ctx, request)
@@ -365,6 +1018,7 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			// Assert
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), "error getting relevance score")
+			assert.Equal(t, apperrors.CodeLLMUnavailable, apperrors.CodeOf(err))
 			assert.Empty(t, result.Posts)
 		})
 
@@ -406,9 +1060,9 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			}
 
 			mockLLMClient.EXPECT().GetEmbedding(ctx, "test topic").Return(topicEmbedding, nil)
-			mockRedditService.EXPECT().SearchPosts("test", "test topic", 5).Return(redditResponse, nil)
-			mockLLMClient.EXPECT().GetEmbedding(ctx, "Test Post. Test content").Return(postEmbedding, nil)
-			mockLLMClient.EXPECT().Chat(ctx, mock.Anything).Return("", expectedError)
+			mockRedditService.EXPECT().SearchPosts(mock.Anything, "test", "test topic", 5, reddit.SearchOptions{}).Return(redditResponse, nil)
+			mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Test Post. Test content").Return(postEmbedding, nil)
+			mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("", expectedError)
 
 			// Act
 			result, err := service.GetRelevantPosts(ctx, request)
@@ -416,7 +1070,139 @@ func TestRelevanceService_GetRelevantPosts(t *testing.T) {
 			// Assert
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), "error getting relevance summary")
+			assert.Equal(t, apperrors.CodeLLMUnavailable, apperrors.CodeOf(err))
 			assert.Empty(t, result.Posts)
 		})
 	})
 }
+
+// ============================================================================
+// Tracing Tests
+// ============================================================================
+
+func TestRelevanceService_GetRelevantPosts_Tracing(t *testing.T) {
+	t.Run("SpansPerSubredditAndPost", func(t *testing.T) {
+		// Arrange
+		spanRecorder := tracetest.NewSpanRecorder()
+		ctx, root := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)).
+			Tracer("test").Start(context.Background(), "request")
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		mockRedditService := mock_services.NewMockRedditService(t)
+		service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+
+		request := contracts.RelevanceRequestDto{
+			Topic:              "test topic",
+			Subreddits:         []string{"test"},
+			RelevanceThreshold: 0.5,
+			Limit:              5,
+			SearchMethod:       contracts.SearchMethodSearch,
+		}
+		redditResponse := &reddit.RedditResponse{
+			Data: reddit.RedditData{
+				Children: []reddit.RedditChild{
+					{Data: reddit.RedditPostData{Title: "Test Post", Selftext: "Test content"}},
+				},
+			},
+		}
+
+		mockLLMClient.EXPECT().GetEmbedding(ctx, "test topic").Return([]float32{0.1, 0.2}, nil)
+		mockRedditService.EXPECT().SearchPosts(mock.Anything, "test", "test topic", 5, reddit.SearchOptions{}).Return(redditResponse, nil)
+		mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Test Post. Test content").Return([]float32{0.1, 0.2}, nil)
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+		// Act
+		_, err := service.GetRelevantPosts(ctx, request)
+		root.End()
+
+		// Assert
+		assert.NoError(t, err)
+		names := make([]string, 0)
+		for _, span := range spanRecorder.Ended() {
+			names = append(names, span.Name())
+			assert.Equal(t, root.SpanContext().TraceID(), span.SpanContext().TraceID())
+		}
+		assert.Equal(t, []string{
+			"RelevanceService.fetchSubredditPosts",
+			"RelevanceService.getRelevanceScore",
+			"RelevanceService.getRelevanceSummary",
+			"request",
+		}, names)
+	})
+}
+
+func TestRelevanceService_GetRelevantPosts_Logging(t *testing.T) {
+	t.Run("PostContentOnlyAtDebug", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		core, logs := observer.New(zapcore.DebugLevel)
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		mockRedditService := mock_services.NewMockRedditService(t)
+		service := newRelevanceServiceForTesting(mockLLMClient, mockRedditService)
+		service.logger = zap.New(core)
+
+		request := contracts.RelevanceRequestDto{
+			Topic:              "test topic",
+			Subreddits:         []string{"test"},
+			RelevanceThreshold: 0.5,
+			Limit:              5,
+			SearchMethod:       contracts.SearchMethodSearch,
+		}
+		redditResponse := &reddit.RedditResponse{
+			Data: reddit.RedditData{
+				Children: []reddit.RedditChild{
+					{Data: reddit.RedditPostData{ID: "a1", Title: "Test Post", Selftext: "Private content"}},
+				},
+			},
+		}
+
+		mockLLMClient.EXPECT().GetEmbedding(ctx, "test topic").Return([]float32{0.1, 0.2}, nil)
+		mockRedditService.EXPECT().SearchPosts(mock.Anything, "test", "test topic", 5, reddit.SearchOptions{}).Return(redditResponse, nil)
+		mockLLMClient.EXPECT().GetEmbedding(mock.Anything, "Test Post. Private content").Return([]float32{0.1, 0.2}, nil)
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("Relevant", nil)
+
+		// Act
+		_, err := service.GetRelevantPosts(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		for _, message := range []string{"Getting relevance score", "Getting relevance summary"} {
+			entries := logs.FilterMessage(message).All()
+			assert.Len(t, entries, 1, message)
+			assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+			assert.Equal(t, "a1", entries[0].ContextMap()["post_id"])
+			assert.NotContains(t, entries[0].ContextMap(), "content")
+		}
+		content := logs.FilterField(zap.String("content", "Private content")).All()
+		assert.NotEmpty(t, content)
+		for _, entry := range content {
+			assert.Equal(t, zapcore.DebugLevel, entry.Level)
+		}
+	})
+}
+
+// ============================================================================
+// Listing Options Tests
+// ============================================================================
+
+func TestListingOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    ContentQuery
+		expected reddit.ListingOptions
+	}{
+		{"LatestIsNew", ContentQuery{Method: contracts.SearchMethodLatest}, reddit.ListingOptions{Sort: reddit.ListingSortNew}},
+		{"TopWithTimeWindow", ContentQuery{Method: contracts.SearchMethodTop, TimeWindow: contracts.TimeWindowYear}, reddit.ListingOptions{Sort: reddit.ListingSortTop, Time: reddit.TimeWindowYear}},
+		{"RisingIgnoresTimeWindow", ContentQuery{Method: contracts.SearchMethodRising, TimeWindow: contracts.TimeWindowYear}, reddit.ListingOptions{Sort: reddit.ListingSortRising}},
+		{"SearchIsDefaultListing", ContentQuery{Method: contracts.SearchMethodSearch, TimeWindow: contracts.TimeWindowYear}, reddit.ListingOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			options := listingOptions(tt.query)
+
+			// Assert
+			assert.Equal(t, tt.expected, options)
+		})
+	}
+}
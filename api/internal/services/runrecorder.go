@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/runstore"
+)
+
+type runRecordingService struct {
+	next   RelevanceService
+	store  runstore.Store
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewRunRecordingService wraps next so the posts of every successful search are saved to store,
+// for trends. A failure to save is logged and the search still succeeds.
+func NewRunRecordingService(next RelevanceService, store runstore.Store, logger *zap.Logger) RelevanceService {
+	return &runRecordingService{
+		next:   next,
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *runRecordingService) GetRelevantPosts(ctx context.Context, request contracts.RelevanceRequestDto) (contracts.RelevanceResponseDto, error) {
+	response, err := s.next.GetRelevantPosts(ctx, request)
+	if err != nil {
+		return response, err
+	}
+
+	run := MapRelevanceResponseToRun(newRunID(), RequestTopics(request), s.now(), response)
+	if err := s.store.Save(ctx, run); err != nil {
+		logger.FromContext(ctx, s.logger).Error("Error saving run", zap.Error(err))
+	}
+	return response, nil
+}
+
+func newRunID() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read never returns an error
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+)
+
+// RelevanceScorer scores how relevant a post is to topic embeddings
+type RelevanceScorer interface {
+	Score(ctx context.Context, title, content string, topicEmbedding []float32) (float64, error)
+	// ScoreTopics scores the post against each topic embedding, in order
+	ScoreTopics(ctx context.Context, title, content string, topicEmbeddings [][]float32) ([]float64, error)
+	// ScoreItem scores the item against each topic embedding, in order. It also returns the
+	// embedding of the item, the mean of the embeddings of its chunks.
+	ScoreItem(ctx context.Context, item ContentItem, topicEmbeddings [][]float32) ([]float64, []float32, error)
+}
+
+type embeddingScorer struct {
+	llmClient llm.ClientInterface
+	options   PreprocessOptions
+}
+
+// NewEmbeddingScorer creates a scorer that compares the post embedding with the topic embedding
+// using cosine similarity. It is the scorer used by the relevance service and the evaluation harness.
+func NewEmbeddingScorer(llmClient llm.ClientInterface) RelevanceScorer {
+	return NewEmbeddingScorerWithOptions(llmClient, PreprocessOptions{})
+}
+
+// NewEmbeddingScorerWithOptions creates an embedding scorer that cleans and chunks posts as
+// options say before embedding them
+func NewEmbeddingScorerWithOptions(llmClient llm.ClientInterface, options PreprocessOptions) RelevanceScorer {
+	return &embeddingScorer{
+		llmClient: llmClient,
+		options:   options.withDefaults(),
+	}
+}
+
+func (s *embeddingScorer) Score(ctx context.Context, title, content string, topicEmbedding []float32) (float64, error) {
+	scores, err := s.ScoreTopics(ctx, title, content, [][]float32{topicEmbedding})
+	if err != nil {
+		return 0, err
+	}
+	return scores[0], nil
+}
+
+func (s *embeddingScorer) ScoreTopics(ctx context.Context, title, content string, topicEmbeddings [][]float32) ([]float64, error) {
+	scores, _, err := s.ScoreItem(ctx, ContentItem{Title: title, Body: content}, topicEmbeddings)
+	return scores, err
+}
+
+// ScoreItem embeds every chunk of the cleaned post once, compares them with every topic
+// embedding and combines the chunk scores with the strategy of the scorer
+func (s *embeddingScorer) ScoreItem(ctx context.Context, item ContentItem, topicEmbeddings [][]float32) ([]float64, []float32, error) {
+	title := CleanText(item.Title)
+	chunks := ChunkWords(PostEmbeddingBody(item), s.options.ChunkSize, s.options.ChunkOverlap, s.options.MaxChunks)
+
+	chunkScores := make([][]float64, 0, len(chunks))
+	chunkEmbeddings := make([][]float32, 0, len(chunks))
+	for _, chunk := range chunks {
+		embedding, err := s.llmClient.GetEmbedding(ctx, PostEmbeddingText(title, chunk))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error getting embedding")
+		}
+		chunkEmbeddings = append(chunkEmbeddings, embedding)
+		chunkScores = append(chunkScores, ScoreEmbedding(embedding, topicEmbeddings))
+	}
+
+	var scores []float64
+	switch s.options.Strategy {
+	case ChunkStrategyMean:
+		scores = meanScores(chunkScores)
+	case ChunkStrategyTitleWeighted:
+		titleEmbedding, err := s.llmClient.GetEmbedding(ctx, title)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error getting title embedding")
+		}
+		titleScores := ScoreEmbedding(titleEmbedding, topicEmbeddings)
+		scores = meanScores(chunkScores)
+		for i := range scores {
+			scores[i] = s.options.TitleWeight*titleScores[i] + (1-s.options.TitleWeight)*scores[i]
+		}
+	default:
+		scores = maxScores(chunkScores)
+	}
+	return scores, meanEmbedding(chunkEmbeddings), nil
+}
+
+// ScoreEmbedding compares a post embedding with every topic embedding, in order
+func ScoreEmbedding(embedding []float32, topicEmbeddings [][]float32) []float64 {
+	scores := make([]float64, len(topicEmbeddings))
+	for i, topicEmbedding := range topicEmbeddings {
+		scores[i] = CosineSimilarity(embedding, topicEmbedding)
+	}
+	return scores
+}
+
+// maxScores returns the best score of the chunks against each topic
+func maxScores(chunkScores [][]float64) []float64 {
+	scores := append([]float64(nil), chunkScores[0]...)
+	for _, chunk := range chunkScores[1:] {
+		for i, score := range chunk {
+			scores[i] = max(scores[i], score)
+		}
+	}
+	return scores
+}
+
+// meanScores returns the mean score of the chunks against each topic
+func meanScores(chunkScores [][]float64) []float64 {
+	scores := make([]float64, len(chunkScores[0]))
+	for _, chunk := range chunkScores {
+		for i, score := range chunk {
+			scores[i] += score / float64(len(chunkScores))
+		}
+	}
+	return scores
+}
+
+// meanEmbedding returns the mean of the embeddings, the embedding itself when there is one
+func meanEmbedding(embeddings [][]float32) []float32 {
+	if len(embeddings) == 1 {
+		return embeddings[0]
+	}
+	mean := make([]float32, len(embeddings[0]))
+	for _, embedding := range embeddings {
+		for i := 0; i < len(mean) && i < len(embedding); i++ {
+			mean[i] += embedding[i] / float32(len(embeddings))
+		}
+	}
+	return mean
+}
+
+// PostEmbeddingText returns the text that is embedded for a post, or a chunk of a post
+func PostEmbeddingText(title, content string) string {
+	return fmt.Sprintf("%s. %s", title, content)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
+)
+
+// ============================================================================
+// ScoreItem Tests
+// ============================================================================
+
+func TestEmbeddingScorer_ScoreItem(t *testing.T) {
+	ctx := context.Background()
+	topic := []float32{1, 0}
+	// Two chunks of two words: the first about the topic, the second not
+	item := ContentItem{Title: "**Iterators**", Body: "range func sports news"}
+	options := PreprocessOptions{ChunkSize: 2, ChunkOverlap: 1, MaxChunks: 2}
+
+	newClient := func(t *testing.T) *mock_llm.MockClientInterface {
+		client := mock_llm.NewMockClientInterface(t)
+		client.On("GetEmbedding", ctx, "Iterators. range func").Return([]float32{1, 0}, nil).Once()
+		client.On("GetEmbedding", ctx, "Iterators. func sports").Return([]float32{0, 1}, nil).Once()
+		return client
+	}
+
+	t.Run("Max", func(t *testing.T) {
+		// Arrange
+		scorer := NewEmbeddingScorerWithOptions(newClient(t), options)
+
+		// Act
+		scores, embedding, err := scorer.ScoreItem(ctx, item, [][]float32{topic})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.0, scores[0], 0.0001)
+		assert.Equal(t, []float32{0.5, 0.5}, embedding)
+	})
+
+	t.Run("Mean", func(t *testing.T) {
+		// Arrange
+		options := options
+		options.Strategy = ChunkStrategyMean
+		scorer := NewEmbeddingScorerWithOptions(newClient(t), options)
+
+		// Act
+		scores, _, err := scorer.ScoreItem(ctx, item, [][]float32{topic})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.5, scores[0], 0.0001)
+	})
+
+	t.Run("TitleWeighted", func(t *testing.T) {
+		// Arrange
+		client := newClient(t)
+		client.On("GetEmbedding", ctx, "Iterators").Return([]float32{1, 0}, nil).Once()
+		options := options
+		options.Strategy = ChunkStrategyTitleWeighted
+		options.TitleWeight = 0.5
+		scorer := NewEmbeddingScorerWithOptions(client, options)
+
+		// Act
+		scores, _, err := scorer.ScoreItem(ctx, item, [][]float32{topic})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.75, scores[0], 0.0001)
+	})
+
+	t.Run("CapsChunks", func(t *testing.T) {
+		// Arrange
+		client := mock_llm.NewMockClientInterface(t)
+		client.On("GetEmbedding", ctx, mock.Anything).Return([]float32{1, 0}, nil).Times(3)
+		scorer := NewEmbeddingScorerWithOptions(client, PreprocessOptions{ChunkSize: 10, ChunkOverlap: 2, MaxChunks: 3})
+
+		// Act
+		_, _, err := scorer.ScoreItem(ctx, ContentItem{Title: "Long", Body: strings.Repeat("word ", 500)}, [][]float32{topic})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+)
+
+const (
+	// sentimentPostWords caps the words of a post given to the chat model
+	sentimentPostWords = 500
+	// sentimentCommentWords caps the words of every comment given to the chat model
+	sentimentCommentWords = 100
+)
+
+// SentimentLabel is the sentiment of a post or comment and its stance toward the topics
+type SentimentLabel struct {
+	Sentiment contracts.Sentiment `json:"sentiment"`
+	Stance    contracts.Stance    `json:"stance"`
+}
+
+// PostSentiment is the label of a post and the labels of its comments, in the order they were given
+type PostSentiment struct {
+	Post     SentimentLabel
+	Comments []SentimentLabel
+}
+
+// SentimentAnalyzer classifies the sentiment of posts and their comments and their stance toward topics
+type SentimentAnalyzer interface {
+	Analyze(ctx context.Context, topics []string, item ContentItem, comments []string) (PostSentiment, error)
+}
+
+type llmSentimentAnalyzer struct {
+	llmClient llm.ClientInterface
+	logger    *zap.Logger
+}
+
+// NewLLMSentimentAnalyzer creates a sentiment analyzer that classifies a post and its comments
+// with a single request to the chat model
+func NewLLMSentimentAnalyzer(llmClient llm.ClientInterface, logger *zap.Logger) SentimentAnalyzer {
+	return &llmSentimentAnalyzer{
+		llmClient: llmClient,
+		logger:    logger,
+	}
+}
+
+// Analyze returns the label of the item and of the comments the chat model could classify
+func (a *llmSentimentAnalyzer) Analyze(ctx context.Context, topics []string, item ContentItem, comments []string) (sentiment PostSentiment, err error) {
+	ctx, span := tracing.Start(ctx, "SentimentAnalyzer.Analyze",
+		attribute.String("reddit.post_title", item.Title),
+		attribute.Int("sentiment.comment_count", len(comments)),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	response, err := a.llmClient.Chat(ctx, []llm.Message{
+		{
+			Role:    "user",
+			Content: sentimentPrompt(topics, item, comments),
+		},
+	})
+	if err != nil {
+		return PostSentiment{}, errors.Wrap(err, "error getting chat response")
+	}
+
+	sentiment, ok := parseSentiment(response, len(comments))
+	if !ok {
+		return PostSentiment{}, errors.Errorf("no sentiment in chat response %q", response)
+	}
+	span.SetAttributes(
+		attribute.String("sentiment.label", string(sentiment.Post.Sentiment)),
+		attribute.String("sentiment.stance", string(sentiment.Post.Stance)),
+	)
+	logger.FromContext(ctx, a.logger).Info("Sentiment analyzed",
+		zap.String("title", item.Title),
+		zap.String("sentiment", string(sentiment.Post.Sentiment)),
+		zap.String("stance", string(sentiment.Post.Stance)),
+		zap.Int("comments", len(sentiment.Comments)),
+	)
+	return sentiment, nil
+}
+
+func sentimentPrompt(topics []string, item ContentItem, comments []string) string {
+	content := ChunkWords(CleanText(PostSummaryContent(item)), sentimentPostWords, 0, 1)[0]
+	prompt := fmt.Sprintf(`Classify the sentiment of the following Reddit post and its comments, and their stance toward the topic.
+The sentiment is the overall tone: positive, negative, neutral or mixed.
+The stance is the position toward the topic: favor, against or neutral when there is none.
+
+# Topic: %s
+
+Reddit Post:
+
+# Title: "%s"
+# Content:
+%s
+`, quotedTopics(topics, ", "), item.Title, content)
+
+	if len(comments) > 0 {
+		prompt += "\nComments:\n\n"
+		for i, comment := range comments {
+			prompt += fmt.Sprintf("%d. %s\n", i+1, ChunkWords(comment, sentimentCommentWords, 0, 1)[0])
+		}
+	}
+
+	prompt += `
+Answer with JSON only, with one entry in comments per comment, in order:
+{"post": {"sentiment": "positive", "stance": "favor"}, "comments": [{"sentiment": "negative", "stance": "neutral"}]}
+`
+	return prompt
+}
+
+// parseSentiment reads the JSON object of labels in response, tolerating code fences and text
+// around it. Comments with unknown labels and comments beyond commentCount are dropped.
+func parseSentiment(response string, commentCount int) (PostSentiment, bool) {
+	var parsed struct {
+		Post     SentimentLabel   `json:"post"`
+		Comments []SentimentLabel `json:"comments"`
+	}
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start < 0 || end < start || json.Unmarshal([]byte(response[start:end+1]), &parsed) != nil {
+		return PostSentiment{}, false
+	}
+
+	post, ok := normalizeSentimentLabel(parsed.Post)
+	if !ok {
+		return PostSentiment{}, false
+	}
+	sentiment := PostSentiment{Post: post}
+	for _, comment := range parsed.Comments[:min(len(parsed.Comments), commentCount)] {
+		if label, ok := normalizeSentimentLabel(comment); ok {
+			sentiment.Comments = append(sentiment.Comments, label)
+		}
+	}
+	return sentiment, true
+}
+
+// normalizeSentimentLabel lowercases the label, a missing stance is neutral
+func normalizeSentimentLabel(label SentimentLabel) (SentimentLabel, bool) {
+	label.Sentiment = contracts.Sentiment(strings.ToLower(strings.TrimSpace(string(label.Sentiment))))
+	label.Stance = contracts.Stance(strings.ToLower(strings.TrimSpace(string(label.Stance))))
+	if label.Stance == "" {
+		label.Stance = contracts.StanceNeutral
+	}
+	switch label.Sentiment {
+	case contracts.SentimentPositive, contracts.SentimentNegative, contracts.SentimentNeutral, contracts.SentimentMixed:
+	default:
+		return SentimentLabel{}, false
+	}
+	switch label.Stance {
+	case contracts.StanceFavor, contracts.StanceAgainst, contracts.StanceNeutral:
+	default:
+		return SentimentLabel{}, false
+	}
+	return label, true
+}
+
+// countSentiment adds a post or comment of the label to breakdown
+func countSentiment(breakdown *contracts.SentimentBreakdownDto, label SentimentLabel) {
+	breakdown.Total++
+	countLabel(&breakdown.Sentiments, &breakdown.Stances, label)
+}
+
+// countLabel adds the sentiment and the stance of the label to the counts
+func countLabel(sentiments *contracts.SentimentCountsDto, stances *contracts.StanceCountsDto, label SentimentLabel) {
+	switch label.Sentiment {
+	case contracts.SentimentPositive:
+		sentiments.Positive++
+	case contracts.SentimentNegative:
+		sentiments.Negative++
+	case contracts.SentimentNeutral:
+		sentiments.Neutral++
+	case contracts.SentimentMixed:
+		sentiments.Mixed++
+	}
+	switch label.Stance {
+	case contracts.StanceFavor:
+		stances.Favor++
+	case contracts.StanceAgainst:
+		stances.Against++
+	case contracts.StanceNeutral:
+		stances.Neutral++
+	}
+}
+
+// addSentimentBreakdown adds the counts of other to breakdown
+func addSentimentBreakdown(breakdown *contracts.SentimentBreakdownDto, other contracts.SentimentBreakdownDto) {
+	breakdown.Total += other.Total
+	breakdown.Sentiments.Positive += other.Sentiments.Positive
+	breakdown.Sentiments.Negative += other.Sentiments.Negative
+	breakdown.Sentiments.Neutral += other.Sentiments.Neutral
+	breakdown.Sentiments.Mixed += other.Sentiments.Mixed
+	breakdown.Stances.Favor += other.Stances.Favor
+	breakdown.Stances.Against += other.Stances.Against
+	breakdown.Stances.Neutral += other.Stances.Neutral
+}
+
+// SentimentBySubreddit totals the sentiments of the posts that have one by subreddit or
+// community, in the order they first appear
+func SentimentBySubreddit(posts []contracts.SubRedditPostDto) []contracts.SubredditSentimentDto {
+	totals := make([]contracts.SubredditSentimentDto, 0)
+	indexes := make(map[string]int)
+	for _, post := range posts {
+		if post.Sentiment == nil {
+			continue
+		}
+		index, found := indexes[post.SubredditName]
+		if !found {
+			index = len(totals)
+			indexes[post.SubredditName] = index
+			totals = append(totals, contracts.SubredditSentimentDto{SubredditName: post.SubredditName})
+		}
+		countSentiment(&totals[index].Posts, SentimentLabel{Sentiment: post.Sentiment.Sentiment, Stance: post.Sentiment.Stance})
+		if post.Sentiment.Comments != nil {
+			addSentimentBreakdown(&totals[index].Comments, *post.Sentiment.Comments)
+		}
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+	return totals
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/llm"
+	mock_llm "github.com/ReyOrtiz/reddit-content-analyzer/mocks/llm"
+)
+
+// ============================================================================
+// SentimentAnalyzer Tests
+// ============================================================================
+
+func TestLLMSentimentAnalyzer_Analyze(t *testing.T) {
+	item := ContentItem{Title: "Generics in Go", Body: "I **love** them"}
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		analyzer := NewLLMSentimentAnalyzer(mockLLMClient, zap.NewNop())
+
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+			return strings.Contains(messages[0].Content, `# Topic: "go generics"`) &&
+				strings.Contains(messages[0].Content, "I love them") &&
+				strings.Contains(messages[0].Content, "1. Too slow to compile\n")
+		})).Return("```json\n{\"post\": {\"sentiment\": \"positive\", \"stance\": \"favor\"}, \"comments\": [{\"sentiment\": \"negative\", \"stance\": \"against\"}]}\n```", nil)
+
+		// Act
+		sentiment, err := analyzer.Analyze(context.Background(), []string{"go generics"}, item, []string{"Too slow to compile"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, PostSentiment{
+			Post:     SentimentLabel{Sentiment: contracts.SentimentPositive, Stance: contracts.StanceFavor},
+			Comments: []SentimentLabel{{Sentiment: contracts.SentimentNegative, Stance: contracts.StanceAgainst}},
+		}, sentiment)
+	})
+
+	t.Run("ChatError", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		analyzer := NewLLMSentimentAnalyzer(mockLLMClient, zap.NewNop())
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return("", errors.New("chat service unavailable"))
+
+		// Act
+		_, err := analyzer.Analyze(context.Background(), []string{"go generics"}, item, nil)
+
+		// Assert
+		assert.ErrorContains(t, err, "error getting chat response")
+	})
+
+	t.Run("NoSentiment", func(t *testing.T) {
+		// Arrange
+		mockLLMClient := mock_llm.NewMockClientInterface(t)
+		analyzer := NewLLMSentimentAnalyzer(mockLLMClient, zap.NewNop())
+		mockLLMClient.EXPECT().Chat(mock.Anything, mock.Anything).Return(`{"post": {"sentiment": "happy"}}`, nil)
+
+		// Act
+		_, err := analyzer.Analyze(context.Background(), []string{"go generics"}, item, nil)
+
+		// Assert
+		assert.ErrorContains(t, err, "no sentiment")
+	})
+}
+
+func TestParseSentiment(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		commentCount int
+		expected     PostSentiment
+		ok           bool
+	}{
+		{
+			"MissingStanceIsNeutral",
+			`Sure: {"post": {"sentiment": "Mixed"}}`,
+			0,
+			PostSentiment{Post: SentimentLabel{Sentiment: contracts.SentimentMixed, Stance: contracts.StanceNeutral}},
+			true,
+		},
+		{
+			"DropsUnknownAndExtraComments",
+			`{"post": {"sentiment": "neutral", "stance": "neutral"}, "comments": [{"sentiment": "angry"}, {"sentiment": "positive", "stance": "favor"}, {"sentiment": "negative"}]}`,
+			2,
+			PostSentiment{
+				Post:     SentimentLabel{Sentiment: contracts.SentimentNeutral, Stance: contracts.StanceNeutral},
+				Comments: []SentimentLabel{{Sentiment: contracts.SentimentPositive, Stance: contracts.StanceFavor}},
+			},
+			true,
+		},
+		{"NoJSON", "positive", 0, PostSentiment{}, false},
+		{"UnknownStance", `{"post": {"sentiment": "positive", "stance": "maybe"}}`, 0, PostSentiment{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			sentiment, ok := parseSentiment(tt.response, tt.commentCount)
+
+			// Assert
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, sentiment)
+		})
+	}
+}
+
+// ============================================================================
+// SentimentBySubreddit Tests
+// ============================================================================
+
+func TestSentimentBySubreddit(t *testing.T) {
+	t.Run("TotalsBySubredditInOrder", func(t *testing.T) {
+		// Arrange
+		posts := []contracts.SubRedditPostDto{
+			{SubredditName: "rust", Sentiment: &contracts.SentimentDto{Sentiment: contracts.SentimentNegative, Stance: contracts.StanceAgainst}},
+			{SubredditName: "golang"},
+			{SubredditName: "golang", Sentiment: &contracts.SentimentDto{
+				Sentiment: contracts.SentimentPositive,
+				Stance:    contracts.StanceFavor,
+				Comments:  &contracts.SentimentBreakdownDto{Total: 1, Sentiments: contracts.SentimentCountsDto{Mixed: 1}, Stances: contracts.StanceCountsDto{Neutral: 1}},
+			}},
+			{SubredditName: "rust", Sentiment: &contracts.SentimentDto{Sentiment: contracts.SentimentNeutral, Stance: contracts.StanceNeutral}},
+		}
+
+		// Act
+		totals := SentimentBySubreddit(posts)
+
+		// Assert
+		assert.Equal(t, []contracts.SubredditSentimentDto{
+			{
+				SubredditName: "rust",
+				Posts: contracts.SentimentBreakdownDto{
+					Total:      2,
+					Sentiments: contracts.SentimentCountsDto{Negative: 1, Neutral: 1},
+					Stances:    contracts.StanceCountsDto{Against: 1, Neutral: 1},
+				},
+			},
+			{
+				SubredditName: "golang",
+				Posts: contracts.SentimentBreakdownDto{
+					Total:      1,
+					Sentiments: contracts.SentimentCountsDto{Positive: 1},
+					Stances:    contracts.StanceCountsDto{Favor: 1},
+				},
+				Comments: contracts.SentimentBreakdownDto{Total: 1, Sentiments: contracts.SentimentCountsDto{Mixed: 1}, Stances: contracts.StanceCountsDto{Neutral: 1}},
+			},
+		}, totals)
+	})
+
+	t.Run("NoSentiment", func(t *testing.T) {
+		// Act
+		totals := SentimentBySubreddit([]contracts.SubRedditPostDto{{SubredditName: "golang"}})
+
+		// Assert
+		assert.Nil(t, totals)
+	})
+}
@@ -0,0 +1,39 @@
+package services
+
+import "strings"
+
+// Prefixes of the sources a request analyzes besides subreddits
+const (
+	SourceUserPrefix       = "user:"
+	SourceDomainPrefix     = "domain:"
+	SourceHackerNewsPrefix = "hackernews:"
+	SourceFeedPrefix       = "feed:"
+	SourceLemmyPrefix      = "lemmy:"
+)
+
+// subredditSource returns the source of the posts fetched from a subreddit, for example r/golang
+func subredditSource(subreddit string) string {
+	return "r/" + subreddit
+}
+
+// isRedditSource reports whether the posts of a source are Reddit posts, which have comments to fetch
+func isRedditSource(source string) bool {
+	return strings.HasPrefix(source, "r/") || strings.HasPrefix(source, SourceUserPrefix) || strings.HasPrefix(source, SourceDomainPrefix)
+}
+
+// SourceKind returns the kind of a source: subreddit, user, domain, hackernews, feed or lemmy, and
+// unknown for anything else. Unlike source names the kinds are a fixed set, fit for metric labels.
+func SourceKind(source string) string {
+	if strings.HasPrefix(source, "r/") {
+		return "subreddit"
+	}
+	for _, prefix := range []string{SourceUserPrefix, SourceDomainPrefix, SourceHackerNewsPrefix, SourceFeedPrefix, SourceLemmyPrefix} {
+		if strings.HasPrefix(source, prefix) {
+			return strings.TrimSuffix(prefix, ":")
+		}
+	}
+	return "unknown"
+}
+
+// SourceRuleMessage describes the sources a request accepts
+const SourceRuleMessage = "must be user:{name}, domain:{host}, hackernews:{tag}, feed:{url} or lemmy:{community@instance}"
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+)
+
+const (
+	// DefaultExclusionThreshold is the score against an exclusion topic from which a post is penalized
+	DefaultExclusionThreshold = 0.6
+	// DefaultExclusionPenalty is the share of the relevance score a penalized post loses
+	DefaultExclusionPenalty = 0.5
+)
+
+// topicQuery holds the embedded topics of a request and how the scores of a post against them are combined
+type topicQuery struct {
+	topics              []string
+	topicEmbeddings     [][]float32
+	exclusions          []string
+	exclusionEmbeddings [][]float32
+	aggregation         contracts.TopicAggregation
+	relevanceThreshold  float64
+	exclusionThreshold  float64
+	exclusionPenalty    float64
+}
+
+// PostRelevance is how relevant a post is to the topics of a request
+type PostRelevance struct {
+	Score           float64
+	TopicScores     []contracts.TopicScoreDto
+	ExclusionScores []contracts.TopicScoreDto
+	IsExcluded      bool
+}
+
+// RequestTopics returns the distinct topics of a request, Topic first
+func RequestTopics(request contracts.RelevanceRequestDto) []string {
+	return distinctTopics(append([]string{request.Topic}, request.Topics...))
+}
+
+func distinctTopics(topics []string) []string {
+	seen := make(map[string]bool, len(topics))
+	distinct := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" || seen[strings.ToLower(topic)] {
+			continue
+		}
+		seen[strings.ToLower(topic)] = true
+		distinct = append(distinct, topic)
+	}
+	return distinct
+}
+
+// embeddings returns the topic embeddings followed by the exclusion embeddings, in the order
+// the scorer scores them
+func (q *topicQuery) embeddings() [][]float32 {
+	embeddings := make([][]float32, 0, len(q.topicEmbeddings)+len(q.exclusionEmbeddings))
+	embeddings = append(embeddings, q.topicEmbeddings...)
+	return append(embeddings, q.exclusionEmbeddings...)
+}
+
+// relevance combines the scores of a post against the topics and exclusions of the query.
+// The topic scores are aggregated with max or mean, and the result is reduced by the exclusion
+// penalty when the post scores at least the exclusion threshold against any exclusion topic.
+func (q *topicQuery) relevance(scores []float64) PostRelevance {
+	relevance := PostRelevance{
+		TopicScores:     make([]contracts.TopicScoreDto, 0, len(q.topics)),
+		ExclusionScores: make([]contracts.TopicScoreDto, 0, len(q.exclusions)),
+	}
+
+	for i, topic := range q.topics {
+		relevance.TopicScores = append(relevance.TopicScores, contracts.TopicScoreDto{Topic: topic, Score: scores[i]})
+		switch {
+		case q.aggregation == contracts.TopicAggregationMean:
+			relevance.Score += scores[i] / float64(len(q.topics))
+		case i == 0 || scores[i] > relevance.Score:
+			relevance.Score = scores[i]
+		}
+	}
+
+	for i, exclusion := range q.exclusions {
+		score := scores[len(q.topics)+i]
+		relevance.ExclusionScores = append(relevance.ExclusionScores, contracts.TopicScoreDto{Topic: exclusion, Score: score})
+		if score >= q.exclusionThreshold {
+			relevance.IsExcluded = true
+		}
+	}
+	if relevance.IsExcluded {
+		relevance.Score *= 1 - q.exclusionPenalty
+	}
+	return relevance
+}
+
+// quotedTopics formats topics for prompts and search queries, for example "a", "b"
+func quotedTopics(topics []string, separator string) string {
+	quoted := make([]string, len(topics))
+	for i, topic := range topics {
+		quoted[i] = `"` + topic + `"`
+	}
+	return strings.Join(quoted, separator)
+}
+
+// searchQuery returns the Reddit search query matching any of the topics. A single topic is
+// searched as is so Reddit can match its words in any order.
+func searchQuery(topics []string) string {
+	if len(topics) == 1 {
+		return topics[0]
+	}
+	return quotedTopics(topics, " OR ")
+}
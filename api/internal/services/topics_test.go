@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+)
+
+// ============================================================================
+// RequestTopics Tests
+// ============================================================================
+
+func TestRequestTopics(t *testing.T) {
+	t.Run("TopicFirstWithoutDuplicates", func(t *testing.T) {
+		// Arrange
+		request := contracts.RelevanceRequestDto{
+			Topic:  "Go generics",
+			Topics: []string{"go generics", " Go iterators ", ""},
+		}
+
+		// Act
+		topics := RequestTopics(request)
+
+		// Assert
+		assert.Equal(t, []string{"Go generics", "Go iterators"}, topics)
+	})
+
+	t.Run("TopicsOnly", func(t *testing.T) {
+		// Act
+		topics := RequestTopics(contracts.RelevanceRequestDto{Topics: []string{"rust", "zig"}})
+
+		// Assert
+		assert.Equal(t, []string{"rust", "zig"}, topics)
+	})
+}
+
+// ============================================================================
+// topicQuery Tests
+// ============================================================================
+
+func TestTopicQuery_Relevance(t *testing.T) {
+	newQuery := func(aggregation contracts.TopicAggregation) *topicQuery {
+		return &topicQuery{
+			topics:             []string{"Go generics", "Go iterators"},
+			exclusions:         []string{"job postings"},
+			aggregation:        aggregation,
+			exclusionThreshold: 0.6,
+			exclusionPenalty:   0.5,
+		}
+	}
+
+	t.Run("MaxAggregation", func(t *testing.T) {
+		// Act
+		relevance := newQuery(contracts.TopicAggregationMax).relevance([]float64{0.4, 0.8, 0.1})
+
+		// Assert
+		assert.InDelta(t, 0.8, relevance.Score, 0.0001)
+		assert.False(t, relevance.IsExcluded)
+		assert.Equal(t, []contracts.TopicScoreDto{{Topic: "Go generics", Score: 0.4}, {Topic: "Go iterators", Score: 0.8}}, relevance.TopicScores)
+		assert.Equal(t, []contracts.TopicScoreDto{{Topic: "job postings", Score: 0.1}}, relevance.ExclusionScores)
+	})
+
+	t.Run("DefaultsToMax", func(t *testing.T) {
+		// Act
+		relevance := newQuery("").relevance([]float64{-0.2, -0.1, 0.1})
+
+		// Assert
+		assert.InDelta(t, -0.1, relevance.Score, 0.0001)
+	})
+
+	t.Run("MeanAggregation", func(t *testing.T) {
+		// Act
+		relevance := newQuery(contracts.TopicAggregationMean).relevance([]float64{0.4, 0.8, 0.1})
+
+		// Assert
+		assert.InDelta(t, 0.6, relevance.Score, 0.0001)
+	})
+
+	t.Run("ExclusionPenalty", func(t *testing.T) {
+		// Act
+		relevance := newQuery(contracts.TopicAggregationMax).relevance([]float64{0.4, 0.8, 0.7})
+
+		// Assert
+		assert.True(t, relevance.IsExcluded)
+		assert.InDelta(t, 0.4, relevance.Score, 0.0001)
+	})
+}
+
+// ============================================================================
+// searchQuery Tests
+// ============================================================================
+
+func TestSearchQuery(t *testing.T) {
+	t.Run("SingleTopic", func(t *testing.T) {
+		assert.Equal(t, "golang generics", searchQuery([]string{"golang generics"}))
+	})
+
+	t.Run("SeveralTopics", func(t *testing.T) {
+		assert.Equal(t, `"golang generics" OR "golang iterators"`, searchQuery([]string{"golang generics", "golang iterators"}))
+	})
+}
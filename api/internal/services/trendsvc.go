@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/logger"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/runstore"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/tracing"
+)
+
+const (
+	// DefaultTrendBuckets is how many buckets a trend covers when the request has no start
+	DefaultTrendBuckets = 30
+	// DefaultBaselineWindow is how many previous buckets the spike baseline averages
+	DefaultBaselineWindow = 7
+	// DefaultSpikeThreshold is how many standard deviations above the baseline a spike is
+	DefaultSpikeThreshold = 2.0
+	// maxTrendBuckets caps the buckets of a trend, for example 2000 hours is about 12 weeks
+	maxTrendBuckets = 2000
+)
+
+// TrendService builds time series of the posts the stored runs of a topic evaluated
+type TrendService interface {
+	GetTrends(ctx context.Context, request contracts.TrendRequestDto) (contracts.TrendResponseDto, error)
+}
+
+type trendService struct {
+	logger *zap.Logger
+	store  runstore.Store
+	now    func() time.Time
+}
+
+func NewTrendService(store runstore.Store, logger *zap.Logger) TrendService {
+	return &trendService{
+		logger: logger,
+		store:  store,
+		now:    time.Now,
+	}
+}
+
+// GetTrends buckets the posts the runs of the topic evaluated by the time they were created, per
+// subreddit, and flags the buckets with unusually many relevant posts
+func (s *trendService) GetTrends(ctx context.Context, request contracts.TrendRequestDto) (response contracts.TrendResponseDto, err error) {
+	ctx, span := tracing.Start(ctx, "TrendService.GetTrends", attribute.String("relevance.topic", request.Topic))
+	defer func() { tracing.End(span, err) }()
+
+	granularity := request.Granularity
+	if granularity == "" {
+		granularity = contracts.TrendGranularityDay
+	}
+	to := request.To
+	if to.IsZero() {
+		to = s.now()
+	}
+	to = to.UTC()
+	from := request.From
+	if from.IsZero() {
+		from = addBuckets(bucketStart(to, granularity), granularity, 1-DefaultTrendBuckets)
+	}
+	from = bucketStart(from.UTC(), granularity)
+	if !from.Before(to) {
+		return contracts.TrendResponseDto{}, apperrors.Validation(nil, []apperrors.FieldError{{Field: "from", Rule: "before_to", Message: "must be before to"}})
+	}
+	starts := bucketStarts(from, to, granularity)
+	if len(starts) > maxTrendBuckets {
+		return contracts.TrendResponseDto{}, apperrors.Validation(nil, []apperrors.FieldError{{
+			Field:   "from",
+			Rule:    "max_buckets",
+			Message: fmt.Sprintf("must be at most %d %ss before to", maxTrendBuckets, granularity),
+		}})
+	}
+
+	baselineWindow := request.BaselineWindow
+	if baselineWindow <= 0 {
+		baselineWindow = DefaultBaselineWindow
+	}
+	spikeThreshold := request.SpikeThreshold
+	if spikeThreshold == 0 {
+		spikeThreshold = DefaultSpikeThreshold
+	}
+	// The buckets of the baseline window before from are bucketed too, so the first buckets of
+	// the trend have a baseline, and trimmed from the response
+	baselineFrom := addBuckets(from, granularity, -baselineWindow)
+	starts = append(bucketStarts(baselineFrom, from, granularity), starts...)
+
+	// A run only evaluates posts created before it, so older runs have no post in the range
+	runs, err := s.store.List(ctx, runstore.Filter{Topic: request.Topic, Since: baselineFrom})
+	if err != nil {
+		return contracts.TrendResponseDto{}, errors.Wrap(err, "error listing runs")
+	}
+	span.SetAttributes(attribute.Int("trend.run_count", len(runs)))
+	logger.FromContext(ctx, s.logger).Info("Building trends",
+		zap.String("topic", request.Topic),
+		zap.String("granularity", string(granularity)),
+		zap.Int("runs", len(runs)),
+	)
+
+	trends := make([]contracts.SubredditTrendDto, 0)
+	for subreddit, posts := range postsBySubreddit(latestPosts(runs), request.Subreddits, baselineFrom, to) {
+		buckets := bucketPosts(posts, starts, granularity)
+		flagSpikes(buckets, baselineWindow, spikeThreshold)
+		trend := contracts.SubredditTrendDto{SubredditName: subreddit, Buckets: buckets[baselineWindow:]}
+		postCount := 0
+		for _, bucket := range trend.Buckets {
+			postCount += bucket.Posts
+			trend.RelevantPosts += bucket.RelevantPosts
+		}
+		// Subreddits with posts in the baseline window only are not part of the trend
+		if postCount == 0 {
+			continue
+		}
+		trends = append(trends, trend)
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].RelevantPosts != trends[j].RelevantPosts {
+			return trends[i].RelevantPosts > trends[j].RelevantPosts
+		}
+		return trends[i].SubredditName < trends[j].SubredditName
+	})
+
+	return contracts.TrendResponseDto{
+		Topic:       request.Topic,
+		Granularity: granularity,
+		From:        from,
+		To:          to,
+		Runs:        len(runs),
+		Subreddits:  trends,
+	}, nil
+}
+
+// latestPosts returns the posts of the runs, each post once as the latest run evaluated it.
+// Runs are listed oldest first.
+func latestPosts(runs []runstore.Run) []runstore.Post {
+	indexes := make(map[string]int)
+	posts := make([]runstore.Post, 0)
+	for _, run := range runs {
+		for _, post := range run.Posts {
+			key := post.ID
+			if key == "" {
+				key = post.URL
+			}
+			if index, found := indexes[key]; found {
+				posts[index] = post
+				continue
+			}
+			indexes[key] = len(posts)
+			posts = append(posts, post)
+		}
+	}
+	return posts
+}
+
+// postsBySubreddit groups the posts created in [from, to) by subreddit, keeping only the
+// subreddits asked for, ignoring case, when there are some
+func postsBySubreddit(posts []runstore.Post, subreddits []string, from, to time.Time) map[string][]runstore.Post {
+	wanted := make(map[string]bool, len(subreddits))
+	for _, subreddit := range subreddits {
+		wanted[strings.ToLower(subreddit)] = true
+	}
+	grouped := make(map[string][]runstore.Post)
+	for _, post := range posts {
+		if post.CreatedAt.Before(from) || !post.CreatedAt.Before(to) {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[strings.ToLower(post.Subreddit)] {
+			continue
+		}
+		grouped[post.Subreddit] = append(grouped[post.Subreddit], post)
+	}
+	return grouped
+}
+
+// bucketPosts describes the posts of every bucket, buckets without posts included
+func bucketPosts(posts []runstore.Post, starts []time.Time, granularity contracts.TrendGranularity) []contracts.TrendBucketDto {
+	buckets := make([]contracts.TrendBucketDto, len(starts))
+	relevanceSums := make([]float64, len(starts))
+	for i, start := range starts {
+		buckets[i].Start = start
+	}
+	for _, post := range posts {
+		i := bucketIndex(starts[0], bucketStart(post.CreatedAt.UTC(), granularity), granularity)
+		if i < 0 || i >= len(buckets) {
+			continue
+		}
+		bucket := &buckets[i]
+		bucket.Posts++
+		relevanceSums[i] += post.RelevanceScore
+		if !post.IsRelevant {
+			continue
+		}
+		bucket.RelevantPosts++
+		bucket.AverageScore += float64(post.Score)
+		bucket.AverageComments += float64(post.NumComments)
+		if post.Sentiment != "" {
+			countLabel(&bucket.Sentiments, &bucket.Stances, SentimentLabel{
+				Sentiment: contracts.Sentiment(post.Sentiment),
+				Stance:    contracts.Stance(post.Stance),
+			})
+		}
+	}
+	for i := range buckets {
+		if buckets[i].Posts > 0 {
+			buckets[i].AverageRelevance = relevanceSums[i] / float64(buckets[i].Posts)
+		}
+		if buckets[i].RelevantPosts > 0 {
+			buckets[i].AverageScore /= float64(buckets[i].RelevantPosts)
+			buckets[i].AverageComments /= float64(buckets[i].RelevantPosts)
+		}
+	}
+	return buckets
+}
+
+// flagSpikes sets the baseline of the buckets that have window previous buckets, as the mean of
+// their relevant posts, and flags the buckets above it by threshold standard deviations. The
+// standard deviation is at least 1, so a quiet series needs a few more posts than usual to spike.
+func flagSpikes(buckets []contracts.TrendBucketDto, window int, threshold float64) {
+	for i := window; i < len(buckets); i++ {
+		var sum, squares float64
+		for _, previous := range buckets[i-window : i] {
+			sum += float64(previous.RelevantPosts)
+			squares += float64(previous.RelevantPosts * previous.RelevantPosts)
+		}
+		mean := sum / float64(window)
+		deviation := math.Sqrt(max(squares/float64(window)-mean*mean, 0))
+		buckets[i].Baseline = mean
+		buckets[i].IsSpike = float64(buckets[i].RelevantPosts) > mean+threshold*max(deviation, 1)
+	}
+}
+
+// bucketStart returns the start of the bucket of t, which is in UTC
+func bucketStart(t time.Time, granularity contracts.TrendGranularity) time.Time {
+	switch granularity {
+	case contracts.TrendGranularityHour:
+		return t.Truncate(time.Hour)
+	case contracts.TrendGranularityWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// addBuckets moves the bucket start t by n buckets
+func addBuckets(t time.Time, granularity contracts.TrendGranularity, n int) time.Time {
+	switch granularity {
+	case contracts.TrendGranularityHour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case contracts.TrendGranularityWeek:
+		return t.AddDate(0, 0, 7*n)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// bucketIndex returns how many buckets start is after first, both bucket starts
+func bucketIndex(first, start time.Time, granularity contracts.TrendGranularity) int {
+	switch granularity {
+	case contracts.TrendGranularityHour:
+		return int(start.Sub(first) / time.Hour)
+	case contracts.TrendGranularityWeek:
+		return int(start.Sub(first).Hours()/24) / 7
+	default:
+		return int(start.Sub(first).Hours() / 24)
+	}
+}
+
+// bucketStarts returns the starts of the buckets from the bucket start from up to to, excluded,
+// stopping past maxTrendBuckets
+func bucketStarts(from, to time.Time, granularity contracts.TrendGranularity) []time.Time {
+	starts := make([]time.Time, 0)
+	for start := from; start.Before(to) && len(starts) <= maxTrendBuckets; start = addBuckets(start, granularity, 1) {
+		starts = append(starts, start)
+	}
+	return starts
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/apperrors"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/contracts"
+	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/runstore"
+	mock_services "github.com/ReyOrtiz/reddit-content-analyzer/mocks/services"
+)
+
+// failingStore is a run store whose every call fails
+type failingStore struct{}
+
+func (failingStore) Save(ctx context.Context, run runstore.Run) error {
+	return errors.New("disk full")
+}
+
+func (failingStore) List(ctx context.Context, filter runstore.Filter) ([]runstore.Run, error) {
+	return nil, errors.New("disk full")
+}
+
+func newTrendServiceForTesting(store runstore.Store, now time.Time) *trendService {
+	return &trendService{
+		logger: zap.NewNop(),
+		store:  store,
+		now:    func() time.Time { return now },
+	}
+}
+
+// ============================================================================
+// GetTrends Tests
+// ============================================================================
+
+func TestTrendService_GetTrends(t *testing.T) {
+	// Wednesday
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("BucketsPostsByDay", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		store := runstore.NewMemoryStore(0)
+		require.NoError(t, store.Save(ctx, runstore.Run{ID: "1", Topics: []string{"go generics"}, CreatedAt: day.Add(30 * time.Hour), Posts: []runstore.Post{
+			{ID: "a", Subreddit: "golang", CreatedAt: day.Add(2 * time.Hour), IsRelevant: true, RelevanceScore: 0.8, Score: 10, NumComments: 4, Sentiment: "positive", Stance: "favor"},
+			{ID: "b", Subreddit: "golang", CreatedAt: day.Add(3 * time.Hour), RelevanceScore: 0.2},
+			{ID: "c", Subreddit: "rust", CreatedAt: day.Add(26 * time.Hour), IsRelevant: true, RelevanceScore: 0.6},
+		}}))
+		// The later run sees post a again with more engagement, it counts once
+		require.NoError(t, store.Save(ctx, runstore.Run{ID: "2", Topics: []string{"Go Generics"}, CreatedAt: day.Add(40 * time.Hour), Posts: []runstore.Post{
+			{ID: "a", Subreddit: "golang", CreatedAt: day.Add(2 * time.Hour), IsRelevant: true, RelevanceScore: 0.8, Score: 20, NumComments: 6},
+			{ID: "d", Subreddit: "golang", CreatedAt: day.Add(28 * time.Hour), IsRelevant: true, RelevanceScore: 0.9, Score: 5, Sentiment: "negative", Stance: "against"},
+		}}))
+		require.NoError(t, store.Save(ctx, runstore.Run{ID: "3", Topics: []string{"rust"}, CreatedAt: day.Add(40 * time.Hour), Posts: []runstore.Post{
+			{ID: "e", Subreddit: "golang", CreatedAt: day.Add(2 * time.Hour), IsRelevant: true},
+		}}))
+		service := newTrendServiceForTesting(store, day.Add(47*time.Hour))
+
+		// Act
+		response, err := service.GetTrends(ctx, contracts.TrendRequestDto{Topic: "go generics", From: day.Add(5 * time.Hour)})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, response.Runs)
+		assert.Equal(t, contracts.TrendGranularityDay, response.Granularity)
+		assert.Equal(t, day, response.From)
+		assert.Equal(t, day.Add(47*time.Hour), response.To)
+		assert.Equal(t, []contracts.SubredditTrendDto{
+			{
+				SubredditName: "golang",
+				RelevantPosts: 2,
+				Buckets: []contracts.TrendBucketDto{
+					{Start: day, Posts: 2, RelevantPosts: 1, AverageRelevance: 0.5, AverageScore: 20, AverageComments: 6},
+					{
+						Start: day.AddDate(0, 0, 1), Posts: 1, RelevantPosts: 1, AverageRelevance: 0.9, AverageScore: 5,
+						Sentiments: contracts.SentimentCountsDto{Negative: 1},
+						Stances:    contracts.StanceCountsDto{Against: 1},
+						Baseline:   1.0 / DefaultBaselineWindow,
+					},
+				},
+			},
+			{
+				SubredditName: "rust",
+				RelevantPosts: 1,
+				Buckets: []contracts.TrendBucketDto{
+					{Start: day},
+					{Start: day.AddDate(0, 0, 1), Posts: 1, RelevantPosts: 1, AverageRelevance: 0.6},
+				},
+			},
+		}, response.Subreddits)
+	})
+
+	t.Run("WeeksStartOnMonday", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		store := runstore.NewMemoryStore(0)
+		require.NoError(t, store.Save(ctx, runstore.Run{ID: "1", Topics: []string{"go"}, CreatedAt: day, Posts: []runstore.Post{
+			{ID: "a", Subreddit: "golang", CreatedAt: day.Add(-time.Hour), IsRelevant: true},
+		}}))
+		service := newTrendServiceForTesting(store, day.Add(time.Hour))
+
+		// Act
+		response, err := service.GetTrends(ctx, contracts.TrendRequestDto{Topic: "go", Granularity: contracts.TrendGranularityWeek, Subreddits: []string{"GoLang"}})
+
+		// Assert
+		assert.NoError(t, err)
+		monday := day.AddDate(0, 0, -2)
+		assert.Equal(t, monday.AddDate(0, 0, -7*29), response.From)
+		assert.Len(t, response.Subreddits, 1)
+		buckets := response.Subreddits[0].Buckets
+		assert.Len(t, buckets, DefaultTrendBuckets)
+		assert.Equal(t, monday, buckets[len(buckets)-1].Start)
+		assert.Equal(t, 1, buckets[len(buckets)-1].RelevantPosts)
+	})
+
+	t.Run("SpikeInFirstBucket", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		store := runstore.NewMemoryStore(0)
+		// One relevant post a day for the week before from, then five on its first day
+		posts := make([]runstore.Post, 0)
+		for i := 1; i <= DefaultBaselineWindow; i++ {
+			posts = append(posts, runstore.Post{ID: fmt.Sprintf("before-%d", i), Subreddit: "golang", CreatedAt: day.AddDate(0, 0, -i), IsRelevant: true})
+		}
+		for i := range 5 {
+			posts = append(posts, runstore.Post{ID: fmt.Sprintf("spike-%d", i), Subreddit: "golang", CreatedAt: day.Add(time.Hour), IsRelevant: true})
+		}
+		// A subreddit with posts in the baseline window only has no trend
+		posts = append(posts, runstore.Post{ID: "rust", Subreddit: "rust", CreatedAt: day.AddDate(0, 0, -1), IsRelevant: true})
+		require.NoError(t, store.Save(ctx, runstore.Run{ID: "1", Topics: []string{"go"}, CreatedAt: day.Add(2 * time.Hour), Posts: posts}))
+		service := newTrendServiceForTesting(store, day.Add(3*time.Hour))
+
+		// Act
+		response, err := service.GetTrends(ctx, contracts.TrendRequestDto{Topic: "go", From: day})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, day, response.From)
+		require.Len(t, response.Subreddits, 1)
+		trend := response.Subreddits[0]
+		assert.Equal(t, "golang", trend.SubredditName)
+		assert.Equal(t, 5, trend.RelevantPosts)
+		require.Len(t, trend.Buckets, 1)
+		assert.Equal(t, day, trend.Buckets[0].Start)
+		assert.InDelta(t, 1.0, trend.Buckets[0].Baseline, 0.0001)
+		assert.True(t, trend.Buckets[0].IsSpike)
+	})
+
+	t.Run("FromAfterTo", func(t *testing.T) {
+		// Arrange
+		service := newTrendServiceForTesting(runstore.NewMemoryStore(0), day)
+
+		// Act
+		_, err := service.GetTrends(context.Background(), contracts.TrendRequestDto{Topic: "go", From: day.AddDate(0, 0, 1), To: day})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeValidationFailed, apperrors.CodeOf(err))
+	})
+
+	t.Run("TooManyBuckets", func(t *testing.T) {
+		// Arrange
+		service := newTrendServiceForTesting(runstore.NewMemoryStore(0), day)
+
+		// Act
+		_, err := service.GetTrends(context.Background(), contracts.TrendRequestDto{
+			Topic:       "go",
+			Granularity: contracts.TrendGranularityHour,
+			From:        day.AddDate(-1, 0, 0),
+		})
+
+		// Assert
+		assert.Equal(t, apperrors.CodeValidationFailed, apperrors.CodeOf(err))
+	})
+
+	t.Run("StoreError", func(t *testing.T) {
+		// Arrange
+		service := newTrendServiceForTesting(failingStore{}, day)
+
+		// Act
+		_, err := service.GetTrends(context.Background(), contracts.TrendRequestDto{Topic: "go"})
+
+		// Assert
+		assert.ErrorContains(t, err, "error listing runs")
+	})
+}
+
+func TestFlagSpikes(t *testing.T) {
+	buckets := func(counts ...int) []contracts.TrendBucketDto {
+		result := make([]contracts.TrendBucketDto, 0, len(counts))
+		for _, count := range counts {
+			result = append(result, contracts.TrendBucketDto{RelevantPosts: count})
+		}
+		return result
+	}
+	spikes := func(buckets []contracts.TrendBucketDto) []int {
+		result := make([]int, 0)
+		for i, bucket := range buckets {
+			if bucket.IsSpike {
+				result = append(result, i)
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name      string
+		buckets   []contracts.TrendBucketDto
+		window    int
+		threshold float64
+		expected  []int
+	}{
+		{"SpikeAboveBaseline", buckets(4, 5, 6, 5, 20, 5), 3, 2, []int{4}},
+		{"NoSpikeWithoutFullWindow", buckets(20, 1, 1), 3, 2, []int{}},
+		{"QuietSeriesNeedsSeveralPosts", buckets(0, 0, 0, 2, 0, 3), 3, 2, []int{5}},
+		{"LowerThreshold", buckets(4, 5, 6, 5, 8, 5), 3, 1, []int{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			flagSpikes(tt.buckets, tt.window, tt.threshold)
+
+			// Assert
+			assert.Equal(t, tt.expected, spikes(tt.buckets))
+		})
+	}
+
+	t.Run("Baseline", func(t *testing.T) {
+		// Arrange
+		series := buckets(1, 2, 3, 4)
+
+		// Act
+		flagSpikes(series, 2, 2)
+
+		// Assert
+		assert.Equal(t, []float64{0, 0, 1.5, 2.5}, []float64{series[0].Baseline, series[1].Baseline, series[2].Baseline, series[3].Baseline})
+	})
+}
+
+// ============================================================================
+// RunRecordingService Tests
+// ============================================================================
+
+func TestRunRecordingService_GetRelevantPosts(t *testing.T) {
+	request := contracts.RelevanceRequestDto{Topic: "go generics", Topics: []string{"go iterators"}}
+	created := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	t.Run("SavesRun", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockRelevanceService := mock_services.NewMockRelevanceService(t)
+		store := runstore.NewMemoryStore(0)
+		service := NewRunRecordingService(mockRelevanceService, store, zap.NewNop())
+
+		response := contracts.RelevanceResponseDto{Posts: []contracts.SubRedditPostDto{{
+			ID: "a", SubredditName: "golang", Url: "https://reddit.com/a", CreatedAt: created, IsRelevant: true, RelevanceScore: 0.8,
+			Score: 10, NumComments: 3, Sentiment: &contracts.SentimentDto{Sentiment: contracts.SentimentMixed, Stance: contracts.StanceFavor},
+		}}}
+		mockRelevanceService.EXPECT().GetRelevantPosts(ctx, request).Return(response, nil)
+
+		// Act
+		result, err := service.GetRelevantPosts(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, response, result)
+		runs, _ := store.List(ctx, runstore.Filter{Topic: "go iterators"})
+		assert.Len(t, runs, 1)
+		assert.NotEmpty(t, runs[0].ID)
+		assert.Equal(t, []string{"go generics", "go iterators"}, runs[0].Topics)
+		assert.Equal(t, []runstore.Post{{
+			ID: "a", URL: "https://reddit.com/a", Subreddit: "golang", CreatedAt: created, IsRelevant: true, RelevanceScore: 0.8,
+			Score: 10, NumComments: 3, Sentiment: "mixed", Stance: "favor",
+		}}, runs[0].Posts)
+	})
+
+	t.Run("SearchErrorSavesNothing", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockRelevanceService := mock_services.NewMockRelevanceService(t)
+		store := runstore.NewMemoryStore(0)
+		service := NewRunRecordingService(mockRelevanceService, store, zap.NewNop())
+		mockRelevanceService.EXPECT().GetRelevantPosts(ctx, request).Return(contracts.RelevanceResponseDto{}, errors.New("reddit unavailable"))
+
+		// Act
+		_, err := service.GetRelevantPosts(ctx, request)
+
+		// Assert
+		assert.Error(t, err)
+		runs, _ := store.List(ctx, runstore.Filter{})
+		assert.Empty(t, runs)
+	})
+
+	t.Run("StoreErrorKeepsResponse", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockRelevanceService := mock_services.NewMockRelevanceService(t)
+		service := NewRunRecordingService(mockRelevanceService, failingStore{}, zap.NewNop())
+		response := contracts.RelevanceResponseDto{Posts: []contracts.SubRedditPostDto{{ID: "a"}}}
+		mockRelevanceService.EXPECT().GetRelevantPosts(mock.Anything, mock.Anything).Return(response, nil)
+
+		// Act
+		result, err := service.GetRelevantPosts(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, response, result)
+	})
+}
@@ -5,6 +5,8 @@
 package mock_services
 
 import (
+	"context"
+
 	"github.com/ReyOrtiz/reddit-content-analyzer/internal/infra/reddit"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -36,9 +38,237 @@ func (_m *MockRedditService) EXPECT() *MockRedditService_Expecter {
 	return &MockRedditService_Expecter{mock: &_m.Mock}
 }
 
+// AutocompleteSubreddits provides a mock function for the type MockRedditService
+func (_mock *MockRedditService) AutocompleteSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error) {
+	ret := _mock.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutocompleteSubreddits")
+	}
+
+	var r0 *reddit.SubredditListing
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*reddit.SubredditListing, error)); ok {
+		return returnFunc(ctx, query, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *reddit.SubredditListing); ok {
+		r0 = returnFunc(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reddit.SubredditListing)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRedditService_AutocompleteSubreddits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AutocompleteSubreddits'
+type MockRedditService_AutocompleteSubreddits_Call struct {
+	*mock.Call
+}
+
+// AutocompleteSubreddits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+func (_e *MockRedditService_Expecter) AutocompleteSubreddits(ctx interface{}, query interface{}, limit interface{}) *MockRedditService_AutocompleteSubreddits_Call {
+	return &MockRedditService_AutocompleteSubreddits_Call{Call: _e.mock.On("AutocompleteSubreddits", ctx, query, limit)}
+}
+
+func (_c *MockRedditService_AutocompleteSubreddits_Call) Run(run func(ctx context.Context, query string, limit int)) *MockRedditService_AutocompleteSubreddits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedditService_AutocompleteSubreddits_Call) Return(subredditListing *reddit.SubredditListing, err error) *MockRedditService_AutocompleteSubreddits_Call {
+	_c.Call.Return(subredditListing, err)
+	return _c
+}
+
+func (_c *MockRedditService_AutocompleteSubreddits_Call) RunAndReturn(run func(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error)) *MockRedditService_AutocompleteSubreddits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetComments provides a mock function for the type MockRedditService
+func (_mock *MockRedditService) GetComments(ctx context.Context, postID string, limit int) (*reddit.CommentListing, error) {
+	ret := _mock.Called(ctx, postID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 *reddit.CommentListing
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*reddit.CommentListing, error)); ok {
+		return returnFunc(ctx, postID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *reddit.CommentListing); ok {
+		r0 = returnFunc(ctx, postID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reddit.CommentListing)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, postID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRedditService_GetComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComments'
+type MockRedditService_GetComments_Call struct {
+	*mock.Call
+}
+
+// GetComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID string
+//   - limit int
+func (_e *MockRedditService_Expecter) GetComments(ctx interface{}, postID interface{}, limit interface{}) *MockRedditService_GetComments_Call {
+	return &MockRedditService_GetComments_Call{Call: _e.mock.On("GetComments", ctx, postID, limit)}
+}
+
+func (_c *MockRedditService_GetComments_Call) Run(run func(ctx context.Context, postID string, limit int)) *MockRedditService_GetComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedditService_GetComments_Call) Return(commentListing *reddit.CommentListing, err error) *MockRedditService_GetComments_Call {
+	_c.Call.Return(commentListing, err)
+	return _c
+}
+
+func (_c *MockRedditService_GetComments_Call) RunAndReturn(run func(ctx context.Context, postID string, limit int) (*reddit.CommentListing, error)) *MockRedditService_GetComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDomainPosts provides a mock function for the type MockRedditService
+func (_mock *MockRedditService) GetDomainPosts(ctx context.Context, domain string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	ret := _mock.Called(ctx, domain, limit, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDomainPosts")
+	}
+
+	var r0 *reddit.RedditResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, reddit.ListingOptions) (*reddit.RedditResponse, error)); ok {
+		return returnFunc(ctx, domain, limit, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, reddit.ListingOptions) *reddit.RedditResponse); ok {
+		r0 = returnFunc(ctx, domain, limit, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reddit.RedditResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, reddit.ListingOptions) error); ok {
+		r1 = returnFunc(ctx, domain, limit, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRedditService_GetDomainPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDomainPosts'
+type MockRedditService_GetDomainPosts_Call struct {
+	*mock.Call
+}
+
+// GetDomainPosts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+//   - limit int
+//   - options reddit.ListingOptions
+func (_e *MockRedditService_Expecter) GetDomainPosts(ctx interface{}, domain interface{}, limit interface{}, options interface{}) *MockRedditService_GetDomainPosts_Call {
+	return &MockRedditService_GetDomainPosts_Call{Call: _e.mock.On("GetDomainPosts", ctx, domain, limit, options)}
+}
+
+func (_c *MockRedditService_GetDomainPosts_Call) Run(run func(ctx context.Context, domain string, limit int, options reddit.ListingOptions)) *MockRedditService_GetDomainPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 reddit.ListingOptions
+		if args[3] != nil {
+			arg3 = args[3].(reddit.ListingOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedditService_GetDomainPosts_Call) Return(redditResponse *reddit.RedditResponse, err error) *MockRedditService_GetDomainPosts_Call {
+	_c.Call.Return(redditResponse, err)
+	return _c
+}
+
+func (_c *MockRedditService_GetDomainPosts_Call) RunAndReturn(run func(ctx context.Context, domain string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error)) *MockRedditService_GetDomainPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPosts provides a mock function for the type MockRedditService
-func (_mock *MockRedditService) GetPosts(subreddit string, limit int) (*reddit.RedditResponse, error) {
-	ret := _mock.Called(subreddit, limit)
+func (_mock *MockRedditService) GetPosts(ctx context.Context, subreddit string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	ret := _mock.Called(ctx, subreddit, limit, options)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPosts")
@@ -46,18 +276,18 @@ func (_mock *MockRedditService) GetPosts(subreddit string, limit int) (*reddit.R
 
 	var r0 *reddit.RedditResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, int) (*reddit.RedditResponse, error)); ok {
-		return returnFunc(subreddit, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, reddit.ListingOptions) (*reddit.RedditResponse, error)); ok {
+		return returnFunc(ctx, subreddit, limit, options)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, int) *reddit.RedditResponse); ok {
-		r0 = returnFunc(subreddit, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, reddit.ListingOptions) *reddit.RedditResponse); ok {
+		r0 = returnFunc(ctx, subreddit, limit, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*reddit.RedditResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, int) error); ok {
-		r1 = returnFunc(subreddit, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, reddit.ListingOptions) error); ok {
+		r1 = returnFunc(ctx, subreddit, limit, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,25 +300,37 @@ type MockRedditService_GetPosts_Call struct {
 }
 
 // GetPosts is a helper method to define mock.On call
+//   - ctx context.Context
 //   - subreddit string
 //   - limit int
-func (_e *MockRedditService_Expecter) GetPosts(subreddit interface{}, limit interface{}) *MockRedditService_GetPosts_Call {
-	return &MockRedditService_GetPosts_Call{Call: _e.mock.On("GetPosts", subreddit, limit)}
+//   - options reddit.ListingOptions
+func (_e *MockRedditService_Expecter) GetPosts(ctx interface{}, subreddit interface{}, limit interface{}, options interface{}) *MockRedditService_GetPosts_Call {
+	return &MockRedditService_GetPosts_Call{Call: _e.mock.On("GetPosts", ctx, subreddit, limit, options)}
 }
 
-func (_c *MockRedditService_GetPosts_Call) Run(run func(subreddit string, limit int)) *MockRedditService_GetPosts_Call {
+func (_c *MockRedditService_GetPosts_Call) Run(run func(ctx context.Context, subreddit string, limit int, options reddit.ListingOptions)) *MockRedditService_GetPosts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 reddit.ListingOptions
+		if args[3] != nil {
+			arg3 = args[3].(reddit.ListingOptions)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -99,14 +341,94 @@ func (_c *MockRedditService_GetPosts_Call) Return(redditResponse *reddit.RedditR
 	return _c
 }
 
-func (_c *MockRedditService_GetPosts_Call) RunAndReturn(run func(subreddit string, limit int) (*reddit.RedditResponse, error)) *MockRedditService_GetPosts_Call {
+func (_c *MockRedditService_GetPosts_Call) RunAndReturn(run func(ctx context.Context, subreddit string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error)) *MockRedditService_GetPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserPosts provides a mock function for the type MockRedditService
+func (_mock *MockRedditService) GetUserPosts(ctx context.Context, username string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error) {
+	ret := _mock.Called(ctx, username, limit, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserPosts")
+	}
+
+	var r0 *reddit.RedditResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, reddit.ListingOptions) (*reddit.RedditResponse, error)); ok {
+		return returnFunc(ctx, username, limit, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, reddit.ListingOptions) *reddit.RedditResponse); ok {
+		r0 = returnFunc(ctx, username, limit, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reddit.RedditResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, reddit.ListingOptions) error); ok {
+		r1 = returnFunc(ctx, username, limit, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRedditService_GetUserPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserPosts'
+type MockRedditService_GetUserPosts_Call struct {
+	*mock.Call
+}
+
+// GetUserPosts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - limit int
+//   - options reddit.ListingOptions
+func (_e *MockRedditService_Expecter) GetUserPosts(ctx interface{}, username interface{}, limit interface{}, options interface{}) *MockRedditService_GetUserPosts_Call {
+	return &MockRedditService_GetUserPosts_Call{Call: _e.mock.On("GetUserPosts", ctx, username, limit, options)}
+}
+
+func (_c *MockRedditService_GetUserPosts_Call) Run(run func(ctx context.Context, username string, limit int, options reddit.ListingOptions)) *MockRedditService_GetUserPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 reddit.ListingOptions
+		if args[3] != nil {
+			arg3 = args[3].(reddit.ListingOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedditService_GetUserPosts_Call) Return(redditResponse *reddit.RedditResponse, err error) *MockRedditService_GetUserPosts_Call {
+	_c.Call.Return(redditResponse, err)
+	return _c
+}
+
+func (_c *MockRedditService_GetUserPosts_Call) RunAndReturn(run func(ctx context.Context, username string, limit int, options reddit.ListingOptions) (*reddit.RedditResponse, error)) *MockRedditService_GetUserPosts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // SearchPosts provides a mock function for the type MockRedditService
-func (_mock *MockRedditService) SearchPosts(subreddit string, query string, limit int) (*reddit.RedditResponse, error) {
-	ret := _mock.Called(subreddit, query, limit)
+func (_mock *MockRedditService) SearchPosts(ctx context.Context, subreddit string, query string, limit int, options reddit.SearchOptions) (*reddit.RedditResponse, error) {
+	ret := _mock.Called(ctx, subreddit, query, limit, options)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SearchPosts")
@@ -114,18 +436,18 @@ func (_mock *MockRedditService) SearchPosts(subreddit string, query string, limi
 
 	var r0 *reddit.RedditResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, int) (*reddit.RedditResponse, error)); ok {
-		return returnFunc(subreddit, query, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, reddit.SearchOptions) (*reddit.RedditResponse, error)); ok {
+		return returnFunc(ctx, subreddit, query, limit, options)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, int) *reddit.RedditResponse); ok {
-		r0 = returnFunc(subreddit, query, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, reddit.SearchOptions) *reddit.RedditResponse); ok {
+		r0 = returnFunc(ctx, subreddit, query, limit, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*reddit.RedditResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, int) error); ok {
-		r1 = returnFunc(subreddit, query, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, reddit.SearchOptions) error); ok {
+		r1 = returnFunc(ctx, subreddit, query, limit, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -138,31 +460,43 @@ type MockRedditService_SearchPosts_Call struct {
 }
 
 // SearchPosts is a helper method to define mock.On call
+//   - ctx context.Context
 //   - subreddit string
 //   - query string
 //   - limit int
-func (_e *MockRedditService_Expecter) SearchPosts(subreddit interface{}, query interface{}, limit interface{}) *MockRedditService_SearchPosts_Call {
-	return &MockRedditService_SearchPosts_Call{Call: _e.mock.On("SearchPosts", subreddit, query, limit)}
+//   - options reddit.SearchOptions
+func (_e *MockRedditService_Expecter) SearchPosts(ctx interface{}, subreddit interface{}, query interface{}, limit interface{}, options interface{}) *MockRedditService_SearchPosts_Call {
+	return &MockRedditService_SearchPosts_Call{Call: _e.mock.On("SearchPosts", ctx, subreddit, query, limit, options)}
 }
 
-func (_c *MockRedditService_SearchPosts_Call) Run(run func(subreddit string, query string, limit int)) *MockRedditService_SearchPosts_Call {
+func (_c *MockRedditService_SearchPosts_Call) Run(run func(ctx context.Context, subreddit string, query string, limit int, options reddit.SearchOptions)) *MockRedditService_SearchPosts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 int
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 reddit.SearchOptions
+		if args[4] != nil {
+			arg4 = args[4].(reddit.SearchOptions)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -173,7 +507,81 @@ func (_c *MockRedditService_SearchPosts_Call) Return(redditResponse *reddit.Redd
 	return _c
 }
 
-func (_c *MockRedditService_SearchPosts_Call) RunAndReturn(run func(subreddit string, query string, limit int) (*reddit.RedditResponse, error)) *MockRedditService_SearchPosts_Call {
+func (_c *MockRedditService_SearchPosts_Call) RunAndReturn(run func(ctx context.Context, subreddit string, query string, limit int, options reddit.SearchOptions) (*reddit.RedditResponse, error)) *MockRedditService_SearchPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSubreddits provides a mock function for the type MockRedditService
+func (_mock *MockRedditService) SearchSubreddits(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error) {
+	ret := _mock.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSubreddits")
+	}
+
+	var r0 *reddit.SubredditListing
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*reddit.SubredditListing, error)); ok {
+		return returnFunc(ctx, query, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *reddit.SubredditListing); ok {
+		r0 = returnFunc(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reddit.SubredditListing)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRedditService_SearchSubreddits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSubreddits'
+type MockRedditService_SearchSubreddits_Call struct {
+	*mock.Call
+}
+
+// SearchSubreddits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+func (_e *MockRedditService_Expecter) SearchSubreddits(ctx interface{}, query interface{}, limit interface{}) *MockRedditService_SearchSubreddits_Call {
+	return &MockRedditService_SearchSubreddits_Call{Call: _e.mock.On("SearchSubreddits", ctx, query, limit)}
+}
+
+func (_c *MockRedditService_SearchSubreddits_Call) Run(run func(ctx context.Context, query string, limit int)) *MockRedditService_SearchSubreddits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedditService_SearchSubreddits_Call) Return(subredditListing *reddit.SubredditListing, err error) *MockRedditService_SearchSubreddits_Call {
+	_c.Call.Return(subredditListing, err)
+	return _c
+}
+
+func (_c *MockRedditService_SearchSubreddits_Call) RunAndReturn(run func(ctx context.Context, query string, limit int) (*reddit.SubredditListing, error)) *MockRedditService_SearchSubreddits_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,10 +4,23 @@ import './App.css'
 import SubredditsList from './components/SubredditsList'
 import { searchRedditPosts } from './services/api'
 
+// Posts of subreddits, Reddit users and domains come from Reddit, the other sources do not
+const isRedditSource = (source) =>
+  !source || source.startsWith('r/') || source.startsWith('user:') || source.startsWith('domain:')
+
 function App() {
   const [searchMethod, setSearchMethod] = useState('search')
+  const [listingSort, setListingSort] = useState('new')
+  const [searchSort, setSearchSort] = useState('relevance')
+  const [timeWindow, setTimeWindow] = useState('')
   const [topic, setTopic] = useState('')
+  const [excludeTopics, setExcludeTopics] = useState('')
+  const [sources, setSources] = useState('')
+  const [expandQuery, setExpandQuery] = useState(false)
+  const [deduplicate, setDeduplicate] = useState(false)
+  const [analyzeSentiment, setAnalyzeSentiment] = useState(false)
   const [subreddits, setSubreddits] = useState(['golang'])
+  const [scope, setScope] = useState('subreddit')
   const [limit, setLimit] = useState(1)
   const [threshold, setThreshold] = useState(0.5)
   const [createdAfter, setCreatedAfter] = useState('')
@@ -15,6 +28,12 @@ function App() {
   const [error, setError] = useState(null)
   const [results, setResults] = useState(null)
 
+  // Reddit only restricts search results and the top and controversial listings to a time window
+  const usesTimeWindow =
+    searchMethod === 'search' ||
+    listingSort === 'top' ||
+    listingSort === 'controversial'
+
   const handleSubmit = async (e) => {
     e.preventDefault()
     setError(null)
@@ -24,11 +43,26 @@ function App() {
     try {
       const response = await searchRedditPosts({
         topic,
-        subreddits,
+        exclude_topics: excludeTopics
+          .split(',')
+          .map((t) => t.trim())
+          .filter(Boolean),
+        subreddits: scope === 'all' || subreddits.length === 0 ? null : subreddits,
+        sources: sources
+          .split(',')
+          .map((s) => s.trim())
+          .filter(Boolean),
+        scope,
         limit,
         relevance_threshold: threshold,
         created_after: createdAfter || null,
-        search_method: searchMethod,
+        search_method: searchMethod === 'search' ? 'search' : listingSort,
+        search_sort: searchMethod === 'search' ? searchSort : '',
+        time_window: usesTimeWindow ? timeWindow : '',
+        expand_query: searchMethod === 'search' && expandQuery,
+        deduplicate,
+        analyze_sentiment: analyzeSentiment,
+        sentiment_comments: analyzeSentiment ? 10 : 0,
       })
       setResults(response)
     } catch (err) {
@@ -63,15 +97,66 @@ function App() {
               <input
                 type="radio"
                 name="searchMethod"
-                value="latest"
-                checked={searchMethod === 'latest'}
+                value="listing"
+                checked={searchMethod === 'listing'}
                 onChange={(e) => setSearchMethod(e.target.value)}
               />
-              <span>Latest Posts</span>
+              <span>Subreddit Listing</span>
             </label>
           </div>
         </div>
 
+        <div className="form-row">
+          {searchMethod === 'search' ? (
+            <div className="form-group">
+              <label htmlFor="searchSort">Sort Results By</label>
+              <select
+                id="searchSort"
+                value={searchSort}
+                onChange={(e) => setSearchSort(e.target.value)}
+              >
+                <option value="relevance">Relevance</option>
+                <option value="new">New</option>
+                <option value="comments">Comments</option>
+              </select>
+            </div>
+          ) : (
+            <div className="form-group">
+              <label htmlFor="listingSort">Listing</label>
+              <select
+                id="listingSort"
+                value={listingSort}
+                onChange={(e) => setListingSort(e.target.value)}
+              >
+                <option value="new">New</option>
+                <option value="hot">Hot</option>
+                <option value="top">Top</option>
+                <option value="rising">Rising</option>
+                <option value="controversial">Controversial</option>
+              </select>
+            </div>
+          )}
+
+          {usesTimeWindow && (
+            <div className="form-group">
+              <label htmlFor="timeWindow">Time Window</label>
+              <select
+                id="timeWindow"
+                value={timeWindow}
+                onChange={(e) => setTimeWindow(e.target.value)}
+              >
+                <option value="">Reddit default</option>
+                <option value="hour">Past hour</option>
+                <option value="day">Past day</option>
+                <option value="week">Past week</option>
+                <option value="month">Past month</option>
+                <option value="year">Past year</option>
+                <option value="all">All time</option>
+              </select>
+            </div>
+          )}
+        </div>
+
         <div className="form-group">
           <label htmlFor="topic">Topic *</label>
           <input
@@ -82,24 +167,77 @@ function App() {
             placeholder="e.g., CLI application development"
             required
           />
-          {searchMethod === 'latest' && (
+          {searchMethod === 'listing' && (
             <small className="field-hint">
-              Topic is used for relevance evaluation even when fetching latest posts
+              Topic is used for relevance evaluation even when fetching listings
             </small>
           )}
+          {searchMethod === 'search' && (
+            <label className="radio-option">
+              <input
+                type="checkbox"
+                checked={expandQuery}
+                onChange={(e) => setExpandQuery(e.target.checked)}
+              />
+              <span>Expand the topic into related search queries</span>
+            </label>
+          )}
         </div>
 
         <div className="form-group">
-          <label>Subreddits *</label>
-          <SubredditsList
-            subreddits={subreddits}
-            onChange={setSubreddits}
+          <label htmlFor="excludeTopics">Exclude Topics (Optional)</label>
+          <input
+            type="text"
+            id="excludeTopics"
+            value={excludeTopics}
+            onChange={(e) => setExcludeTopics(e.target.value)}
+            placeholder="e.g., job postings, course promotions"
           />
+          <small className="field-hint">
+            Comma-separated. Posts about these topics get a lower relevance score
+          </small>
         </div>
 
+        <div className="form-group">
+          <label htmlFor="scope">Scope</label>
+          <select id="scope" value={scope} onChange={(e) => setScope(e.target.value)}>
+            <option value="subreddit">Each subreddit separately</option>
+            <option value="multireddit">All subreddits in one request</option>
+            <option value="all">All of Reddit</option>
+          </select>
+        </div>
+
+        <div className="form-group">
+          <label htmlFor="sources">Other Sources (Optional)</label>
+          <input
+            type="text"
+            id="sources"
+            value={sources}
+            onChange={(e) => setSources(e.target.value)}
+            placeholder="e.g., user:spez, domain:go.dev, hackernews:story, lemmy:golang@programming.dev"
+          />
+          <small className="field-hint">
+            Comma-separated. Reddit users and domains, Hacker News tags, feed:{'{url}'} for RSS
+            and Atom feeds and Lemmy communities
+          </small>
+        </div>
+
+        {scope !== 'all' && (
+          <div className="form-group">
+            <label>Subreddits *</label>
+            <SubredditsList
+              subreddits={subreddits}
+              onChange={setSubreddits}
+              topic={topic}
+            />
+          </div>
+        )}
+
         <div className="form-row">
           <div className="form-group">
-            <label htmlFor="limit">Limit Posts per Subreddit</label>
+            <label htmlFor="limit">
+              {scope === 'subreddit' ? 'Limit Posts per Subreddit' : 'Limit Posts'}
+            </label>
             <input
               type="number"
               id="limit"
@@ -124,6 +262,28 @@ function App() {
           </div>
         </div>
 
+        <div className="form-group">
+          <label className="radio-option">
+            <input
+              type="checkbox"
+              checked={deduplicate}
+              onChange={(e) => setDeduplicate(e.target.checked)}
+            />
+            <span>Group crossposts and duplicate stories</span>
+          </label>
+        </div>
+
+        <div className="form-group">
+          <label className="radio-option">
+            <input
+              type="checkbox"
+              checked={analyzeSentiment}
+              onChange={(e) => setAnalyzeSentiment(e.target.checked)}
+            />
+            <span>Analyze the sentiment of relevant posts and their top comments</span>
+          </label>
+        </div>
+
         <div className="form-group">
           <label htmlFor="createdAfter">Created After (Optional)</label>
           <input
@@ -137,7 +297,11 @@ function App() {
         <button
           type="submit"
           className="submit-button"
-          disabled={loading || !topic || subreddits.length === 0}
+          disabled={
+            loading ||
+            !topic ||
+            (scope !== 'all' && subreddits.length === 0 && !sources.trim())
+          }
         >
           {loading ? 'Searching...' : 'Search Posts'}
         </button>
@@ -154,6 +318,13 @@ function App() {
             <p>
               Found {results.posts?.length || 0} posts matching your criteria
             </p>
+            {results.sentiment_by_subreddit?.map((totals) => (
+              <p key={totals.subreddit_name} className="post-sentiment">
+                {totals.subreddit_name}: {totals.posts.sentiments.positive} positive,{' '}
+                {totals.posts.sentiments.negative} negative, {totals.posts.sentiments.neutral} neutral,{' '}
+                {totals.posts.sentiments.mixed} mixed of {totals.posts.total} relevant posts
+              </p>
+            ))}
           </div>
           {results.posts && results.posts.length > 0 ? (
             <div className="posts-list">
@@ -175,7 +346,12 @@ function App() {
                           {post.is_relevant ? '✓ Relevant' : '✗ Not Relevant'}
                         </span>
                       )}
-                      <span className="subreddit-name">r/{post.subreddit_name}</span>
+                      <span className="subreddit-name">
+                        {isRedditSource(post.source) ? `r/${post.subreddit_name}` : post.subreddit_name}
+                      </span>
+                      {post.source && !post.source.startsWith('r/') && (
+                        <span className="subreddit-name">via {post.source}</span>
+                      )}
                     </div>
                   </div>
                   {post.content && (
@@ -197,6 +373,31 @@ function App() {
                       <p>{post.relevance_summary}</p>
                     </div>
                   )}
+                  {post.duplicates?.length > 0 && (
+                    <div className="post-duplicates">
+                      Also posted in {post.subreddits.slice(1).join(', ') || post.subreddit_name}
+                      {' '}({post.duplicates.length} duplicate{post.duplicates.length > 1 ? 's' : ''},
+                      combined score {post.combined_score})
+                    </div>
+                  )}
+                  {post.media && (
+                    <div className="post-media">
+                      {post.media.kind === 'gallery'
+                        ? `Gallery of ${post.media.items?.length ?? 0} images`
+                        : post.media.kind === 'video' ? 'Video' : 'Image'}
+                      {post.media.generated_caption && <>: {post.media.generated_caption}</>}
+                    </div>
+                  )}
+                  {post.sentiment && (
+                    <div className="post-sentiment">
+                      Sentiment: {post.sentiment.sentiment}, stance: {post.sentiment.stance}
+                      {post.sentiment.comments && (
+                        <> ({post.sentiment.comments.sentiments.positive} positive and{' '}
+                        {post.sentiment.comments.sentiments.negative} negative of{' '}
+                        {post.sentiment.comments.total} top comments)</>
+                      )}
+                    </div>
+                  )}
                   <div className="post-meta">
                     <span>Score: {post.score}</span>
                     <span>Comments: {post.num_comments}</span>
@@ -208,13 +409,19 @@ function App() {
                         Relevance Score: <strong>{(post.relevance_score * 100).toFixed(1)}%</strong>
                       </span>
                     )}
+                    {post.exclusion_scores?.map((exclusion) => (
+                      <span key={exclusion.topic} className="relevance-score-badge">
+                        Exclusion “{exclusion.topic}”:{' '}
+                        <strong>{(exclusion.score * 100).toFixed(1)}%</strong>
+                      </span>
+                    ))}
                     <a
                       href={post.url}
                       target="_blank"
                       rel="noopener noreferrer"
                       className="reddit-link"
                     >
-                      View on Reddit →
+                      {isRedditSource(post.source) ? 'View on Reddit →' : 'View post →'}
                     </a>
                   </div>
                 </div>
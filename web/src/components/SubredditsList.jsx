@@ -1,10 +1,34 @@
 import React, { useState } from 'react'
+import { suggestSubreddits } from '../services/api'
 import './SubredditsList.css'
 
-function SubredditsList({ subreddits, onChange }) {
+function SubredditsList({ subreddits, onChange, topic }) {
   const [newSubreddit, setNewSubreddit] = useState('')
   const [editingIndex, setEditingIndex] = useState(null)
   const [editValue, setEditValue] = useState('')
+  const [suggestions, setSuggestions] = useState([])
+  const [suggesting, setSuggesting] = useState(false)
+  const [suggestError, setSuggestError] = useState(null)
+
+  const handleSuggest = async () => {
+    setSuggesting(true)
+    setSuggestError(null)
+    try {
+      setSuggestions(await suggestSubreddits(topic.trim()))
+    } catch (err) {
+      setSuggestError(err.message)
+      setSuggestions([])
+    } finally {
+      setSuggesting(false)
+    }
+  }
+
+  const handleAddSuggestion = (name) => {
+    const normalized = name.toLowerCase()
+    if (!subreddits.includes(normalized)) {
+      onChange([...subreddits, normalized])
+    }
+  }
 
   const handleAdd = () => {
     const trimmed = newSubreddit.trim().toLowerCase()
@@ -65,8 +89,41 @@ function SubredditsList({ subreddits, onChange }) {
         >
           Add
         </button>
+        <button
+          type="button"
+          onClick={handleSuggest}
+          className="suggest-button"
+          disabled={suggesting || !topic?.trim()}
+          title="Suggest subreddits for the topic"
+        >
+          {suggesting ? 'Suggesting...' : 'Suggest'}
+        </button>
       </div>
 
+      {suggestError && <p className="suggestions-error">{suggestError}</p>}
+
+      {suggestions.length > 0 && (
+        <div className="subreddit-suggestions">
+          {suggestions.map((suggestion) => (
+            <button
+              key={suggestion.name}
+              type="button"
+              onClick={() => handleAddSuggestion(suggestion.name)}
+              className="subreddit-suggestion"
+              disabled={subreddits.includes(suggestion.name.toLowerCase())}
+              title={suggestion.description || suggestion.title}
+            >
+              <span className="subreddit-name">r/{suggestion.name}</span>
+              <span className="suggestion-stats">
+                {suggestion.subscribers.toLocaleString()} members
+                {suggestion.active_users > 0 &&
+                  ` · ${suggestion.active_users.toLocaleString()} online`}
+              </span>
+            </button>
+          ))}
+        </div>
+      )}
+
       <div className="subreddits-tags">
         {subreddits.map((subreddit, index) => (
           <div key={index} className="subreddit-tag">
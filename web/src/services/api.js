@@ -2,6 +2,7 @@ import axios from 'axios'
 
 const API_BASE_URL = '/v1'
 
+// The API key the backend requires with auth.enabled is added by the proxy in front of it
 const api = axios.create({
   baseURL: API_BASE_URL,
   headers: {
@@ -14,7 +15,10 @@ export const searchRedditPosts = async (params) => {
     // Format the request according to the backend DTO
     const requestData = {
       topic: params.topic || '',
+      exclude_topics: params.exclude_topics || [],
       subreddits: params.subreddits,
+      scope: params.scope || 'subreddit',
+      sources: params.sources || [],
       relevance_threshold: params.relevance_threshold || 0.5,
       limit: params.limit || 25,
       created_after: params.created_after
@@ -22,20 +26,40 @@ export const searchRedditPosts = async (params) => {
         : null,
       min_num_comments: 0, // Default value
       search_method: params.search_method || 'search',
+      search_sort: params.search_sort || '',
+      time_window: params.time_window || '',
+      expand_query: params.expand_query || false,
     }
 
     const response = await api.post('/reddit/relevance/search', requestData)
     return response.data
   } catch (error) {
-    if (error.response) {
-      throw new Error(
-        error.response.data?.error || 'Failed to search Reddit posts'
-      )
-    } else if (error.request) {
-      throw new Error('No response from server. Is the backend running?')
-    } else {
-      throw new Error(error.message || 'An error occurred')
-    }
+    throw toError(error, 'Failed to search Reddit posts')
+  }
+}
+
+export const suggestSubreddits = async (topic, limit = 10) => {
+  try {
+    const response = await api.get('/reddit/subreddits/suggestions', {
+      params: { topic, limit },
+    })
+    return response.data.suggestions || []
+  } catch (error) {
+    throw toError(error, 'Failed to suggest subreddits')
   }
 }
 
+// toError turns a failed request into an Error with a readable message
+const toError = (error, fallback) => {
+  if (error.response) {
+    // Errors are RFC 7807 problem+json bodies
+    const problem = error.response.data
+    const fieldErrors = (problem?.errors || [])
+      .map((fieldError) => `${fieldError.field} ${fieldError.message}`)
+      .join('; ')
+    return new Error(fieldErrors || problem?.detail || problem?.title || fallback)
+  } else if (error.request) {
+    return new Error('No response from server. Is the backend running?')
+  }
+  return new Error(error.message || 'An error occurred')
+}
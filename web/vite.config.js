@@ -8,7 +8,9 @@ export default defineConfig({
     proxy: {
       '/v1': {
         target: 'http://localhost:8080',
-        changeOrigin: true
+        changeOrigin: true,
+        // Added here rather than in the browser so the key never reaches the bundle
+        headers: process.env.API_KEY ? { 'X-API-Key': process.env.API_KEY } : {}
       }
     }
   }